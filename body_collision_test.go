@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"ebiten-platformer/entities"
+	"ebiten-platformer/level"
+)
+
+// TestBodyCollision verifies a plain physics body gets the same collision handling as the player
+func TestBodyCollision(t *testing.T) {
+	testLevel := level.NewLevel(20, 12, 32, "Body Test")
+	for x := 0; x < testLevel.Width; x++ {
+		testLevel.SetTile(x, 10, level.TileSolid) // Floor top at Y=320
+	}
+	testLevel.SetTile(2, 5, level.TileSolid)         // Single-tile platform, top at Y=160
+	testLevel.SetTile(10, 9, level.TileSolid)        // Wall, left side at X=320
+	testLevel.SetTile(14, 7, level.TileOneWay)       // One-way platform, top at Y=224
+	testLevel.SetTile(17, 9, level.TileSlopeRight45) // Slope rising to the right
+
+	testCases := []struct {
+		name                 string
+		x, y                 float64
+		velocityX, velocityY float64
+		gravity              float64
+		ignoreOneWay         bool
+		wantX, wantY         float64
+	}{
+		{"Dropped crate lands on a platform", 64, 0, 0, 0, 500, false, 64, 128},
+		{"Fast debris doesn't tunnel", 64, 0, 0, 5000, 500, false, 64, 128},
+		{"Thrown crate stops at a wall", 200, 288, 2000, 0, 500, false, 288, 288},
+		{"Crate lands on a one-way platform", 448, 100, 0, 0, 500, false, 448, 192},
+		{"Debris falls through one-way platforms", 448, 100, 0, 0, 500, true, 448, 288},
+		{"Crate lands on a slope", 544, 200, 0, 0, 500, false, 544, 272},
+		{"Flying body ignores gravity", 64, 32, 0, 0, 0, false, 64, 32},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := entities.NewBody(tc.x, tc.y, 32, 32, tc.gravity)
+			body.VelocityX, body.VelocityY = tc.velocityX, tc.velocityY
+			body.IgnoreOneWay = tc.ignoreOneWay
+
+			for i := 0; i < 180; i++ {
+				body.Update(testLevel, 1.0/60.0)
+				body.VelocityX *= 0.9
+			}
+
+			x, y := body.GetPosition()
+			fmt.Printf("%s: came to rest at (%.1f, %.1f), on ground=%v\n", tc.name, x, y, body.OnGround)
+			if math.Abs(x-tc.wantX) > 0.5 || math.Abs(y-tc.wantY) > 0.5 {
+				t.Errorf("%s: Expected to come to rest at (%.0f, %.0f), got (%.2f, %.2f)", tc.name, tc.wantX, tc.wantY, x, y)
+			}
+			if tc.gravity > 0 && !body.OnGround {
+				t.Errorf("%s: Expected the body to be on the ground", tc.name)
+			}
+		})
+	}
+}
+
+// TestBodyMatchesPlayer verifies a body and the player land in the same place
+func TestBodyMatchesPlayer(t *testing.T) {
+	testLevel := level.NewLevel(10, 10, 32, "Body Player Test")
+	testLevel.SetTile(2, 5, level.TileSolid)
+
+	player := entities.NewPlayer(64, 0, entities.CreateTestSpriteSheet())
+	player.SetLevel(testLevel)
+	body := entities.NewBody(64, 0, player.Width, player.Height, player.Gravity)
+
+	for i := 0; i < 120; i++ {
+		player.Update(1.0 / 60.0)
+		body.Update(testLevel, 1.0/60.0)
+		if player.Y != body.Y || player.OnGround != body.OnGround {
+			t.Fatalf("Frame %d: player at Y=%.2f (ground=%v), body at Y=%.2f (ground=%v)",
+				i, player.Y, player.OnGround, body.Y, body.OnGround)
+		}
+	}
+}
+
+// TestPlayerUpdateDoesNotAllocate verifies collision checks reuse the player's result
+func TestPlayerUpdateDoesNotAllocate(t *testing.T) {
+	testLevel := level.CreateTestLevel()
+	player := entities.NewPlayer(64, 512, entities.CreateTestSpriteSheet())
+	player.SetLevel(testLevel)
+
+	// Warm up so the contact list has grown to its working size
+	for i := 0; i < 60; i++ {
+		player.MoveRight()
+		player.Update(1.0 / 60.0)
+	}
+
+	allocs := testing.AllocsPerRun(120, func() {
+		player.MoveRight()
+		player.Update(1.0 / 60.0)
+	})
+	fmt.Printf("Player update: %.1f allocations per frame\n", allocs)
+	if allocs != 0 {
+		t.Errorf("Expected player updates not to allocate, got %v allocations per frame", allocs)
+	}
+}
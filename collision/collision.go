@@ -0,0 +1,87 @@
+// Package collision holds the collision types shared by the level and the entities that
+// move through it, so neither has to copy results into its own types.
+package collision
+
+// Result represents the result of a collision check
+type Result struct {
+	Collided         bool
+	CollisionX       bool      // True if collision occurred on X axis
+	CollisionY       bool      // True if collision occurred on Y axis
+	PenetrationX     float64   // How much the entity penetrated on X axis
+	PenetrationY     float64   // How much the entity penetrated on Y axis
+	OnGround         bool      // True if the entity is standing on solid ground
+	TouchingWall     bool      // True if the entity is touching a wall
+	ClimbableSurface bool      // True if touching a climbable surface
+	DangerousTile    bool      // True if touching a dangerous tile
+	OneWayPlatform   bool      // True if touching a one-way platform from above
+	OnSlope          bool      // True if standing on a sloped tile
+	SlopeGradient    float64   // Surface Y change per pixel moved right while on a slope
+	SurfaceY         float64   // World Y of the slope surface under the entity's centre
+	OneWaySurface    bool      // True if a one-way platform's top edge lies inside the entity's box
+	OneWaySurfaceY   float64   // World Y of the highest such one-way platform top
+	Material         Material  // Material under the entity's feet while OnGround (DefaultMaterial otherwise)
+	Contacts         []Contact // Tiles the entity overlaps or stands on
+}
+
+// Contact is a tile an entity touches and which way the tile pushes it
+type Contact struct {
+	TileX, TileY     int     // Grid coordinates of the tile
+	NormalX, NormalY float64 // Unit direction out of the tile's surface; zero for tiles that don't block, like spikes
+}
+
+// Reset clears a result for reuse, keeping the Contacts slice's storage
+func (r *Result) Reset() {
+	*r = Result{Material: DefaultMaterial, Contacts: r.Contacts[:0]}
+}
+
+// AddContact records a touched tile. A tile already recorded keeps its first normal
+// unless that didn't block.
+func (r *Result) AddContact(tileX, tileY int, normalX, normalY float64) {
+	for i := range r.Contacts {
+		contact := &r.Contacts[i]
+		if contact.TileX == tileX && contact.TileY == tileY {
+			if contact.NormalX == 0 && contact.NormalY == 0 {
+				contact.NormalX, contact.NormalY = normalX, normalY
+			}
+			return
+		}
+	}
+	r.Contacts = append(r.Contacts, Contact{TileX: tileX, TileY: tileY, NormalX: normalX, NormalY: normalY})
+}
+
+// Touches returns whether a tile is among the result's contacts
+func (r *Result) Touches(tileX, tileY int) bool {
+	for _, contact := range r.Contacts {
+		if contact.TileX == tileX && contact.TileY == tileY {
+			return true
+		}
+	}
+	return false
+}
+
+// SweepResult describes where a box moved through the level first touched a tile
+type SweepResult struct {
+	Hit          bool    // True if the box touched a tile before completing the move
+	Time         float64 // Fraction of the move completed at the contact (1 when nothing was hit)
+	X, Y         float64 // Position of the box at the contact, or at the end of the move
+	NormalX      float64 // Contact normal, pointing out of the surface that was hit
+	NormalY      float64
+	TileX, TileY int // Grid coordinates of the tile that was hit
+}
+
+// Checker is anything entities can collide with, such as a level
+type Checker interface {
+	// CheckCollisionInto fills result with what a box at (entityX, entityY) touches. The
+	// result is reset first and its Contacts storage reused, so checks don't allocate.
+	CheckCollisionInto(entityX, entityY, entityWidth, entityHeight float64, result *Result)
+
+	// Sweep moves a box by (deltaX, deltaY) and stops it where it first touches the level.
+	// One-way platforms only stop a falling box when landOnOneWay is set.
+	Sweep(entityX, entityY, entityWidth, entityHeight, deltaX, deltaY float64, landOnOneWay bool) SweepResult
+}
+
+// SightChecker is anything that can block an entity's view, such as a level
+type SightChecker interface {
+	// LineOfSight returns whether nothing blocks the straight line from (x0, y0) to (x1, y1)
+	LineOfSight(x0, y0, x1, y1 float64) bool
+}
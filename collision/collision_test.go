@@ -0,0 +1,52 @@
+package collision
+
+import "testing"
+
+func TestResult_Reset(t *testing.T) {
+	result := Result{Collided: true, OnGround: true, Material: Material{Name: "ice"}}
+	result.AddContact(1, 2, 0, -1)
+	storage := &result.Contacts[:1][0]
+
+	result.Reset()
+	if result.Collided || result.OnGround || len(result.Contacts) != 0 {
+		t.Errorf("Expected a cleared result, got %+v", result)
+	}
+	if result.Material != DefaultMaterial {
+		t.Errorf("Expected the default material after a reset, got %+v", result.Material)
+	}
+
+	result.AddContact(3, 4, 1, 0)
+	if &result.Contacts[0] != storage {
+		t.Error("Expected Reset to keep the contact storage")
+	}
+}
+
+func TestResult_AddContact(t *testing.T) {
+	var result Result
+	result.AddContact(1, 1, 0, 0)  // Touched without blocking
+	result.AddContact(1, 1, 0, -1) // Later found to be ground
+	result.AddContact(1, 1, 1, 0)  // Already blocking, ignored
+	result.AddContact(2, 1, -1, 0)
+
+	want := []Contact{{TileX: 1, TileY: 1, NormalY: -1}, {TileX: 2, TileY: 1, NormalX: -1}}
+	if len(result.Contacts) != len(want) {
+		t.Fatalf("Expected %d contacts, got %+v", len(want), result.Contacts)
+	}
+	for i := range want {
+		if result.Contacts[i] != want[i] {
+			t.Errorf("Contact %d: expected %+v, got %+v", i, want[i], result.Contacts[i])
+		}
+	}
+}
+
+func TestResult_Touches(t *testing.T) {
+	var result Result
+	result.AddContact(5, 6, 0, -1)
+
+	if !result.Touches(5, 6) {
+		t.Error("Expected to touch tile (5, 6)")
+	}
+	if result.Touches(6, 5) {
+		t.Error("Didn't expect to touch tile (6, 5)")
+	}
+}
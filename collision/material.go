@@ -0,0 +1,15 @@
+package collision
+
+// Material is the physical surface of a tile and changes how entities move while standing on it
+type Material struct {
+	Name          string  // Material name used by level data
+	Friction      float64 // Ground friction relative to normal ground (1 is normal, lower is slippery)
+	Bounciness    float64 // Fraction of landing speed returned as upward speed
+	LaunchSpeed   float64 // Upward speed given to anything standing on it (px/s), for bounce pads
+	ConveyorSpeed float64 // Horizontal speed given to entities standing on it (px/s, positive is right)
+	SpeedScale    float64 // Multiplier on walking speed
+	JumpScale     float64 // Multiplier on jump speed
+}
+
+// DefaultMaterial is normal ground, reported in the air and when nothing else is known
+var DefaultMaterial = Material{Name: "default", Friction: 1, SpeedScale: 1, JumpScale: 1}
@@ -0,0 +1,161 @@
+package collision
+
+import "math"
+
+// Solid is a box that isn't part of the level's tiles but blocks entities like a solid tile,
+// such as a moving platform. Its owner moves it by changing its position.
+type Solid struct {
+	Rect
+	OneWay   bool     // Only stops boxes falling onto its top, like a one-way platform
+	Material Material // Surface of its top; a zero Material is normal ground
+}
+
+// Solids is a Checker that adds solids to another, such as a level. Entities given it in
+// place of the level collide with the level's tiles and the solids alike. Sweeps that hit
+// a solid report a TileX and TileY of -1.
+type Solids struct {
+	base   Checker
+	solids []*Solid
+}
+
+// NewSolids creates a checker that adds solids to base
+func NewSolids(base Checker) *Solids {
+	return &Solids{base: base}
+}
+
+// Add adds a solid. Solids are used where they are, so moving one needs no further call.
+func (s *Solids) Add(solid *Solid) {
+	s.solids = append(s.solids, solid)
+}
+
+// Remove removes a solid
+func (s *Solids) Remove(solid *Solid) {
+	for i, existing := range s.solids {
+		if existing == solid {
+			s.solids = append(s.solids[:i], s.solids[i+1:]...)
+			return
+		}
+	}
+}
+
+// CheckCollisionInto checks the base checker, then adds what the box touches among the
+// solids. Solids don't add Contacts, which are tiles.
+func (s *Solids) CheckCollisionInto(entityX, entityY, entityWidth, entityHeight float64, result *Result) {
+	s.base.CheckCollisionInto(entityX, entityY, entityWidth, entityHeight, result)
+
+	entityBottom := entityY + entityHeight
+	for _, solid := range s.solids {
+		overlapX := math.Min(entityX+entityWidth, solid.X+solid.Width) - math.Max(entityX, solid.X)
+		overlapY := math.Min(entityBottom, solid.Y+solid.Height) - math.Max(entityY, solid.Y)
+		if overlapX < 0 || overlapY < 0 {
+			continue
+		}
+
+		// Touching a side, like a wall
+		if overlapX == 0 {
+			if overlapY > 0 && !solid.OneWay {
+				result.TouchingWall = true
+			}
+			continue
+		}
+
+		// Standing on its top. Like tiles, it only holds the box up under half its width.
+		if entityBottom >= solid.Y && entityBottom <= solid.Y+GroundTolerance {
+			result.Collided = true
+			if solid.OneWay {
+				result.OneWayPlatform = true
+			}
+			if overlapX >= entityWidth*0.5 {
+				result.OnGround = true
+				if solid.Material.Name != "" {
+					result.Material = solid.Material
+				}
+			}
+			continue
+		}
+
+		if overlapY == 0 {
+			continue
+		}
+		if solid.OneWay {
+			if solid.Y >= entityY && !result.OneWaySurface {
+				result.OneWaySurface = true
+				result.OneWaySurfaceY = solid.Y
+			}
+			continue
+		}
+
+		// Inside it: report the shallower axis, which is the way out
+		result.Collided = true
+		if overlapX < overlapY {
+			result.CollisionX = true
+			result.PenetrationX = math.Max(result.PenetrationX, overlapX)
+		} else {
+			result.CollisionY = true
+			result.PenetrationY = math.Max(result.PenetrationY, overlapY)
+		}
+	}
+}
+
+// Sweep sweeps the box through the base checker and returns the earliest contact with it
+// or any solid. Like one-way platforms, one-way solids only stop a falling box when
+// landOnOneWay is set. A box already inside a solid isn't stopped by it.
+func (s *Solids) Sweep(entityX, entityY, entityWidth, entityHeight, deltaX, deltaY float64, landOnOneWay bool) SweepResult {
+	result := s.base.Sweep(entityX, entityY, entityWidth, entityHeight, deltaX, deltaY, landOnOneWay)
+	if deltaX == 0 && deltaY == 0 {
+		return result
+	}
+
+	for _, solid := range s.solids {
+		time, normalX, normalY, hit := solid.sweep(entityX, entityY, entityWidth, entityHeight, deltaX, deltaY)
+		if !hit || time > result.Time || (time == result.Time && result.Hit) {
+			continue
+		}
+		if solid.OneWay && (!landOnOneWay || normalY >= 0) {
+			continue
+		}
+		result = SweepResult{
+			Hit:     true,
+			Time:    time,
+			X:       entityX + deltaX*time,
+			Y:       entityY + deltaY*time,
+			NormalX: normalX,
+			NormalY: normalY,
+			TileX:   -1,
+			TileY:   -1,
+		}
+	}
+	return result
+}
+
+// LineOfSight returns whether the base checker's view is clear between two points. Solids
+// don't block sight, and a base that can't block sight never does.
+func (s *Solids) LineOfSight(x0, y0, x1, y1 float64) bool {
+	if sight, ok := s.base.(SightChecker); ok {
+		return sight.LineOfSight(x0, y0, x1, y1)
+	}
+	return true
+}
+
+// sweep returns the fraction of a move at which a box first touches the solid and the
+// normal of the side it touches. Feet up to GroundTolerance into the top still land
+// on it, and feet that close to the top are standing on it rather than against its side.
+func (s *Solid) sweep(x, y, width, height, deltaX, deltaY float64) (time, normalX, normalY float64, hit bool) {
+	sinkY := SweepEpsilon
+	if deltaY > 0 {
+		sinkY = GroundTolerance
+	} else if deltaY == 0 {
+		height -= GroundTolerance
+	}
+
+	entryX, exitX := SweepAxis(x, width, deltaX, s.X, s.Width, SweepEpsilon)
+	entryY, exitY := SweepAxis(y, height, deltaY, s.Y, s.Height, sinkY)
+	entry, exit := math.Max(entryX, entryY), math.Min(exitX, exitY)
+	if entry >= exit || entry < 0 || entry > 1 {
+		return 0, 0, 0, false
+	}
+	if entryX > entryY {
+		return entry, -math.Copysign(1, deltaX), 0, true
+	}
+	return entry, 0, -math.Copysign(1, deltaY), true
+}
@@ -0,0 +1,164 @@
+package collision
+
+import (
+	"math"
+	"testing"
+)
+
+// floorChecker is open space above a floor at floorY that blocks sight while blind is set
+type floorChecker struct {
+	floorY float64
+	blind  bool
+}
+
+func (f *floorChecker) CheckCollisionInto(x, y, width, height float64, result *Result) {
+	result.Reset()
+	bottom := y + height
+	result.OnGround = bottom >= f.floorY && bottom <= f.floorY+2
+}
+
+func (f *floorChecker) Sweep(x, y, width, height, deltaX, deltaY float64, landOnOneWay bool) SweepResult {
+	if deltaY > 0 && y+height+deltaY > f.floorY {
+		time := (f.floorY - y - height) / deltaY
+		return SweepResult{Hit: true, Time: time, X: x, Y: f.floorY - height, NormalY: -1, TileX: 1, TileY: 1}
+	}
+	return SweepResult{Time: 1, X: x + deltaX, Y: y + deltaY}
+}
+
+func (f *floorChecker) LineOfSight(x0, y0, x1, y1 float64) bool {
+	return !f.blind
+}
+
+// newSolidsTest returns a floor at Y=500 with a 100x20 solid whose top-left is at (100, 200)
+// and a one-way solid of the same size at (300, 200)
+func newSolidsTest() (*Solids, *Solid, *Solid) {
+	solids := NewSolids(&floorChecker{floorY: 500})
+	block := &Solid{Rect: Rect{X: 100, Y: 200, Width: 100, Height: 20}, Material: Material{Name: "ice", Friction: 0.1}}
+	oneWay := &Solid{Rect: Rect{X: 300, Y: 200, Width: 100, Height: 20}, OneWay: true}
+	solids.Add(block)
+	solids.Add(oneWay)
+	return solids, block, oneWay
+}
+
+func TestSolids_Sweep(t *testing.T) {
+	solids, _, _ := newSolidsTest()
+
+	tests := []struct {
+		name           string
+		x, y           float64
+		deltaX, deltaY float64
+		landOnOneWay   bool
+		wantHit        bool
+		wantX, wantY   float64
+		wantNX, wantNY float64
+		wantTileX      int
+	}{
+		{"falls onto the top", 120, 100, 0, 150, true, true, 120, 168, 0, -1, -1},
+		{"fast fall still lands", 120, -1000, 0, 5000, true, true, 120, 168, 0, -1, -1},
+		{"feet sunk into the top land", 120, 169.5, 0, 10, true, true, 120, 169.5, 0, -1, -1},
+		{"rises into the bottom", 120, 300, 0, -150, false, true, 120, 220, 0, 1, -1},
+		{"walks into the left side", 40, 190, 100, 0, false, true, 68, 190, -1, 0, -1},
+		{"walks into the right side", 250, 190, -100, 0, false, true, 200, 190, 1, 0, -1},
+		{"walks along the top", 120, 168, 200, 0, false, false, 320, 168, 0, 0, 0},
+		{"walks along feet slightly sunk", 80, 169, 100, 0, false, false, 180, 169, 0, 0, 0},
+		{"falls past the side", 50, 100, 0, 500, true, true, 50, 468, 0, -1, 1},
+		{"already inside passes out", 150, 195, 0, 100, true, false, 150, 295, 0, 0, 0},
+		{"lands on the one-way top", 320, 100, 0, 150, true, true, 320, 168, 0, -1, -1},
+		{"drops through the one-way top", 320, 100, 0, 150, false, false, 320, 250, 0, 0, 0},
+		{"rises through the one-way", 320, 300, 0, -150, false, false, 320, 150, 0, 0, 0},
+		{"walks through the one-way", 240, 190, 100, 0, false, false, 340, 190, 0, 0, 0},
+		{"touches the top at the end of a move", 120, 100, 0, 68, true, true, 120, 168, 0, -1, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := solids.Sweep(tt.x, tt.y, 32, 32, tt.deltaX, tt.deltaY, tt.landOnOneWay)
+			if result.Hit != tt.wantHit || result.X != tt.wantX || result.Y != tt.wantY {
+				t.Fatalf("Expected hit=%v at (%v, %v), got hit=%v at (%v, %v)", tt.wantHit, tt.wantX, tt.wantY, result.Hit, result.X, result.Y)
+			}
+			if result.NormalX != tt.wantNX || result.NormalY != tt.wantNY || result.TileX != tt.wantTileX {
+				t.Errorf("Expected normal (%v, %v) on tile X %d, got (%v, %v) on %d", tt.wantNX, tt.wantNY, tt.wantTileX, result.NormalX, result.NormalY, result.TileX)
+			}
+			if want := (tt.wantY - tt.y + tt.wantX - tt.x) / (tt.deltaX + tt.deltaY); math.Abs(result.Time-want) > 1e-9 {
+				t.Errorf("Expected the contact at time %v, got %v", want, result.Time)
+			}
+		})
+	}
+}
+
+func TestSolids_CheckCollision(t *testing.T) {
+	solids, _, _ := newSolidsTest()
+
+	tests := []struct {
+		name         string
+		x, y         float64
+		wantGround   bool
+		wantOneWay   bool
+		wantMaterial string
+		wantWall     bool
+		wantX, wantY bool
+	}{
+		{"standing on the top", 120, 168, true, false, "ice", false, false, false},
+		{"sunk into the top", 120, 169.5, true, false, "ice", false, false, false},
+		{"just above the top", 120, 167, false, false, "default", false, false, false},
+		{"mostly off the edge", 185, 168, false, false, "default", false, false, false},
+		{"standing on the one-way top", 320, 168, true, true, "default", false, false, false},
+		{"inside the one-way", 320, 180, false, false, "default", false, false, false},
+		{"against the side", 68, 190, false, false, "default", true, false, false},
+		{"pushed into the side", 72, 190, false, false, "default", false, true, false},
+		{"pushed up into the bottom", 150, 210, false, false, "default", false, false, true},
+		{"on the floor", 50, 468, true, false, "default", false, false, false},
+	}
+
+	var result Result
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solids.CheckCollisionInto(tt.x, tt.y, 32, 32, &result)
+			if result.OnGround != tt.wantGround || result.OneWayPlatform != tt.wantOneWay || result.Material.Name != tt.wantMaterial {
+				t.Errorf("Expected ground=%v one-way=%v on %q, got ground=%v one-way=%v on %q",
+					tt.wantGround, tt.wantOneWay, tt.wantMaterial, result.OnGround, result.OneWayPlatform, result.Material.Name)
+			}
+			if result.TouchingWall != tt.wantWall || result.CollisionX != tt.wantX || result.CollisionY != tt.wantY {
+				t.Errorf("Expected wall=%v X=%v Y=%v, got wall=%v X=%v Y=%v",
+					tt.wantWall, tt.wantX, tt.wantY, result.TouchingWall, result.CollisionX, result.CollisionY)
+			}
+		})
+	}
+
+	// Penetration is how far the box must move out
+	solids.CheckCollisionInto(72, 190, 32, 32, &result)
+	if result.PenetrationX != 4 {
+		t.Errorf("Expected 4px of penetration into the side, got %v", result.PenetrationX)
+	}
+}
+
+func TestSolids_AddRemoveAndSight(t *testing.T) {
+	base := &floorChecker{floorY: 500}
+	solids := NewSolids(base)
+	solid := &Solid{Rect: Rect{X: 0, Y: 100, Width: 64, Height: 16}}
+	solids.Add(solid)
+
+	if result := solids.Sweep(0, 0, 32, 32, 0, 200, true); !result.Hit || result.Y != 68 {
+		t.Fatalf("Expected to land on the solid at Y=68, got %+v", result)
+	}
+
+	// Solids are used where they are now
+	solid.Y = 200
+	if result := solids.Sweep(0, 0, 32, 32, 0, 200, true); !result.Hit || result.Y != 168 {
+		t.Errorf("Expected to land on the moved solid at Y=168, got %+v", result)
+	}
+
+	solids.Remove(solid)
+	if result := solids.Sweep(0, 0, 32, 32, 0, 200, true); result.Hit {
+		t.Errorf("Expected a removed solid not to block, got %+v", result)
+	}
+
+	// Sight is the base checker's
+	if !solids.LineOfSight(0, 0, 100, 0) {
+		t.Error("Expected a clear view when the base's view is clear")
+	}
+	base.blind = true
+	if solids.LineOfSight(0, 0, 100, 0) {
+		t.Error("Expected the base to block sight")
+	}
+}
@@ -0,0 +1,322 @@
+package collision
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// ID identifies an entity tracked by a SpatialHash
+type ID uint64
+
+// Rect is an axis-aligned box in world space
+type Rect struct {
+	X, Y          float64 // Top-left corner
+	Width, Height float64
+}
+
+// Overlaps returns whether two boxes overlap. Boxes that only share an edge don't.
+func (r Rect) Overlaps(other Rect) bool {
+	return r.X < other.X+other.Width && r.X+r.Width > other.X &&
+		r.Y < other.Y+other.Height && r.Y+r.Height > other.Y
+}
+
+// Pair is two entities in contact, with the lower ID first
+type Pair struct {
+	A, B ID
+}
+
+// ContactHandler is called with the two entities of a contact, lower ID first
+type ContactHandler func(a, b ID)
+
+// minSpatialHashBuckets is how many buckets cells are hashed into at first. Cells that
+// share a bucket only cost extra box tests, so the buckets are doubled whenever entities
+// cover more cells than there are buckets.
+const minSpatialHashBuckets = 1 << 8
+
+// hashEntry is a tracked entity, its box and the range of cells it is filed under
+type hashEntry struct {
+	id                     ID
+	bounds                 Rect
+	minX, minY, maxX, maxY int
+	stamp                  uint64 // Last query that reported this entity, so each is reported once
+}
+
+// SpatialHash is a broadphase for entity-vs-entity collision. Entity boxes are filed under
+// every grid cell they cover, so overlap and region queries only look at entities in
+// nearby cells instead of testing every pair. Cells are usually the level's tile size.
+//
+// UpdateContacts finds every overlapping pair once per frame and reports contacts that
+// began, continued and ended through OnEnter, OnStay and OnExit.
+type SpatialHash struct {
+	OnEnter ContactHandler // Called when two entities start overlapping
+	OnStay  ContactHandler // Called every UpdateContacts while two entities keep overlapping
+	OnExit  ContactHandler // Called when two entities stop overlapping, or one is removed
+
+	cellSize float64
+	buckets  [][]int32    // Indices into entries of everything filed under cells hashed to each bucket
+	filed    int          // Total number of cells entities are filed under
+	entries  []hashEntry  // Tracked entities, densely packed
+	index    map[ID]int32 // Position of each entity in entries
+	stamp    uint64       // Incremented by every query
+	pairs    []Pair       // Pairs overlapping at the last UpdateContacts, sorted
+	newPairs []Pair       // Scratch for the next UpdateContacts
+}
+
+// NewSpatialHash creates an empty spatial hash with square cells of cellSize pixels
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	return &SpatialHash{
+		cellSize: cellSize,
+		buckets:  make([][]int32, minSpatialHashBuckets),
+		index:    make(map[ID]int32),
+	}
+}
+
+// CellSize returns the width and height of the hash's cells in pixels
+func (h *SpatialHash) CellSize() float64 {
+	return h.cellSize
+}
+
+// Len returns the number of entities being tracked
+func (h *SpatialHash) Len() int {
+	return len(h.entries)
+}
+
+// Insert starts tracking an entity's box, or moves it if the entity is already tracked
+func (h *SpatialHash) Insert(id ID, bounds Rect) {
+	if h.Move(id, bounds) {
+		return
+	}
+
+	entry := hashEntry{id: id, bounds: bounds}
+	entry.minX, entry.minY, entry.maxX, entry.maxY = h.cellRange(bounds)
+	index := int32(len(h.entries))
+	h.entries = append(h.entries, entry)
+	h.index[id] = index
+	h.file(index)
+}
+
+// Move updates a tracked entity's box and returns false if the entity isn't tracked.
+// Entities that stay within the same cells aren't refiled.
+func (h *SpatialHash) Move(id ID, bounds Rect) bool {
+	index, ok := h.index[id]
+	if !ok {
+		return false
+	}
+
+	entry := &h.entries[index]
+	entry.bounds = bounds
+	minX, minY, maxX, maxY := h.cellRange(bounds)
+	if minX == entry.minX && minY == entry.minY && maxX == entry.maxX && maxY == entry.maxY {
+		return true
+	}
+	h.unfile(index)
+	entry.minX, entry.minY, entry.maxX, entry.maxY = minX, minY, maxX, maxY
+	h.file(index)
+	return true
+}
+
+// Remove stops tracking an entity. Its contacts end at the next UpdateContacts.
+func (h *SpatialHash) Remove(id ID) {
+	index, ok := h.index[id]
+	if !ok {
+		return
+	}
+
+	h.unfile(index)
+	delete(h.index, id)
+
+	// Fill the gap with the last entry, refiling it under its new index
+	last := int32(len(h.entries) - 1)
+	if index != last {
+		h.unfile(last)
+		h.entries[index] = h.entries[last]
+		h.index[h.entries[index].id] = index
+		h.file(index)
+	}
+	h.entries = h.entries[:last]
+}
+
+// Bounds returns a tracked entity's box
+func (h *SpatialHash) Bounds(id ID) (Rect, bool) {
+	index, ok := h.index[id]
+	if !ok {
+		return Rect{}, false
+	}
+	return h.entries[index].bounds, true
+}
+
+// QueryRegion appends every entity whose box overlaps region to result and returns it.
+// Passing the previous result back in, truncated to zero length, avoids allocating.
+func (h *SpatialHash) QueryRegion(region Rect, result []ID) []ID {
+	return h.query(region, -1, result)
+}
+
+// QueryOverlaps appends every other entity overlapping the given entity's box to result
+// and returns it
+func (h *SpatialHash) QueryOverlaps(id ID, result []ID) []ID {
+	index, ok := h.index[id]
+	if !ok {
+		return result
+	}
+	return h.query(h.entries[index].bounds, index, result)
+}
+
+// InContact returns whether two entities were overlapping at the last UpdateContacts
+func (h *SpatialHash) InContact(a, b ID) bool {
+	_, found := slices.BinarySearchFunc(h.pairs, makePair(a, b), comparePairs)
+	return found
+}
+
+// Contacts returns the pairs overlapping at the last UpdateContacts, sorted by ID. The
+// slice is reused by the next UpdateContacts.
+func (h *SpatialHash) Contacts() []Pair {
+	return h.pairs
+}
+
+// UpdateContacts finds every pair of overlapping entities and calls the contact handlers:
+// OnEnter for pairs that weren't overlapping last time, OnStay for pairs that still are,
+// then OnExit for pairs that no longer overlap. Each group is called in ID order. Handlers
+// may move and remove entities; the changes are picked up by the next UpdateContacts.
+func (h *SpatialHash) UpdateContacts() {
+	for bucket, indices := range h.buckets {
+		for i, index := range indices {
+			entry := &h.entries[index]
+			for _, otherIndex := range indices[i+1:] {
+				other := &h.entries[otherIndex]
+				if !entry.bounds.Overlaps(other.bounds) {
+					continue
+				}
+				// Overlapping boxes share cells. Only the first of them reports the pair.
+				if h.bucket(max(entry.minX, other.minX), max(entry.minY, other.minY)) == bucket {
+					h.newPairs = append(h.newPairs, makePair(entry.id, other.id))
+				}
+			}
+		}
+	}
+	// Two cells of the same pair can share a bucket, finding the pair twice
+	slices.SortFunc(h.newPairs, comparePairs)
+	h.newPairs = slices.Compact(h.newPairs)
+
+	// Swap before calling handlers so InContact and Contacts describe this update
+	oldPairs := h.pairs
+	h.pairs, h.newPairs = h.newPairs, oldPairs
+
+	old := 0
+	for _, pair := range h.pairs {
+		for old < len(oldPairs) && comparePairs(oldPairs[old], pair) < 0 {
+			old++
+		}
+		wasTouching := old < len(oldPairs) && oldPairs[old] == pair
+		if !wasTouching && h.OnEnter != nil {
+			h.OnEnter(pair.A, pair.B)
+		} else if wasTouching && h.OnStay != nil {
+			h.OnStay(pair.A, pair.B)
+		}
+	}
+	current := 0
+	for _, pair := range oldPairs {
+		for current < len(h.pairs) && comparePairs(h.pairs[current], pair) < 0 {
+			current++
+		}
+		touching := current < len(h.pairs) && h.pairs[current] == pair
+		if !touching && h.OnExit != nil {
+			h.OnExit(pair.A, pair.B)
+		}
+	}
+
+	h.newPairs = h.newPairs[:0]
+}
+
+// query appends the entities overlapping region, skipping the entry at exclude
+func (h *SpatialHash) query(region Rect, exclude int32, result []ID) []ID {
+	h.stamp++
+	minX, minY, maxX, maxY := h.cellRange(region)
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			for _, index := range h.buckets[h.bucket(x, y)] {
+				entry := &h.entries[index]
+				if entry.stamp == h.stamp || index == exclude {
+					continue
+				}
+				entry.stamp = h.stamp
+				if region.Overlaps(entry.bounds) {
+					result = append(result, entry.id)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// cellRange returns the cells a box covers
+func (h *SpatialHash) cellRange(bounds Rect) (minX, minY, maxX, maxY int) {
+	minX = int(math.Floor(bounds.X / h.cellSize))
+	minY = int(math.Floor(bounds.Y / h.cellSize))
+	maxX = int(math.Floor((bounds.X + bounds.Width) / h.cellSize))
+	maxY = int(math.Floor((bounds.Y + bounds.Height) / h.cellSize))
+	return minX, minY, maxX, maxY
+}
+
+// bucket returns the bucket a cell is hashed into
+func (h *SpatialHash) bucket(x, y int) int {
+	return (x*73856093 ^ y*19349663) & (len(h.buckets) - 1)
+}
+
+// file adds an entry to the bucket of every cell in its range, growing the buckets when
+// they become crowded
+func (h *SpatialHash) file(index int32) {
+	entry := &h.entries[index]
+	for y := entry.minY; y <= entry.maxY; y++ {
+		for x := entry.minX; x <= entry.maxX; x++ {
+			bucket := h.bucket(x, y)
+			h.buckets[bucket] = append(h.buckets[bucket], index)
+		}
+	}
+	h.filed += (entry.maxX - entry.minX + 1) * (entry.maxY - entry.minY + 1)
+
+	if h.filed > len(h.buckets) {
+		size := len(h.buckets)
+		for size < h.filed {
+			size *= 2
+		}
+		h.buckets = make([][]int32, size)
+		h.filed = 0
+		for index := range h.entries {
+			h.file(int32(index))
+		}
+	}
+}
+
+// unfile removes an entry from the bucket of every cell in its range. Buckets keep their
+// storage for the next entity to move in.
+func (h *SpatialHash) unfile(index int32) {
+	entry := &h.entries[index]
+	for y := entry.minY; y <= entry.maxY; y++ {
+		for x := entry.minX; x <= entry.maxX; x++ {
+			bucket := h.bucket(x, y)
+			indices := h.buckets[bucket]
+			if i := slices.Index(indices, index); i >= 0 {
+				indices[i] = indices[len(indices)-1]
+				h.buckets[bucket] = indices[:len(indices)-1]
+			}
+		}
+	}
+	h.filed -= (entry.maxX - entry.minX + 1) * (entry.maxY - entry.minY + 1)
+}
+
+// makePair orders two IDs into a Pair
+func makePair(a, b ID) Pair {
+	if b < a {
+		a, b = b, a
+	}
+	return Pair{a, b}
+}
+
+// comparePairs orders pairs by their first ID, then their second
+func comparePairs(a, b Pair) int {
+	if c := cmp.Compare(a.A, b.A); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.B, b.B)
+}
@@ -0,0 +1,272 @@
+package collision
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// contactLog records contact callbacks as "enter 1-2" style strings
+type contactLog []string
+
+func (l *contactLog) listen(hash *SpatialHash) {
+	hash.OnEnter = func(a, b ID) { *l = append(*l, fmt.Sprintf("enter %d-%d", a, b)) }
+	hash.OnStay = func(a, b ID) { *l = append(*l, fmt.Sprintf("stay %d-%d", a, b)) }
+	hash.OnExit = func(a, b ID) { *l = append(*l, fmt.Sprintf("exit %d-%d", a, b)) }
+}
+
+func (l *contactLog) take() []string {
+	events := *l
+	*l = nil
+	return events
+}
+
+func TestSpatialHash_QueryRegion(t *testing.T) {
+	hash := NewSpatialHash(32)
+	hash.Insert(1, Rect{0, 0, 16, 16})
+	hash.Insert(2, Rect{40, 0, 16, 16})
+	hash.Insert(3, Rect{0, 100, 200, 16}) // Spans several cells
+	hash.Insert(4, Rect{16, 0, 16, 16})   // Touches 1's right edge without overlapping it
+
+	tests := []struct {
+		name   string
+		region Rect
+		want   []ID
+	}{
+		{"single cell", Rect{0, 0, 10, 10}, []ID{1}},
+		{"across cells", Rect{10, 0, 40, 10}, []ID{1, 2, 4}},
+		{"wide entity reported once", Rect{0, 90, 300, 40}, []ID{3}},
+		{"shared edge doesn't overlap", Rect{32, 0, 8, 8}, nil},
+		{"empty space", Rect{500, 500, 10, 10}, nil},
+		{"negative coordinates", Rect{-50, -50, 60, 60}, []ID{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hash.QueryRegion(tt.region, nil)
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSpatialHash_QueryOverlaps(t *testing.T) {
+	hash := NewSpatialHash(32)
+	hash.Insert(1, Rect{0, 0, 40, 40})
+	hash.Insert(2, Rect{30, 30, 10, 10})
+	hash.Insert(3, Rect{100, 0, 10, 10})
+
+	if got := hash.QueryOverlaps(1, nil); !slices.Equal(got, []ID{2}) {
+		t.Errorf("Expected 1 to overlap [2], got %v", got)
+	}
+	if got := hash.QueryOverlaps(3, nil); len(got) != 0 {
+		t.Errorf("Expected 3 to overlap nothing, got %v", got)
+	}
+	if got := hash.QueryOverlaps(99, nil); len(got) != 0 {
+		t.Errorf("Expected an untracked entity to overlap nothing, got %v", got)
+	}
+}
+
+func TestSpatialHash_MoveAndRemove(t *testing.T) {
+	hash := NewSpatialHash(32)
+	hash.Insert(1, Rect{0, 0, 16, 16})
+
+	if !hash.Move(1, Rect{200, 200, 16, 16}) {
+		t.Fatal("Expected a tracked entity to move")
+	}
+	if got := hash.QueryRegion(Rect{0, 0, 32, 32}, nil); len(got) != 0 {
+		t.Errorf("Expected the old cell to be empty, got %v", got)
+	}
+	if got := hash.QueryRegion(Rect{190, 190, 32, 32}, nil); !slices.Equal(got, []ID{1}) {
+		t.Errorf("Expected to find the entity in its new cell, got %v", got)
+	}
+	if bounds, ok := hash.Bounds(1); !ok || bounds.X != 200 {
+		t.Errorf("Expected bounds at X=200, got %+v (tracked=%v)", bounds, ok)
+	}
+
+	// Inserting a tracked entity again moves it
+	hash.Insert(1, Rect{64, 0, 16, 16})
+	if hash.Len() != 1 {
+		t.Errorf("Expected one entity, got %d", hash.Len())
+	}
+
+	hash.Remove(1)
+	if hash.Len() != 0 || hash.Move(1, Rect{}) {
+		t.Error("Expected the entity to be gone")
+	}
+	if got := hash.QueryRegion(Rect{0, 0, 300, 300}, nil); len(got) != 0 {
+		t.Errorf("Expected no entities, got %v", got)
+	}
+}
+
+func TestSpatialHash_ContactCallbacks(t *testing.T) {
+	hash := NewSpatialHash(32)
+	var log contactLog
+	log.listen(hash)
+
+	hash.Insert(1, Rect{0, 0, 16, 16})
+	hash.Insert(2, Rect{100, 0, 16, 16})
+	hash.UpdateContacts()
+	if events := log.take(); len(events) != 0 {
+		t.Errorf("Expected no contacts while apart, got %v", events)
+	}
+
+	steps := []struct {
+		name   string
+		change func()
+		want   []string
+	}{
+		{"entities meet", func() { hash.Move(2, Rect{10, 0, 16, 16}) }, []string{"enter 1-2"}},
+		{"entities stay together", func() { hash.Move(2, Rect{8, 8, 16, 16}) }, []string{"stay 1-2"}},
+		{"third entity joins", func() { hash.Insert(3, Rect{12, 12, 8, 8}) }, []string{"stay 1-2", "enter 1-3", "enter 2-3"}},
+		{"entities part", func() { hash.Move(1, Rect{300, 0, 16, 16}) }, []string{"stay 2-3", "exit 1-2", "exit 1-3"}},
+		{"removed entity exits", func() { hash.Remove(3) }, []string{"exit 2-3"}},
+	}
+
+	for _, step := range steps {
+		step.change()
+		hash.UpdateContacts()
+		if events := log.take(); !slices.Equal(events, step.want) {
+			t.Errorf("%s: expected %v, got %v", step.name, step.want, events)
+		}
+	}
+}
+
+func TestSpatialHash_InContact(t *testing.T) {
+	hash := NewSpatialHash(32)
+	hash.Insert(5, Rect{0, 0, 16, 16})
+	hash.Insert(2, Rect{8, 8, 16, 16})
+	hash.UpdateContacts()
+
+	if !hash.InContact(5, 2) || !hash.InContact(2, 5) {
+		t.Error("Expected the entities to be in contact either way round")
+	}
+	if contacts := hash.Contacts(); len(contacts) != 1 || contacts[0] != (Pair{2, 5}) {
+		t.Errorf("Expected one contact {2 5}, got %v", contacts)
+	}
+
+	// Handlers may remove entities while contacts are being reported
+	hash.OnStay = func(a, b ID) { hash.Remove(b) }
+	hash.UpdateContacts()
+	hash.UpdateContacts()
+	if hash.InContact(2, 5) || hash.Len() != 1 {
+		t.Errorf("Expected the removed entity's contact to end, %d entities left", hash.Len())
+	}
+}
+
+func TestSpatialHash_DoesNotAllocate(t *testing.T) {
+	hash, _ := newCrowd(200, 1)
+	bounds := make([]Rect, hash.Len())
+	for i := range bounds {
+		bounds[i], _ = hash.Bounds(ID(i + 1))
+	}
+
+	// Entities pace back and forth across cell boundaries, so every bucket they use has
+	// already grown after the first frames
+	var found []ID
+	frame := 0
+	step := func() {
+		offset := float64(frame%2) * 40
+		for i, box := range bounds {
+			box.X += offset
+			hash.Move(ID(i+1), box)
+		}
+		hash.UpdateContacts()
+		found = hash.QueryRegion(Rect{100, 100, 200, 200}, found[:0])
+		frame++
+	}
+	for i := 0; i < 4; i++ {
+		step()
+	}
+
+	if allocs := testing.AllocsPerRun(50, step); allocs != 0 {
+		t.Errorf("Expected a steady crowd not to allocate, got %v allocations per frame", allocs)
+	}
+}
+
+// newCrowd scatters entities over a 200x40 tile level of 32px tiles and returns a function
+// that moves them all a few pixels, as one frame of a busy level
+func newCrowd(count int, seed int64) (*SpatialHash, func()) {
+	const levelWidth, levelHeight = 200 * 32, 40 * 32
+	random := rand.New(rand.NewSource(seed))
+	hash := NewSpatialHash(32)
+
+	bounds := make([]Rect, count)
+	velocities := make([][2]float64, count)
+	for i := range bounds {
+		size := 16 + random.Float64()*32
+		bounds[i] = Rect{random.Float64() * (levelWidth - size), random.Float64() * (levelHeight - size), size, size}
+		velocities[i] = [2]float64{random.Float64()*8 - 4, random.Float64()*8 - 4}
+		hash.Insert(ID(i+1), bounds[i])
+	}
+
+	move := func() {
+		for i := range bounds {
+			box, velocity := &bounds[i], &velocities[i]
+			box.X += velocity[0]
+			box.Y += velocity[1]
+			if box.X < 0 || box.X+box.Width > levelWidth {
+				velocity[0] = math.Copysign(velocity[0], levelWidth/2-box.X)
+			}
+			if box.Y < 0 || box.Y+box.Height > levelHeight {
+				velocity[1] = math.Copysign(velocity[1], levelHeight/2-box.Y)
+			}
+			hash.Move(ID(i+1), *box)
+		}
+	}
+	return hash, move
+}
+
+func BenchmarkSpatialHash_UpdateContacts(b *testing.B) {
+	for _, count := range []int{100, 500, 1000} {
+		b.Run(fmt.Sprintf("%d entities", count), func(b *testing.B) {
+			hash, move := newCrowd(count, 1)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				move()
+				hash.UpdateContacts()
+			}
+		})
+	}
+}
+
+// BenchmarkBruteForceContacts tests every pair, for comparison with the spatial hash
+func BenchmarkBruteForceContacts(b *testing.B) {
+	for _, count := range []int{100, 500, 1000} {
+		b.Run(fmt.Sprintf("%d entities", count), func(b *testing.B) {
+			hash, move := newCrowd(count, 1)
+			boxes := make([]Rect, count)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				move()
+				for j := range boxes {
+					boxes[j], _ = hash.Bounds(ID(j + 1))
+				}
+				contacts := 0
+				for j := range boxes {
+					for k := j + 1; k < len(boxes); k++ {
+						if boxes[j].Overlaps(boxes[k]) {
+							contacts++
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialHash_QueryRegion(b *testing.B) {
+	hash, _ := newCrowd(500, 1)
+	var found []ID
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// A screen-sized region, as a camera culling or explosion query would use
+		found = hash.QueryRegion(Rect{float64(i%100) * 32, 200, 640, 480}, found[:0])
+	}
+}
@@ -0,0 +1,38 @@
+package collision
+
+import "math"
+
+// Tolerances shared by everything that collides boxes, so tiles and other solids agree
+const (
+	// GroundTolerance is how far feet may be sunk into a surface and still stand on it.
+	// It absorbs floating-point error and keeps ground detection stable.
+	GroundTolerance = 2.0
+
+	// SweepEpsilon is how far, in pixels, a box may already be inside a side and still be
+	// stopped by it, so rounding in a previous contact can't let a box slip into a wall
+	SweepEpsilon = 1e-6
+)
+
+// SweepAxis returns the fractions of a move along one axis at which a box starts and stops
+// overlapping a span. A box up to sink pixels past the span's near side counts as only just
+// touching it. A box that doesn't move along the axis overlaps the span throughout or never.
+func SweepAxis(position, size, delta, spanStart, spanSize, sink float64) (entry, exit float64) {
+	switch {
+	case delta > 0:
+		near := spanStart - (position + size)
+		if near < 0 && near >= -sink {
+			near = 0
+		}
+		return near / delta, (spanStart + spanSize - position) / delta
+	case delta < 0:
+		near := spanStart + spanSize - position
+		if near > 0 && near <= sink {
+			near = 0
+		}
+		return near / delta, (spanStart - (position + size)) / delta
+	case position < spanStart+spanSize && position+size > spanStart:
+		return math.Inf(-1), math.Inf(1)
+	default:
+		return math.Inf(1), math.Inf(-1)
+	}
+}
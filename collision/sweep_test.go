@@ -0,0 +1,39 @@
+package collision
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSweepAxis(t *testing.T) {
+	tests := []struct {
+		name                                       string
+		position, size, delta, spanStart, spanSize float64
+		sink                                       float64
+		entry, exit                                float64
+	}{
+		{"moving right towards", 0, 10, 20, 20, 10, 0, 0.5, 1.5},
+		{"moving left towards", 40, 10, -20, 20, 10, 0, 0.5, 1.5},
+		{"moving away", 40, 10, 20, 20, 10, 0, -1.5, -0.5},
+		{"already past the near side", 0, 12, 20, 10, 10, 0, -0.1, 1},
+		{"within the sink", 0, 12, 20, 10, 10, 2, 0, 1},
+		{"deeper than the sink", 0, 13, 20, 10, 10, 2, -0.15, 1},
+		{"sunk moving back", 18, 10, -20, 10, 10, 2, 0, 0.9},
+		{"still and overlapping", 5, 10, 0, 10, 10, 0, math.Inf(-1), math.Inf(1)},
+		{"still and apart", 0, 10, 0, 10, 10, 0, math.Inf(1), math.Inf(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, exit := SweepAxis(tt.position, tt.size, tt.delta, tt.spanStart, tt.spanSize, tt.sink)
+			if !closeTo(entry, tt.entry) || !closeTo(exit, tt.exit) {
+				t.Errorf("Expected entry %v and exit %v, got %v and %v", tt.entry, tt.exit, entry, exit)
+			}
+		})
+	}
+}
+
+// closeTo returns whether two fractions match, allowing for rounding, including infinities
+func closeTo(a, b float64) bool {
+	return a == b || math.Abs(a-b) < 1e-9
+}
@@ -14,8 +14,6 @@ func TestHighSpeedCollision(t *testing.T) {
 	testLevel := level.NewLevel(10, 10, 32, "High Speed Test")
 	testLevel.SetTile(2, 5, level.TileSolid) // Platform at tile (2,5) = world position (64, 160) to (96, 192)
 	
-	// Create level adapter
-	levelAdapter := level.NewCollisionAdapter(testLevel)
 	
 	// Create a test sprite sheet to avoid the nil pointer issue
 	testSpriteSheet := entities.CreateTestSpriteSheet()
@@ -36,7 +34,7 @@ func TestHighSpeedCollision(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create a fresh player for each test
 			player := entities.NewPlayer(64, tc.startY, testSpriteSheet)
-			player.SetLevel(levelAdapter)
+			player.SetLevel(testLevel)
 			
 			// Set the falling velocity
 			player.VelocityY = tc.velocityY
@@ -86,8 +84,7 @@ func TestConsistentLanding(t *testing.T) {
 	testLevel := level.NewLevel(10, 10, 32, "Landing Test")
 	testLevel.SetTile(2, 5, level.TileSolid) // Platform at tile (2,5) = world position (64, 160) to (96, 192)
 	
-	// Create level adapter and test sprite sheet
-	levelAdapter := level.NewCollisionAdapter(testLevel)
+	// Create test sprite sheet
 	testSpriteSheet := entities.CreateTestSpriteSheet()
 	
 	// Test different landing scenarios
@@ -106,7 +103,7 @@ func TestConsistentLanding(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create a fresh player for each test
 			player := entities.NewPlayer(64, tc.startY, testSpriteSheet)
-			player.SetLevel(levelAdapter)
+			player.SetLevel(testLevel)
 			
 			// Set the falling velocity
 			player.VelocityY = tc.velocityY
@@ -155,11 +152,10 @@ func TestNoSinking(t *testing.T) {
 	testLevel := level.NewLevel(10, 10, 32, "Sinking Test")
 	testLevel.SetTile(2, 5, level.TileSolid) // Platform at tile (2,5)
 	
-	// Create level adapter, sprite sheet, and player
-	levelAdapter := level.NewCollisionAdapter(testLevel)
+	// Create sprite sheet and player
 	testSpriteSheet := entities.CreateTestSpriteSheet()
 	player := entities.NewPlayer(64, 128, testSpriteSheet) // Position player on platform
-	player.SetLevel(levelAdapter)
+	player.SetLevel(testLevel)
 	
 	initialY := 128.0
 	maxAllowedY := 135.0 // Allow some tolerance but shouldn't sink much
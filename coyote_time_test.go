@@ -25,8 +25,7 @@ func TestCoyoteTime(t *testing.T) {
 	
 	// Create player on the platform, closer to the edge
 	player := entities.NewPlayer(80, 192, testImage) // Position closer to edge
-	adapter := level.NewCollisionAdapter(testLevel)
-	player.SetLevel(adapter)
+	player.SetLevel(testLevel)
 	
 	// Simulate first frame - player should be on ground
 	deltaTime := 1.0 / 60.0 // 60 FPS
@@ -76,8 +75,7 @@ func TestCoyoteTimeExpires(t *testing.T) {
 	
 	// Create player on the platform
 	player := entities.NewPlayer(80, 192, testImage)
-	adapter := level.NewCollisionAdapter(testLevel)
-	player.SetLevel(adapter)
+	player.SetLevel(testLevel)
 	
 	deltaTime := 1.0 / 60.0 // 60 FPS
 	
@@ -130,8 +128,7 @@ func TestCoyoteTimeForgivingDetection(t *testing.T) {
 	
 	// Position player so they have good overlap with the platform initially
 	player := entities.NewPlayer(90, 192, testImage) // Good overlap with tile at X=96-128
-	adapter := level.NewCollisionAdapter(testLevel)
-	player.SetLevel(adapter)
+	player.SetLevel(testLevel)
 	
 	deltaTime := 1.0 / 60.0
 	
@@ -16,13 +16,11 @@ func TestSpecificPosition192(t *testing.T) {
 		testLevel.SetTile(x, 5, level.TileSolid)
 	}
 	
-	// Create collision adapter
-	adapter := level.NewCollisionAdapter(testLevel)
 	
 	// Create player exactly at X=192 where the issue occurs
 	spriteSheet := ebiten.NewImage(32, 32)
 	player := entities.NewPlayer(192, 128, spriteSheet)
-	player.SetLevel(adapter)
+	player.SetLevel(testLevel)
 	
 	deltaTime := 1.0 / 60.0
 	
@@ -33,7 +31,7 @@ func TestSpecificPosition192(t *testing.T) {
 	}
 	
 	// Manual collision check
-	result := adapter.CheckCollision(192, 128, 32, 32)
+	result := testLevel.CheckCollision(192, 128, 32, 32)
 	t.Logf("Manual collision result: OnGround=%t, Collided=%t", result.OnGround, result.Collided)
 	
 	// Check what tiles the player overlaps
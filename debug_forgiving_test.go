@@ -22,8 +22,7 @@ func TestDebugForgivingDetection(t *testing.T) {
 	
 	// Position player so they have good overlap with the platform initially
 	player := entities.NewPlayer(90, 192, testImage) // Good overlap with tile at X=96-128
-	adapter := level.NewCollisionAdapter(testLevel)
-	player.SetLevel(adapter)
+	player.SetLevel(testLevel)
 	
 	deltaTime := 1.0 / 60.0
 	
@@ -32,11 +32,10 @@ func TestDebugOverlapCalculation(t *testing.T) {
 		{90, true, 72},  // 90-122 vs 96-128, overlap 96-122 = 26px = 81%
 	}
 	
-	adapter := level.NewCollisionAdapter(testLevel)
 	
 	for _, tc := range testCases {
 		player := entities.NewPlayer(tc.x, 192, testImage)
-		player.SetLevel(adapter)
+		player.SetLevel(testLevel)
 		
 		deltaTime := 1.0 / 60.0
 		player.Update(deltaTime)
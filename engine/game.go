@@ -172,6 +172,7 @@ func (sm *StateManager) StateToString(state GameState) string {
 type Game struct {
 	assetManager *AssetManager
 	stateManager *StateManager
+	saveManager  *SaveManager
 	screenWidth  int
 	screenHeight int
 	lastFrameTime float64
@@ -182,6 +183,7 @@ type GameConfig struct {
 	ScreenWidth  int
 	ScreenHeight int
 	AssetConfig  AssetConfig
+	SavePath     string // Save file; empty keeps progress in memory only
 }
 
 // NewGame creates a new game instance
@@ -189,6 +191,7 @@ func NewGame(config GameConfig) *Game {
 	game := &Game{
 		assetManager: NewAssetManager(config.AssetConfig),
 		stateManager: NewStateManager(StateLoading),
+		saveManager:  NewSaveManager(config.SavePath),
 		screenWidth:  config.ScreenWidth,
 		screenHeight: config.ScreenHeight,
 	}
@@ -240,6 +243,11 @@ func (g *Game) GetAssetManager() *AssetManager {
 	return g.assetManager
 }
 
+// GetSaveManager returns the game's save manager
+func (g *Game) GetSaveManager() *SaveManager {
+	return g.saveManager
+}
+
 // GetState returns the current game state
 func (g *Game) GetState() GameState {
 	return g.stateManager.GetCurrentState()
@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// LevelProgress is what the player has achieved in one level
+type LevelProgress struct {
+	HeartsCollected []string `json:"hearts_collected"` // Keys of the hearts collected, sorted
+	HeartsTotal     int      `json:"hearts_total"`     // Hearts placed in the level when it was last played
+	CatsHelped      []string `json:"cats_helped"`      // Keys of the cats given a heart, sorted
+	Score           int      `json:"score"`            // Points earned in the level
+}
+
+// CollectHeart records a heart as collected and returns false if it already was
+func (p *LevelProgress) CollectHeart(key string) bool {
+	index, found := slices.BinarySearch(p.HeartsCollected, key)
+	if found {
+		return false
+	}
+	p.HeartsCollected = slices.Insert(p.HeartsCollected, index, key)
+	return true
+}
+
+// HasHeart returns whether a heart has been collected
+func (p *LevelProgress) HasHeart(key string) bool {
+	_, found := slices.BinarySearch(p.HeartsCollected, key)
+	return found
+}
+
+// HeartsCollectedCount returns how many of the level's hearts have been collected
+func (p *LevelProgress) HeartsCollectedCount() int {
+	return len(p.HeartsCollected)
+}
+
+// HelpCat records a cat as helped and returns false if it already was. Each cat helped
+// spends one of the level's collected hearts.
+func (p *LevelProgress) HelpCat(key string) bool {
+	index, found := slices.BinarySearch(p.CatsHelped, key)
+	if found {
+		return false
+	}
+	p.CatsHelped = slices.Insert(p.CatsHelped, index, key)
+	return true
+}
+
+// HasHelpedCat returns whether a cat has been helped
+func (p *LevelProgress) HasHelpedCat(key string) bool {
+	_, found := slices.BinarySearch(p.CatsHelped, key)
+	return found
+}
+
+// CatsHelpedCount returns how many of the level's cats have been helped
+func (p *LevelProgress) CatsHelpedCount() int {
+	return len(p.CatsHelped)
+}
+
+// HeartsAvailable returns how many collected hearts haven't been given to cats yet
+func (p *LevelProgress) HeartsAvailable() int {
+	return max(len(p.HeartsCollected)-len(p.CatsHelped), 0)
+}
+
+// SaveData is everything the game remembers between sessions
+type SaveData struct {
+	Levels map[string]*LevelProgress `json:"levels"` // Progress by level name
+}
+
+// SaveManager keeps the player's progress and stores it as a JSON file
+type SaveManager struct {
+	path string
+	data SaveData
+}
+
+// NewSaveManager creates a save manager with no progress. An empty path keeps progress
+// in memory only, which is what tests want.
+func NewSaveManager(path string) *SaveManager {
+	return &SaveManager{
+		path: path,
+		data: SaveData{Levels: make(map[string]*LevelProgress)},
+	}
+}
+
+// GetPath returns the save file's path, or "" if progress is only kept in memory
+func (sm *SaveManager) GetPath() string {
+	return sm.path
+}
+
+// SetPath changes where progress is saved. The current progress is kept.
+func (sm *SaveManager) SetPath(path string) {
+	sm.path = path
+}
+
+// GetLevelProgress returns the progress for a level, creating it if the level hasn't been
+// played. Changes are kept until the next Save.
+func (sm *SaveManager) GetLevelProgress(levelName string) *LevelProgress {
+	progress, exists := sm.data.Levels[levelName]
+	if !exists {
+		progress = &LevelProgress{}
+		sm.data.Levels[levelName] = progress
+	}
+	return progress
+}
+
+// Load replaces the current progress with the save file's. A missing save file isn't an
+// error: the player simply hasn't saved yet.
+func (sm *SaveManager) Load() error {
+	if sm.path == "" {
+		return nil
+	}
+
+	file, err := os.Open(sm.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open save file %s: %w", sm.path, err)
+	}
+	defer file.Close()
+
+	var data SaveData
+	if err := json.NewDecoder(file).Decode(&data); err != nil {
+		return fmt.Errorf("failed to decode save file %s: %w", sm.path, err)
+	}
+	if data.Levels == nil {
+		data.Levels = make(map[string]*LevelProgress)
+	}
+	for name, progress := range data.Levels {
+		// A hand-edited or damaged save may have no progress for a level: start it afresh
+		if progress == nil {
+			delete(data.Levels, name)
+			continue
+		}
+		slices.Sort(progress.HeartsCollected)
+		progress.HeartsCollected = slices.Compact(progress.HeartsCollected)
+		slices.Sort(progress.CatsHelped)
+		progress.CatsHelped = slices.Compact(progress.CatsHelped)
+	}
+	sm.data = data
+	return nil
+}
+
+// Save writes the progress to the save file. The file is replaced in one step, so a crash
+// while saving never leaves half a save behind.
+func (sm *SaveManager) Save() error {
+	if sm.path == "" {
+		return nil
+	}
+
+	encoded, err := json.MarshalIndent(sm.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode save data: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(sm.path), 0755); err != nil {
+		return fmt.Errorf("failed to create save directory: %w", err)
+	}
+
+	tmpPath := sm.path + ".tmp"
+	if err := os.WriteFile(tmpPath, encoded, 0644); err != nil {
+		return fmt.Errorf("failed to write save file %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, sm.path); err != nil {
+		return fmt.Errorf("failed to replace save file %s: %w", sm.path, err)
+	}
+	return nil
+}
+
+// DefaultSavePath returns where a game's progress is saved for the current user
+func DefaultSavePath(gameName string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config directory: %w", err)
+	}
+	return filepath.Join(dir, gameName, "save.json"), nil
+}
@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLevelProgress_CollectHeart(t *testing.T) {
+	var progress LevelProgress
+
+	for _, key := range []string{"b", "a", "c", "a"} {
+		progress.CollectHeart(key)
+	}
+	if !slices.Equal(progress.HeartsCollected, []string{"a", "b", "c"}) {
+		t.Errorf("Expected sorted keys without duplicates, got %v", progress.HeartsCollected)
+	}
+	if progress.CollectHeart("b") {
+		t.Error("Expected collecting a heart twice to report false")
+	}
+	if !progress.HasHeart("c") || progress.HasHeart("d") {
+		t.Error("Expected HasHeart to match the collected keys")
+	}
+	if progress.HeartsCollectedCount() != 3 {
+		t.Errorf("Expected 3 hearts, got %d", progress.HeartsCollectedCount())
+	}
+}
+
+func TestLevelProgress_HelpCat(t *testing.T) {
+	var progress LevelProgress
+	progress.CollectHeart("h1")
+	progress.CollectHeart("h2")
+
+	if !progress.HelpCat("tabby") || progress.HelpCat("tabby") {
+		t.Error("Expected a cat to be helped once")
+	}
+	if !progress.HasHelpedCat("tabby") || progress.HasHelpedCat("ginger") || progress.CatsHelpedCount() != 1 {
+		t.Errorf("Expected only the tabby to be helped, got %v", progress.CatsHelped)
+	}
+	if progress.HeartsAvailable() != 1 {
+		t.Errorf("Expected one heart to spare, got %d", progress.HeartsAvailable())
+	}
+	progress.HelpCat("ginger")
+	progress.HelpCat("calico")
+	if progress.HeartsAvailable() != 0 {
+		t.Errorf("Expected no hearts to spare, got %d", progress.HeartsAvailable())
+	}
+}
+
+func TestSaveManager_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profile", "save.json")
+	saves := NewSaveManager(path)
+
+	progress := saves.GetLevelProgress("Simple Level")
+	progress.HeartsTotal = 3
+	progress.CollectHeart("heart-left")
+	progress.HelpCat("cat-ground")
+	progress.Score = 100
+	if saves.GetLevelProgress("Simple Level") != progress {
+		t.Fatal("Expected the same progress for the same level")
+	}
+	if err := saves.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected the temporary file to be renamed away")
+	}
+
+	loaded := NewSaveManager(path)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	got := loaded.GetLevelProgress("Simple Level")
+	if got.HeartsTotal != 3 || !got.HasHeart("heart-left") || got.HeartsCollectedCount() != 1 ||
+		!got.HasHelpedCat("cat-ground") || got.Score != 100 {
+		t.Errorf("Expected the saved progress back, got %+v", got)
+	}
+}
+
+func TestSaveManager_Load(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string // Empty for no file
+		wantErr  bool
+		hearts   int
+	}{
+		{"no save yet", "", false, 0},
+		{"unsorted hearts", `{"levels": {"L": {"hearts_collected": ["b", "a", "b"], "hearts_total": 2}}}`, false, 2},
+		{"no levels", `{}`, false, 0},
+		{"null level", `{"levels": {"L": null}}`, false, 0},
+		{"corrupt", `{"levels": `, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "save.json")
+			if tt.contents != "" {
+				if err := os.WriteFile(path, []byte(tt.contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			saves := NewSaveManager(path)
+			err := saves.Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			progress := saves.GetLevelProgress("L")
+			if progress.HeartsCollectedCount() != tt.hearts {
+				t.Errorf("Expected %d hearts, got %v", tt.hearts, progress.HeartsCollected)
+			}
+			if tt.hearts > 0 && !progress.HasHeart("a") {
+				t.Error("Expected loaded hearts to be searchable")
+			}
+		})
+	}
+}
+
+func TestSaveManager_InMemory(t *testing.T) {
+	saves := NewSaveManager("")
+	saves.GetLevelProgress("L").CollectHeart("h")
+	if err := saves.Save(); err != nil {
+		t.Errorf("Expected saving in memory to succeed, got %v", err)
+	}
+	if err := saves.Load(); err != nil || !saves.GetLevelProgress("L").HasHeart("h") {
+		t.Errorf("Expected loading in memory to keep progress, got %v", err)
+	}
+}
+
+func TestDefaultSavePath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path, err := DefaultSavePath("robo9-test")
+	if err != nil {
+		t.Skipf("No user config directory: %v", err)
+	}
+	if !strings.HasSuffix(path, filepath.Join("robo9-test", "save.json")) {
+		t.Errorf("Expected the save file in the game's directory, got %s", path)
+	}
+}
+
+func TestGame_SaveManager(t *testing.T) {
+	game := NewGame(GameConfig{ScreenWidth: 320, ScreenHeight: 240, SavePath: "progress.json"})
+	if game.GetSaveManager() == nil || game.GetSaveManager().GetPath() != "progress.json" {
+		t.Error("Expected the game to create a save manager for its save path")
+	}
+}
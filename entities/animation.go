@@ -1,7 +1,9 @@
 package entities
 
 import (
+	"fmt"
 	"image"
+	"math"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -15,16 +17,63 @@ const (
 	AnimationFall
 	AnimationClimb
 	AnimationDamage
+	AnimationJumpStart // Transition-only clip between the ground and AnimationJump
+	AnimationLand      // Short squash played on touching down
 )
 
+// String returns a readable name for the animation state
+func (s AnimationState) String() string {
+	switch s {
+	case AnimationIdle:
+		return "Idle"
+	case AnimationWalk:
+		return "Walk"
+	case AnimationJump:
+		return "Jump"
+	case AnimationFall:
+		return "Fall"
+	case AnimationClimb:
+		return "Climb"
+	case AnimationDamage:
+		return "Damage"
+	case AnimationJumpStart:
+		return "JumpStart"
+	case AnimationLand:
+		return "Land"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(s))
+	}
+}
+
+// PlaybackMode controls the order in which an animation's frames are played
+type PlaybackMode int
+
+const (
+	PlaybackForward  PlaybackMode = iota // 0, 1, 2, 3
+	PlaybackReverse                      // 3, 2, 1, 0
+	PlaybackPingPong                     // 0, 1, 2, 3, 2, 1
+)
+
+// AnimationEventHandler is called when playback enters a frame with a named event.
+// The frame is the index into the animation's Frames, not the playback step.
+type AnimationEventHandler func(name string, frame int)
+
 // Animation represents a single animation sequence
 type Animation struct {
-	Frames      []*ebiten.Image // Individual frames of the animation
-	FrameCount  int             // Number of frames in the animation
-	FrameTime   float64         // Time per frame in seconds
-	Loop        bool            // Whether the animation should loop
-	CurrentTime float64         // Current time in the animation
-	Finished    bool            // Whether the animation has finished (for non-looping)
+	Frames         []*ebiten.Image // Individual frames of the animation
+	FrameCount     int             // Number of frames in the animation
+	FrameTime      float64         // Time per frame in seconds
+	FrameDurations []float64       // Optional per-frame durations in seconds (zero entries fall back to FrameTime)
+	Loop           bool            // Whether the animation should loop
+	Mode           PlaybackMode    // Order in which frames are played
+	Speed          float64         // Playback speed multiplier (zero is treated as 1.0)
+	CurrentTime    float64         // Current time in the animation
+	Finished       bool            // Whether the animation has finished (for non-looping)
+
+	events   map[int][]string      // Named events keyed by frame index
+	onEvent  AnimationEventHandler // Receives events as frames are entered
+	lastStep int                   // Last playback step whose events have fired
+	started  bool                  // Whether the first frame's events have fired
 }
 
 // NewAnimation creates a new animation from a sprite sheet
@@ -47,29 +96,53 @@ func NewAnimation(spriteSheet *ebiten.Image, frameWidth, frameHeight, frameCount
 		FrameCount: frameCount,
 		FrameTime:  frameTime,
 		Loop:       loop,
+		Speed:      1.0,
 	}
 }
 
-// Update updates the animation timing
+// Update updates the animation timing and fires events for every frame entered,
+// including frames skipped over by a large delta
 func (a *Animation) Update(deltaTime float64) {
 	if a.Finished && !a.Loop {
 		return
 	}
+
+	if !a.started {
+		a.started = true
+		a.lastStep = 0
+		a.fireEvents(0)
+	}
 	
-	a.CurrentTime += deltaTime
+	a.CurrentTime += deltaTime * a.playbackSpeed()
 	
 	// Check if we've completed the animation
-	totalAnimationTime := float64(a.FrameCount) * a.FrameTime
+	totalAnimationTime := a.TotalDuration()
 	if a.CurrentTime >= totalAnimationTime {
-		if a.Loop {
-			// Reset for looping animations
-			a.CurrentTime = 0
-		} else {
+		if !a.Loop || totalAnimationTime <= 0 {
+			// Fire events for any frames between the last one seen and the end
+			a.advanceTo(a.stepCount() - 1)
+
+			if a.Loop {
+				a.CurrentTime = 0
+				return
+			}
 			// Mark as finished for non-looping animations
 			a.CurrentTime = totalAnimationTime - 0.001 // Keep at last frame
 			a.Finished = true
+			return
+		}
+
+		// Looping animations fire the rest of every cycle crossed and the first frame of
+		// the next, then carry the leftover time into the new cycle
+		for range int(a.CurrentTime / totalAnimationTime) {
+			a.advanceTo(a.stepCount() - 1)
+			a.lastStep = 0
+			a.fireEvents(0)
 		}
+		a.CurrentTime = math.Mod(a.CurrentTime, totalAnimationTime)
 	}
+
+	a.advanceTo(a.stepAt(a.CurrentTime))
 }
 
 // GetCurrentFrame returns the current frame image
@@ -78,18 +151,58 @@ func (a *Animation) GetCurrentFrame() *ebiten.Image {
 		return nil
 	}
 	
-	frameIndex := int(a.CurrentTime / a.FrameTime)
-	if frameIndex >= a.FrameCount {
-		frameIndex = a.FrameCount - 1
+	frameIndex := a.CurrentFrameIndex()
+	if frameIndex >= len(a.Frames) {
+		frameIndex = len(a.Frames) - 1
+	}
+	if frameIndex < 0 {
+		return nil
 	}
 	
 	return a.Frames[frameIndex]
 }
 
+// CurrentFrameIndex returns the index into Frames that is currently showing
+func (a *Animation) CurrentFrameIndex() int {
+	if a.FrameCount == 0 {
+		return 0
+	}
+	return a.stepFrame(a.stepAt(a.CurrentTime))
+}
+
+// TotalDuration returns the length of one playback cycle in seconds at normal speed
+func (a *Animation) TotalDuration() float64 {
+	total := 0.0
+	for step := 0; step < a.stepCount(); step++ {
+		total += a.frameDuration(a.stepFrame(step))
+	}
+	return total
+}
+
+// AddEvent registers a named event on one or more frames. Out-of-range frames are ignored.
+func (a *Animation) AddEvent(name string, frames ...int) {
+	if a.events == nil {
+		a.events = make(map[int][]string)
+	}
+	for _, frame := range frames {
+		if frame < 0 || frame >= a.FrameCount {
+			continue
+		}
+		a.events[frame] = append(a.events[frame], name)
+	}
+}
+
+// SetEventHandler sets the callback that receives this animation's frame events
+func (a *Animation) SetEventHandler(handler AnimationEventHandler) {
+	a.onEvent = handler
+}
+
 // Reset resets the animation to the beginning
 func (a *Animation) Reset() {
 	a.CurrentTime = 0
 	a.Finished = false
+	a.lastStep = 0
+	a.started = false
 }
 
 // IsFinished returns whether the animation has finished (for non-looping animations)
@@ -97,6 +210,80 @@ func (a *Animation) IsFinished() bool {
 	return a.Finished
 }
 
+// playbackSpeed returns the effective speed multiplier
+func (a *Animation) playbackSpeed() float64 {
+	if a.Speed <= 0 {
+		return 1.0
+	}
+	return a.Speed
+}
+
+// frameDuration returns how long a frame is shown for at normal speed
+func (a *Animation) frameDuration(frame int) float64 {
+	if frame >= 0 && frame < len(a.FrameDurations) && a.FrameDurations[frame] > 0 {
+		return a.FrameDurations[frame]
+	}
+	return a.FrameTime
+}
+
+// stepCount returns the number of playback steps in one cycle
+func (a *Animation) stepCount() int {
+	if a.Mode == PlaybackPingPong && a.FrameCount > 2 {
+		return a.FrameCount*2 - 2
+	}
+	return a.FrameCount
+}
+
+// stepFrame maps a playback step to a frame index for the current mode
+func (a *Animation) stepFrame(step int) int {
+	switch a.Mode {
+	case PlaybackReverse:
+		return a.FrameCount - 1 - step
+	case PlaybackPingPong:
+		if step >= a.FrameCount {
+			return a.FrameCount*2 - 2 - step
+		}
+	}
+	return step
+}
+
+// stepAt returns the playback step shown at the given time
+func (a *Animation) stepAt(t float64) int {
+	steps := a.stepCount()
+	elapsed := 0.0
+	for step := 0; step < steps; step++ {
+		elapsed += a.frameDuration(a.stepFrame(step))
+		if t < elapsed {
+			return step
+		}
+	}
+	if steps == 0 {
+		return 0
+	}
+	return steps - 1
+}
+
+// advanceTo fires events for every step after the last one seen, up to and including target
+func (a *Animation) advanceTo(target int) {
+	for step := a.lastStep + 1; step <= target; step++ {
+		a.fireEvents(step)
+	}
+	if target > a.lastStep {
+		a.lastStep = target
+	}
+}
+
+// fireEvents notifies the handler of any events on the frame shown at the given step
+func (a *Animation) fireEvents(step int) {
+	if a.onEvent == nil || a.FrameCount == 0 {
+		return
+	}
+	frame := a.stepFrame(step)
+	for _, name := range a.events[frame] {
+		a.onEvent(name, frame)
+	}
+}
+
 // AnimationController manages multiple animations for a single entity
 type AnimationController struct {
 	animations    map[AnimationState]*Animation
@@ -105,8 +292,12 @@ type AnimationController struct {
 	spriteSheet   *ebiten.Image
 	frameWidth    int
 	frameHeight   int
+	eventHandler  StateEventHandler
 }
 
+// StateEventHandler receives frame events from any animation in a controller
+type StateEventHandler func(state AnimationState, name string, frame int)
+
 // NewAnimationController creates a new animation controller
 func NewAnimationController(spriteSheet *ebiten.Image, frameWidth, frameHeight int) *AnimationController {
 	return &AnimationController{
@@ -117,6 +308,21 @@ func NewAnimationController(spriteSheet *ebiten.Image, frameWidth, frameHeight i
 	}
 }
 
+// ValidateLayout checks the controller's sprite sheet against a declared layout.
+// AddAnimation clamps bad frame ranges silently, so call this first to surface them.
+func (ac *AnimationController) ValidateLayout(layout SpriteSheetLayout) error {
+	if layout.FrameWidth != ac.frameWidth || layout.FrameHeight != ac.frameHeight {
+		return &SpriteSheetError{Issues: []SpriteSheetIssue{{
+			Kind:    IssueInvalidLayout,
+			Frame:   -1,
+			Message: fmt.Sprintf("layout frame size %dx%d does not match controller frame size %dx%d", layout.FrameWidth, layout.FrameHeight, ac.frameWidth, ac.frameHeight),
+		}}}
+	}
+
+	bounds := ac.spriteSheet.Bounds()
+	return ValidateSpriteSheetSize(bounds.Dx(), bounds.Dy(), layout)
+}
+
 // AddAnimation adds an animation to the controller
 func (ac *AnimationController) AddAnimation(state AnimationState, startFrame, frameCount int, frameTime float64, loop bool) {
 	// Extract the specific frames for this animation from the sprite sheet
@@ -164,12 +370,57 @@ func (ac *AnimationController) AddAnimation(state AnimationState, startFrame, fr
 		}
 	}
 	
-	ac.animations[state] = &Animation{
+	animation := &Animation{
 		Frames:     frames,
 		FrameCount: frameCount,
 		FrameTime:  frameTime,
 		Loop:       loop,
+		Speed:      1.0,
 	}
+	animation.SetEventHandler(func(name string, frame int) {
+		if ac.eventHandler != nil {
+			ac.eventHandler(state, name, frame)
+		}
+	})
+	ac.animations[state] = animation
+}
+
+// GetAnimation returns the animation registered for a state, or nil
+func (ac *AnimationController) GetAnimation(state AnimationState) *Animation {
+	return ac.animations[state]
+}
+
+// SetSpeed sets the playback speed multiplier for a state's animation
+func (ac *AnimationController) SetSpeed(state AnimationState, speed float64) {
+	if animation, exists := ac.animations[state]; exists {
+		animation.Speed = speed
+	}
+}
+
+// SetPlaybackMode sets the frame order for a state's animation
+func (ac *AnimationController) SetPlaybackMode(state AnimationState, mode PlaybackMode) {
+	if animation, exists := ac.animations[state]; exists {
+		animation.Mode = mode
+	}
+}
+
+// SetFrameDurations sets per-frame durations for a state's animation
+func (ac *AnimationController) SetFrameDurations(state AnimationState, durations ...float64) {
+	if animation, exists := ac.animations[state]; exists {
+		animation.FrameDurations = durations
+	}
+}
+
+// AddFrameEvent registers a named event on frames of a state's animation
+func (ac *AnimationController) AddFrameEvent(state AnimationState, name string, frames ...int) {
+	if animation, exists := ac.animations[state]; exists {
+		animation.AddEvent(name, frames...)
+	}
+}
+
+// SetEventHandler sets the callback for frame events from all animations
+func (ac *AnimationController) SetEventHandler(handler StateEventHandler) {
+	ac.eventHandler = handler
 }
 
 // SetState changes the current animation state
@@ -185,6 +436,29 @@ func (ac *AnimationController) SetState(state AnimationState) {
 	}
 }
 
+// SetStateSynced changes state but keeps the normalised playback position,
+// so cycles like walk and run stay in step instead of restarting
+func (ac *AnimationController) SetStateSynced(state AnimationState) {
+	if state == ac.currentState {
+		return
+	}
+
+	progress := 0.0
+	if current, exists := ac.animations[ac.currentState]; exists {
+		if total := current.TotalDuration(); total > 0 {
+			progress = current.CurrentTime / total
+		}
+	}
+
+	ac.SetState(state)
+
+	if next, exists := ac.animations[state]; exists {
+		next.CurrentTime = progress * next.TotalDuration()
+		next.lastStep = next.stepAt(next.CurrentTime)
+		next.started = true
+	}
+}
+
 // GetCurrentState returns the current animation state
 func (ac *AnimationController) GetCurrentState() AnimationState {
 	return ac.currentState
@@ -0,0 +1,166 @@
+package entities
+
+import "sort"
+
+// AnimationStateConfig describes how a state behaves inside an AnimationStateMachine
+type AnimationStateConfig struct {
+	State    AnimationState
+	Priority int            // Transitions need a higher priority to interrupt this state while it is locked
+	PlayOnce bool           // Play the animation to the end, then move to ReturnTo
+	ReturnTo AnimationState // State entered when a play-once animation finishes
+}
+
+// AnimationTransition is a guarded edge between animation states
+type AnimationTransition struct {
+	From     []AnimationState // States this transition applies to (empty means any state)
+	To       AnimationState   // Destination state
+	Guard    func() bool      // Condition that must hold for the transition to fire (nil always fires)
+	Priority int              // Higher priority transitions are evaluated first
+	Via      []AnimationState // Transition-only clips played in order before entering To
+	KeepTime bool             // Carry the normalised playback position over instead of restarting
+}
+
+// AnimationStateMachine drives an AnimationController from declarative states and transitions.
+// It holds no entity-specific logic; guards close over whatever entity owns the machine.
+type AnimationStateMachine struct {
+	controller  *AnimationController
+	states      map[AnimationState]AnimationStateConfig
+	transitions []AnimationTransition
+	queue       []AnimationState // Remaining clips and final target of an in-progress transition
+}
+
+// NewAnimationStateMachine creates a state machine and puts the controller in the initial state
+func NewAnimationStateMachine(controller *AnimationController, initial AnimationState) *AnimationStateMachine {
+	sm := &AnimationStateMachine{
+		controller: controller,
+		states:     make(map[AnimationState]AnimationStateConfig),
+	}
+	controller.SetState(initial)
+	return sm
+}
+
+// AddState registers behaviour for a state. States without a config loop freely at priority 0.
+func (sm *AnimationStateMachine) AddState(config AnimationStateConfig) {
+	sm.states[config.State] = config
+}
+
+// AddTransition registers a transition. Transitions with equal priority keep insertion order.
+func (sm *AnimationStateMachine) AddTransition(transition AnimationTransition) {
+	sm.transitions = append(sm.transitions, transition)
+	sort.SliceStable(sm.transitions, func(i, j int) bool {
+		return sm.transitions[i].Priority > sm.transitions[j].Priority
+	})
+}
+
+// Current returns the state currently playing
+func (sm *AnimationStateMachine) Current() AnimationState {
+	return sm.controller.GetCurrentState()
+}
+
+// ForceState jumps straight to a state, abandoning any in-progress transition
+func (sm *AnimationStateMachine) ForceState(state AnimationState) {
+	sm.queue = nil
+	sm.controller.SetState(state)
+}
+
+// Update advances finished clips and fires the highest priority matching transition.
+// Call it once per frame before updating the controller.
+func (sm *AnimationStateMachine) Update() {
+	current := sm.Current()
+
+	// Finished clips hand over to the next queued state or their return state
+	if sm.isLocked(current) && sm.currentFinished() {
+		sm.advanceQueue(current)
+		current = sm.Current()
+	}
+
+	for _, transition := range sm.transitions {
+		if !transition.appliesTo(current) {
+			continue
+		}
+		if transition.Guard != nil && !transition.Guard() {
+			continue
+		}
+
+		// The highest priority match wins, even if it keeps us where we are
+		if transition.To == current && len(sm.queue) == 0 {
+			return
+		}
+		if sm.isLocked(current) && transition.Priority <= sm.states[current].Priority {
+			return
+		}
+		if sm.isQueuedTarget(transition.To) {
+			return
+		}
+
+		sm.begin(transition)
+		return
+	}
+}
+
+// begin starts a transition, playing any transition-only clips first
+func (sm *AnimationStateMachine) begin(transition AnimationTransition) {
+	if len(transition.Via) == 0 {
+		sm.queue = nil
+		sm.enter(transition.To, transition.KeepTime)
+		return
+	}
+
+	sm.queue = append(append([]AnimationState{}, transition.Via[1:]...), transition.To)
+	sm.enter(transition.Via[0], false)
+}
+
+// advanceQueue moves on from a finished clip or play-once state
+func (sm *AnimationStateMachine) advanceQueue(current AnimationState) {
+	if len(sm.queue) > 0 {
+		next := sm.queue[0]
+		sm.queue = sm.queue[1:]
+		sm.enter(next, false)
+		return
+	}
+
+	if config, exists := sm.states[current]; exists && config.PlayOnce {
+		sm.enter(config.ReturnTo, false)
+	}
+}
+
+// enter switches the controller to a state
+func (sm *AnimationStateMachine) enter(state AnimationState, keepTime bool) {
+	if keepTime {
+		sm.controller.SetStateSynced(state)
+	} else {
+		sm.controller.SetState(state)
+	}
+}
+
+// isLocked reports whether the current state must finish before ordinary transitions apply
+func (sm *AnimationStateMachine) isLocked(state AnimationState) bool {
+	return len(sm.queue) > 0 || sm.states[state].PlayOnce
+}
+
+// isQueuedTarget reports whether a state is already the destination of an in-progress transition
+func (sm *AnimationStateMachine) isQueuedTarget(state AnimationState) bool {
+	return len(sm.queue) > 0 && sm.queue[len(sm.queue)-1] == state
+}
+
+// currentFinished reports whether the current animation has played through.
+// States with no animation count as finished so they can never lock the machine.
+func (sm *AnimationStateMachine) currentFinished() bool {
+	if sm.controller.GetAnimation(sm.Current()) == nil {
+		return true
+	}
+	return sm.controller.IsCurrentAnimationFinished()
+}
+
+// appliesTo reports whether a transition can fire from the given state
+func (t AnimationTransition) appliesTo(state AnimationState) bool {
+	if len(t.From) == 0 {
+		return true
+	}
+	for _, from := range t.From {
+		if from == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package entities
+
+import (
+	"math"
+	"testing"
+)
+
+// newTestMachineController creates a controller with every state registered
+func newTestMachineController() *AnimationController {
+	controller := NewAnimationController(CreateTestSpriteSheet(), 32, 32)
+	controller.AddAnimation(AnimationIdle, 0, 4, 0.2, true)
+	controller.AddAnimation(AnimationWalk, 4, 4, 0.1, true)
+	controller.AddAnimation(AnimationJump, 8, 2, 0.1, false)
+	controller.AddAnimation(AnimationFall, 10, 2, 0.15, true)
+	controller.AddAnimation(AnimationDamage, 16, 2, 0.1, false)
+	controller.AddAnimation(AnimationJumpStart, 8, 1, 0.05, false)
+	controller.AddAnimation(AnimationLand, 8, 1, 0.1, false)
+	return controller
+}
+
+func TestAnimationStateMachine_HighestPriorityWins(t *testing.T) {
+	controller := newTestMachineController()
+	sm := NewAnimationStateMachine(controller, AnimationIdle)
+
+	moving, damaged := false, false
+	sm.AddTransition(AnimationTransition{To: AnimationWalk, Priority: 10, Guard: func() bool { return moving }})
+	sm.AddTransition(AnimationTransition{To: AnimationDamage, Priority: 100, Guard: func() bool { return damaged }})
+	sm.AddTransition(AnimationTransition{To: AnimationIdle, Priority: 0})
+
+	sm.Update()
+	if sm.Current() != AnimationIdle {
+		t.Errorf("Expected Idle with no guards true, got %v", sm.Current())
+	}
+
+	moving = true
+	sm.Update()
+	if sm.Current() != AnimationWalk {
+		t.Errorf("Expected Walk when moving, got %v", sm.Current())
+	}
+
+	// Damage outranks walking even though both guards hold
+	damaged = true
+	sm.Update()
+	if sm.Current() != AnimationDamage {
+		t.Errorf("Expected Damage to override Walk, got %v", sm.Current())
+	}
+
+	// Staying in the winning state must not restart it
+	controller.Update(0.05)
+	sm.Update()
+	if controller.GetAnimation(AnimationDamage).CurrentTime == 0 {
+		t.Error("Re-selecting the current state should not reset its animation")
+	}
+}
+
+func TestAnimationStateMachine_FromFilter(t *testing.T) {
+	controller := newTestMachineController()
+	sm := NewAnimationStateMachine(controller, AnimationIdle)
+
+	sm.AddTransition(AnimationTransition{
+		From: []AnimationState{AnimationFall},
+		To:   AnimationLand,
+	})
+
+	sm.Update()
+	if sm.Current() != AnimationIdle {
+		t.Errorf("Transition from Fall should not fire in Idle, got %v", sm.Current())
+	}
+
+	sm.ForceState(AnimationFall)
+	sm.Update()
+	if sm.Current() != AnimationLand {
+		t.Errorf("Expected Land from Fall, got %v", sm.Current())
+	}
+}
+
+func TestAnimationStateMachine_PlayOnceReturns(t *testing.T) {
+	controller := newTestMachineController()
+	sm := NewAnimationStateMachine(controller, AnimationLand)
+	sm.AddState(AnimationStateConfig{State: AnimationLand, Priority: 20, PlayOnce: true, ReturnTo: AnimationIdle})
+
+	moving := true
+	sm.AddTransition(AnimationTransition{To: AnimationWalk, Priority: 10, Guard: func() bool { return moving }})
+
+	// Lower priority transitions can't cut the land squash short
+	sm.Update()
+	if sm.Current() != AnimationLand {
+		t.Errorf("Land should be locked against lower priority transitions, got %v", sm.Current())
+	}
+
+	controller.Update(0.2)
+	moving = false
+	sm.Update()
+	if sm.Current() != AnimationIdle {
+		t.Errorf("Expected return to Idle after Land finished, got %v", sm.Current())
+	}
+}
+
+func TestAnimationStateMachine_PlayOnceInterruptedByHigherPriority(t *testing.T) {
+	controller := newTestMachineController()
+	sm := NewAnimationStateMachine(controller, AnimationLand)
+	sm.AddState(AnimationStateConfig{State: AnimationLand, Priority: 20, PlayOnce: true, ReturnTo: AnimationIdle})
+	sm.AddTransition(AnimationTransition{To: AnimationDamage, Priority: 100})
+
+	sm.Update()
+	if sm.Current() != AnimationDamage {
+		t.Errorf("Higher priority transition should interrupt Land, got %v", sm.Current())
+	}
+}
+
+func TestAnimationStateMachine_TransitionClips(t *testing.T) {
+	controller := newTestMachineController()
+	sm := NewAnimationStateMachine(controller, AnimationIdle)
+	sm.AddState(AnimationStateConfig{State: AnimationJumpStart, Priority: 45})
+
+	jumping := false
+	sm.AddTransition(AnimationTransition{
+		From:     []AnimationState{AnimationIdle},
+		To:       AnimationJump,
+		Via:      []AnimationState{AnimationJumpStart},
+		Priority: 50,
+		Guard:    func() bool { return jumping },
+	})
+	sm.AddTransition(AnimationTransition{To: AnimationJump, Priority: 40, Guard: func() bool { return jumping }})
+
+	jumping = true
+	sm.Update()
+	if sm.Current() != AnimationJumpStart {
+		t.Fatalf("Expected JumpStart clip first, got %v", sm.Current())
+	}
+
+	// The plain jump transition has lower priority than the clip and must wait
+	controller.Update(0.01)
+	sm.Update()
+	if sm.Current() != AnimationJumpStart {
+		t.Errorf("Clip should play through, got %v", sm.Current())
+	}
+
+	controller.Update(0.1)
+	sm.Update()
+	if sm.Current() != AnimationJump {
+		t.Errorf("Expected Jump after clip finished, got %v", sm.Current())
+	}
+}
+
+func TestAnimationStateMachine_KeepTime(t *testing.T) {
+	controller := newTestMachineController()
+	sm := NewAnimationStateMachine(controller, AnimationIdle)
+
+	walking := true
+	sm.AddTransition(AnimationTransition{To: AnimationWalk, Priority: 10, Guard: func() bool { return walking }})
+	sm.AddTransition(AnimationTransition{To: AnimationIdle, Priority: 0, KeepTime: true})
+
+	sm.Update()
+	controller.Update(0.2) // Halfway through the 0.4s walk cycle
+
+	walking = false
+	sm.Update()
+
+	idle := controller.GetAnimation(AnimationIdle)
+	if math.Abs(idle.CurrentTime-0.4) > 1e-9 {
+		t.Errorf("Expected idle to start halfway through its 0.8s cycle, got %f", idle.CurrentTime)
+	}
+}
+
+func TestPlayer_JumpAndLandAnimations(t *testing.T) {
+	player := NewPlayer(100, 300, CreateTestSpriteSheet())
+	deltaTime := 1.0 / 60.0
+
+	// Settle on the fallback ground
+	for i := 0; i < 10; i++ {
+		player.Update(deltaTime)
+	}
+	if player.GetAnimationState() != AnimationIdle {
+		t.Fatalf("Expected Idle on ground, got %v", player.GetAnimationState())
+	}
+
+	player.Jump()
+	player.Update(deltaTime)
+	if player.GetAnimationState() != AnimationJumpStart {
+		t.Errorf("Expected JumpStart after jumping, got %v", player.GetAnimationState())
+	}
+
+	sawJump, sawLand := false, false
+	for i := 0; i < 120; i++ {
+		player.Update(deltaTime)
+		switch player.GetAnimationState() {
+		case AnimationJump:
+			sawJump = true
+		case AnimationLand:
+			sawLand = true
+		}
+	}
+
+	if !sawJump {
+		t.Error("Expected Jump animation while rising")
+	}
+	if !sawLand {
+		t.Error("Expected Land animation on touching down")
+	}
+	if player.GetAnimationState() != AnimationIdle {
+		t.Errorf("Expected Idle after landing, got %v", player.GetAnimationState())
+	}
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"math"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -59,9 +60,9 @@ func TestAnimation_Update(t *testing.T) {
 	}
 
 	// Test looping
-	anim.Update(0.5) // Should complete loop and reset
-	if anim.CurrentTime != 0 {
-		t.Errorf("Looping animation should reset CurrentTime to 0, got %f", anim.CurrentTime)
+	anim.Update(0.5) // Should complete the loop, keeping the 0.1 seconds left over
+	if math.Abs(anim.CurrentTime-0.1) > 1e-9 {
+		t.Errorf("Looping animation should carry the leftover 0.1s into the next cycle, got %f", anim.CurrentTime)
 	}
 }
 
@@ -174,3 +175,183 @@ func TestAnimation_FrameIndexing(t *testing.T) {
 		t.Fatal("Looped frame should not be nil")
 	}
 }
+
+func TestAnimation_PerFrameDurations(t *testing.T) {
+	img := ebiten.NewImage(96, 32)
+	anim := NewAnimation(img, 32, 32, 3, 0.1, true)
+	anim.FrameDurations = []float64{0.1, 0.5, 0.2}
+
+	if total := anim.TotalDuration(); math.Abs(total-0.8) > 1e-9 {
+		t.Errorf("Expected total duration 0.8, got %f", total)
+	}
+
+	anim.Update(0.15)
+	if anim.CurrentFrameIndex() != 1 {
+		t.Errorf("Expected frame 1 at 0.15s, got %d", anim.CurrentFrameIndex())
+	}
+
+	// Frame 1 is held for 0.5s, so we should still be on it at 0.55s
+	anim.Update(0.4)
+	if anim.CurrentFrameIndex() != 1 {
+		t.Errorf("Expected frame 1 at 0.55s, got %d", anim.CurrentFrameIndex())
+	}
+
+	anim.Update(0.1)
+	if anim.CurrentFrameIndex() != 2 {
+		t.Errorf("Expected frame 2 at 0.65s, got %d", anim.CurrentFrameIndex())
+	}
+}
+
+func TestAnimation_PlaybackModes(t *testing.T) {
+	img := ebiten.NewImage(128, 32)
+
+	testCases := []struct {
+		name     string
+		mode     PlaybackMode
+		expected []int
+	}{
+		{"Forward", PlaybackForward, []int{0, 1, 2, 3}},
+		{"Reverse", PlaybackReverse, []int{3, 2, 1, 0}},
+		{"PingPong", PlaybackPingPong, []int{0, 1, 2, 3, 2, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			anim := NewAnimation(img, 32, 32, 4, 0.1, true)
+			anim.Mode = tc.mode
+
+			for i, expected := range tc.expected {
+				if got := anim.CurrentFrameIndex(); got != expected {
+					t.Errorf("Step %d: expected frame %d, got %d", i, expected, got)
+				}
+				anim.Update(0.1)
+			}
+
+			// A full cycle should bring us back to the first frame
+			if got := anim.CurrentFrameIndex(); got != tc.expected[0] {
+				t.Errorf("Expected loop back to frame %d, got %d", tc.expected[0], got)
+			}
+		})
+	}
+}
+
+func TestAnimation_Speed(t *testing.T) {
+	img := ebiten.NewImage(64, 32)
+	anim := NewAnimation(img, 32, 32, 2, 0.5, true)
+	anim.Speed = 2.0
+
+	anim.Update(0.3) // 0.6s of animation time at double speed
+	if anim.CurrentFrameIndex() != 1 {
+		t.Errorf("Expected frame 1 at double speed, got %d", anim.CurrentFrameIndex())
+	}
+
+	// Zero speed falls back to normal playback
+	anim.Reset()
+	anim.Speed = 0
+	anim.Update(0.3)
+	if anim.CurrentFrameIndex() != 0 {
+		t.Errorf("Expected frame 0 at normal speed, got %d", anim.CurrentFrameIndex())
+	}
+}
+
+func TestAnimation_EventsFireForSkippedFrames(t *testing.T) {
+	img := ebiten.NewImage(128, 32)
+	anim := NewAnimation(img, 32, 32, 4, 0.1, false)
+	anim.AddEvent("footstep", 1, 3)
+
+	var fired []int
+	anim.SetEventHandler(func(name string, frame int) {
+		if name != "footstep" {
+			t.Errorf("Unexpected event %q", name)
+		}
+		fired = append(fired, frame)
+	})
+
+	// One large delta skips straight past every frame
+	anim.Update(1.0)
+
+	if len(fired) != 2 || fired[0] != 1 || fired[1] != 3 {
+		t.Errorf("Expected footsteps on frames [1 3], got %v", fired)
+	}
+
+	// Finished animations don't fire again
+	anim.Update(1.0)
+	if len(fired) != 2 {
+		t.Errorf("Expected no more events after finishing, got %v", fired)
+	}
+}
+
+func TestAnimation_EventsFireOncePerCycle(t *testing.T) {
+	img := ebiten.NewImage(128, 32)
+	anim := NewAnimation(img, 32, 32, 4, 0.1, true)
+	anim.Mode = PlaybackPingPong
+	anim.AddEvent("bounce", 0, 3)
+
+	count := 0
+	anim.SetEventHandler(func(name string, frame int) {
+		count++
+	})
+
+	// Small steps through exactly one ping-pong cycle (0 1 2 3 2 1) and back to 0
+	for i := 0; i < 60; i++ {
+		anim.Update(0.01)
+	}
+
+	// Frame 0 at the start, frame 3 at the turn, frame 0 again on loop
+	if count != 3 {
+		t.Errorf("Expected 3 events over one cycle, got %d", count)
+	}
+}
+
+func TestAnimation_LoopKeepsLeftoverTime(t *testing.T) {
+	img := ebiten.NewImage(128, 32)
+	anim := NewAnimation(img, 32, 32, 4, 0.1, true)
+	anim.AddEvent("footstep", 1)
+
+	footsteps := 0
+	anim.SetEventHandler(func(name string, frame int) {
+		footsteps++
+	})
+
+	// 0.35s in, a 0.2s delta wraps round to 0.15s: frame 1, and its footstep
+	anim.Update(0.35)
+	anim.Update(0.2)
+	if math.Abs(anim.CurrentTime-0.15) > 1e-9 || anim.CurrentFrameIndex() != 1 {
+		t.Errorf("Expected frame 1 at 0.15s after wrapping, got frame %d at %v", anim.CurrentFrameIndex(), anim.CurrentTime)
+	}
+	if footsteps != 2 {
+		t.Errorf("Expected a footstep in each cycle, got %d", footsteps)
+	}
+
+	// A 1s delta runs from 0.15s to 1.15s, passing frame 1 twice more
+	anim.Update(1.0)
+	if footsteps != 4 {
+		t.Errorf("Expected a footstep in each cycle crossed, got %d", footsteps)
+	}
+	if math.Abs(anim.CurrentTime-0.35) > 1e-9 {
+		t.Errorf("Expected to end 0.35s into the cycle, got %v", anim.CurrentTime)
+	}
+}
+
+func TestAnimationController_FrameEvents(t *testing.T) {
+	controller := NewAnimationController(CreateTestSpriteSheet(), 32, 32)
+	controller.AddAnimation(AnimationWalk, 4, 4, 0.1, true)
+	controller.AddFrameEvent(AnimationWalk, "footstep", 1, 3)
+
+	var states []AnimationState
+	controller.SetEventHandler(func(state AnimationState, name string, frame int) {
+		states = append(states, state)
+	})
+
+	controller.SetState(AnimationWalk)
+	controller.Update(0.35)
+
+	if len(states) != 2 {
+		t.Fatalf("Expected 2 footstep events, got %d", len(states))
+	}
+	for _, state := range states {
+		if state != AnimationWalk {
+			t.Errorf("Expected events from AnimationWalk, got %v", state)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package entities
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Tags of the areas the game spawns from level objects
+const (
+	TagCheckpoint = "checkpoint"
+	TagExit       = "exit"
+	TagTrigger    = "trigger"
+)
+
+// AreaHandler is called with the area and the entity that entered or left it
+type AreaHandler func(area *Area, other Entity)
+
+// Area is an invisible region that reacts to entities entering and leaving it, such as a
+// checkpoint, a level exit or a trigger. It needs a world with a spatial hash.
+type Area struct {
+	EntityBase
+	Name                string  // Name of the level object the area came from, if any
+	X, Y, Width, Height float64 // Region in world pixels
+
+	OnEnter AreaHandler // Called when an entity starts overlapping the area
+	OnExit  AreaHandler // Called when an entity stops overlapping the area or is despawned
+
+	occupants int // Entities currently overlapping the area
+}
+
+// NewArea creates an area covering a region with the given tags
+func NewArea(name string, x, y, width, height float64, tags ...string) *Area {
+	return &Area{
+		EntityBase: NewEntityBase(tags...),
+		Name:       name,
+		X:          x,
+		Y:          y,
+		Width:      width,
+		Height:     height,
+	}
+}
+
+// Update does nothing; areas only react to contacts
+func (a *Area) Update(deltaTime float64) {}
+
+// Draw does nothing; areas are invisible
+func (a *Area) Draw(screen *ebiten.Image) {}
+
+// GetBounds returns the area's region
+func (a *Area) GetBounds() (x, y, width, height float64) {
+	return a.X, a.Y, a.Width, a.Height
+}
+
+// IsOccupied returns whether any entity is overlapping the area
+func (a *Area) IsOccupied() bool {
+	return a.occupants > 0
+}
+
+// OnContactEnter implements ContactListener
+func (a *Area) OnContactEnter(other Entity) {
+	a.occupants++
+	if a.OnEnter != nil {
+		a.OnEnter(a, other)
+	}
+}
+
+// OnContactExit implements ContactListener
+func (a *Area) OnContactExit(other Entity) {
+	a.occupants--
+	if a.OnExit != nil {
+		a.OnExit(a, other)
+	}
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"testing"
+
+	"ebiten-platformer/collision"
+)
+
+func TestArea_EnterAndExit(t *testing.T) {
+	var log []string
+	world := NewWorld(collision.NewSpatialHash(32))
+	area := NewArea("door", 100, 0, 32, 64, TagTrigger)
+	visitor := newTestEntity("visitor", 0, 0, &log)
+
+	var entered, exited []Entity
+	area.OnEnter = func(a *Area, other Entity) { entered = append(entered, other) }
+	area.OnExit = func(a *Area, other Entity) { exited = append(exited, other) }
+	world.Spawn(area)
+	world.Spawn(visitor)
+
+	steps := []struct {
+		name     string
+		x        float64
+		entered  int
+		exited   int
+		occupied bool
+	}{
+		{"outside", 0, 0, 0, false},
+		{"walks in", 90, 1, 0, true},
+		{"stays inside", 110, 1, 0, true},
+		{"walks out", 200, 1, 1, false},
+	}
+
+	for _, step := range steps {
+		visitor.x = step.x
+		world.Update(1.0 / 60.0)
+		if len(entered) != step.entered || len(exited) != step.exited || area.IsOccupied() != step.occupied {
+			t.Errorf("%s: expected %d enters, %d exits and occupied=%v; got %d, %d and %v",
+				step.name, step.entered, step.exited, step.occupied, len(entered), len(exited), area.IsOccupied())
+		}
+	}
+	if entered[0] != visitor {
+		t.Error("Expected the handler to be given the visitor")
+	}
+	if world.FirstWithTag(TagTrigger) != area {
+		t.Error("Expected to find the area by its tag")
+	}
+}
@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"math"
+
+	"ebiten-platformer/collision"
+)
+
+// Body is a box that moves through the level under gravity. Entities embed it to share the
+// player's collision handling: walls stop it, it lands on floors, slopes and one-way
+// platforms, and no speed can tunnel it through a tile.
+type Body struct {
+	X, Y                 float64 // Top-left corner
+	Width, Height        float64
+	VelocityX, VelocityY float64
+	Gravity              float64 // Downward acceleration (px/s²), 0 for bodies that fly
+
+	OnGround     bool // Standing on the level
+	IgnoreOneWay bool // Fall through one-way platforms instead of landing on them
+
+	contact collision.Result // Reused by checkCollision so checks don't allocate
+}
+
+// MoveResult describes what a body hit while moving
+type MoveResult struct {
+	Horizontal collision.SweepResult // Sweep along X; Hit when a wall stopped the body
+	Vertical   collision.SweepResult // Sweep along Y; NormalY is negative for ground, positive for a ceiling
+	Contact    *collision.Result     // Collision at the final position, checked by Update; valid until the body's next check
+}
+
+// HitWall returns whether a wall stopped the body's horizontal movement
+func (r MoveResult) HitWall() bool {
+	return r.Horizontal.Hit
+}
+
+// Landed returns whether the body's downward movement was stopped by the ground
+func (r MoveResult) Landed() bool {
+	return r.Vertical.Hit && r.Vertical.NormalY < 0
+}
+
+// HitCeiling returns whether the body's upward movement was stopped by a ceiling
+func (r MoveResult) HitCeiling() bool {
+	return r.Vertical.Hit && r.Vertical.NormalY > 0
+}
+
+// NewBody creates a body with its top-left corner at (x, y)
+func NewBody(x, y, width, height, gravity float64) Body {
+	return Body{X: x, Y: y, Width: width, Height: height, Gravity: gravity}
+}
+
+// Update moves the body by its velocity for one frame, falling under gravity while
+// airborne, and updates OnGround from where it ends up. Without a level the body moves freely.
+func (b *Body) Update(level collision.Checker, deltaTime float64) MoveResult {
+	deltaX := b.VelocityX * deltaTime
+	deltaY := b.Fall(deltaTime)
+	if level == nil {
+		b.X += deltaX
+		b.Y += deltaY
+		return MoveResult{}
+	}
+
+	wasOnGround := b.OnGround
+	b.OnGround = false
+	result := b.MoveAndCollide(level, deltaX, deltaY)
+	result.Contact = b.checkCollision(level, b.X, b.Y)
+	b.updateGround(result.Contact, wasOnGround)
+	return result
+}
+
+// Fall applies a frame of gravity while the body is airborne and returns the distance it
+// falls. Velocity changes linearly over the frame, so the average velocity gives the exact
+// distance at any frame rate.
+func (b *Body) Fall(deltaTime float64) float64 {
+	startVelocityY := b.VelocityY
+	if !b.OnGround {
+		b.VelocityY += b.Gravity * deltaTime
+	}
+	return (startVelocityY + b.VelocityY) / 2 * deltaTime
+}
+
+// MoveAndCollide moves the body one axis at a time so walls and floors are resolved
+// independently. Each axis is a single sweep through the level that stops the body exactly
+// where it first touches a tile, and velocity into a surface that was hit is removed.
+// One-way platforms stop a falling body unless IgnoreOneWay is set; a rising body passes
+// through them.
+func (b *Body) MoveAndCollide(level collision.Checker, deltaX, deltaY float64) MoveResult {
+	var result MoveResult
+	if deltaX != 0 {
+		result.Horizontal = level.Sweep(b.X, b.Y, b.Width, b.Height, deltaX, 0, false)
+		b.X = result.Horizontal.X
+		if result.Horizontal.Hit {
+			b.VelocityX = 0
+		}
+	}
+
+	if deltaY != 0 {
+		landOnOneWay := deltaY > 0 && !b.IgnoreOneWay
+		result.Vertical = level.Sweep(b.X, b.Y, b.Width, b.Height, 0, deltaY, landOnOneWay)
+		b.Y = result.Vertical.Y
+		if result.Vertical.Hit {
+			b.VelocityY = 0
+		}
+	}
+	return result
+}
+
+// checkCollision checks what the body would touch with its top-left corner at (x, y). The
+// result is the body's own and is overwritten by the next check.
+func (b *Body) checkCollision(level collision.Checker, x, y float64) *collision.Result {
+	level.CheckCollisionInto(x, y, b.Width, b.Height, &b.contact)
+	return &b.contact
+}
+
+// updateGround sets OnGround from a collision check at the body's final position and
+// returns whether the body has just landed. Ground under a rising body, such as a slope
+// or one-way platform, is being passed through rather than stood on.
+func (b *Body) updateGround(result *collision.Result, wasOnGround bool) bool {
+	landed := false
+	if result.OnGround && !b.OnGround && b.VelocityY >= 0 {
+		b.OnGround = true
+		landed = true
+		if b.VelocityY > 0 {
+			b.VelocityY = 0
+		}
+	}
+
+	// Use hysteresis for ground state to reduce jitter, but only for very small movements
+	if !result.OnGround && !result.CollisionY {
+		// Only apply hysteresis if the body is essentially stationary both horizontally and vertically.
+		// This prevents false ground detection when walking off platforms.
+		b.OnGround = wasOnGround && math.Abs(b.VelocityY) < 0.5 && math.Abs(b.VelocityX) < 5.0
+	}
+	return landed
+}
+
+// GetBounds returns the body's collision rectangle
+func (b *Body) GetBounds() (float64, float64, float64, float64) {
+	return b.X, b.Y, b.Width, b.Height
+}
+
+// GetPosition returns the body's current position
+func (b *Body) GetPosition() (float64, float64) {
+	return b.X, b.Y
+}
+
+// SetPosition sets the body's position
+func (b *Body) SetPosition(x, y float64) {
+	b.X = x
+	b.Y = y
+}
+
+// GetVelocity returns the body's current velocity
+func (b *Body) GetVelocity() (float64, float64) {
+	return b.VelocityX, b.VelocityY
+}
+
+// GetVelocityY returns the body's Y velocity
+func (b *Body) GetVelocityY() float64 {
+	return b.VelocityY
+}
+
+// IsOnGround returns whether the body is currently on ground
+func (b *Body) IsOnGround() bool {
+	return b.OnGround
+}
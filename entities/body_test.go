@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"ebiten-platformer/collision"
+)
+
+// floorChecker is a level with a floor at floorY and a wall whose left side is at wallX
+type floorChecker struct {
+	floorY, wallX float64
+	landOnOneWay  bool // Whether the last vertical sweep asked to land on one-way platforms
+}
+
+func (f *floorChecker) CheckCollisionInto(x, y, width, height float64, result *collision.Result) {
+	result.Reset()
+	bottom := y + height
+	result.OnGround = bottom >= f.floorY && bottom <= f.floorY+2
+	result.Collided = result.OnGround
+}
+
+func (f *floorChecker) Sweep(x, y, width, height, deltaX, deltaY float64, landOnOneWay bool) collision.SweepResult {
+	result := collision.SweepResult{Time: 1, X: x + deltaX, Y: y + deltaY}
+	if deltaX > 0 && x+width+deltaX > f.wallX {
+		result = collision.SweepResult{Hit: true, Time: (f.wallX - x - width) / deltaX, X: f.wallX - width, Y: y, NormalX: -1}
+	}
+	if deltaY != 0 {
+		f.landOnOneWay = landOnOneWay
+	}
+	if deltaY > 0 && y+height+deltaY > f.floorY {
+		result = collision.SweepResult{Hit: true, Time: (f.floorY - y - height) / deltaY, X: x, Y: f.floorY - height, NormalY: -1}
+	}
+	return result
+}
+
+func TestBody_Fall(t *testing.T) {
+	body := NewBody(0, 0, 32, 32, 500)
+	if distance := body.Fall(0.5); distance != 62.5 || body.VelocityY != 250 {
+		t.Errorf("Expected to fall 62.5px reaching 250px/s, fell %v reaching %v", distance, body.VelocityY)
+	}
+
+	body.OnGround = true
+	body.VelocityY = 0
+	if distance := body.Fall(0.5); distance != 0 || body.VelocityY != 0 {
+		t.Errorf("A grounded body shouldn't fall, fell %v", distance)
+	}
+}
+
+func TestBody_UpdateLands(t *testing.T) {
+	level := &floorChecker{floorY: 200, wallX: math.Inf(1)}
+	body := NewBody(0, 0, 32, 32, 500)
+
+	landed := false
+	for i := 0; i < 120 && !landed; i++ {
+		result := body.Update(level, 1.0/60.0)
+		landed = result.Landed()
+		if landed && (result.Contact == nil || !result.Contact.OnGround) {
+			t.Error("Expected the final collision check to report ground")
+		}
+	}
+
+	if !landed || !body.OnGround {
+		t.Fatalf("Expected the body to land, on ground=%v", body.OnGround)
+	}
+	if body.Y != 168 || body.VelocityY != 0 {
+		t.Errorf("Expected to rest on the floor at Y=168, got Y=%v with velocity %v", body.Y, body.VelocityY)
+	}
+	if !level.landOnOneWay {
+		t.Error("Falling bodies should land on one-way platforms")
+	}
+
+	// Resting bodies stay put
+	body.Update(level, 1.0/60.0)
+	if body.Y != 168 || !body.OnGround {
+		t.Errorf("Expected the body to stay on the floor, got Y=%v on ground=%v", body.Y, body.OnGround)
+	}
+}
+
+func TestBody_MoveAndCollide(t *testing.T) {
+	tests := []struct {
+		name           string
+		deltaX, deltaY float64
+		ignoreOneWay   bool
+		wantX, wantY   float64
+		hitWall        bool
+		landed         bool
+	}{
+		{"moves freely", 10, -10, false, 10, 90, false, false},
+		{"stopped by a wall", 100, 0, false, 68, 100, true, false},
+		{"lands on the floor", 0, 500, false, 0, 168, false, true},
+		{"slides along the floor into the wall", 100, 500, false, 68, 168, true, true},
+		{"falls through one-way platforms", 0, 10, true, 0, 110, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := &floorChecker{floorY: 200, wallX: 100}
+			body := NewBody(0, 100, 32, 32, 500)
+			body.VelocityX, body.VelocityY = 50, 50
+			body.IgnoreOneWay = tt.ignoreOneWay
+
+			result := body.MoveAndCollide(level, tt.deltaX, tt.deltaY)
+			if body.X != tt.wantX || body.Y != tt.wantY {
+				t.Errorf("Expected to end at (%v, %v), got (%v, %v)", tt.wantX, tt.wantY, body.X, body.Y)
+			}
+			if result.HitWall() != tt.hitWall || (body.VelocityX == 0) != tt.hitWall {
+				t.Errorf("Expected hit wall=%v, got %v with X velocity %v", tt.hitWall, result.HitWall(), body.VelocityX)
+			}
+			if result.Landed() != tt.landed || (body.VelocityY == 0) != tt.landed {
+				t.Errorf("Expected landed=%v, got %v with Y velocity %v", tt.landed, result.Landed(), body.VelocityY)
+			}
+			if tt.deltaY > 0 && level.landOnOneWay == tt.ignoreOneWay {
+				t.Errorf("Expected landing on one-way platforms to be %v", !tt.ignoreOneWay)
+			}
+		})
+	}
+}
+
+func TestBody_UpdateWithoutLevel(t *testing.T) {
+	body := NewBody(0, 0, 32, 32, 0)
+	body.VelocityX, body.VelocityY = 60, -30
+	body.Update(nil, 0.5)
+
+	if x, y := body.GetPosition(); x != 30 || y != -15 {
+		t.Errorf("Expected a flying body to move to (30, -15), got (%v, %v)", x, y)
+	}
+}
@@ -0,0 +1,342 @@
+package entities
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand/v2"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// TagCat is the tag of every cat
+const TagCat = "cat"
+
+// Cat size and movement
+const (
+	CatWidth            = 24
+	CatHeight           = 20
+	CatGravity          = 500.0
+	DefaultCatWalkSpeed = 20.0 // Wandering speed (px/s) while sad; happy cats trot at twice this
+
+	catEdgeProbeDepth = 4.0 // How far below its front foot a wandering cat looks for ground
+)
+
+// CatMood is how a cat feels. Sad cats become happy when the player gives them a heart.
+type CatMood int
+
+const (
+	CatSad CatMood = iota
+	CatHappy
+	catMoodCount
+)
+
+// String returns a readable name for the mood
+func (m CatMood) String() string {
+	switch m {
+	case CatSad:
+		return "Sad"
+	case CatHappy:
+		return "Happy"
+	default:
+		return "Unknown"
+	}
+}
+
+// catIdleTimes and catWanderTimes are the shortest and longest a cat idles or wanders for
+// before changing its mind, by mood. Happy cats idle less and wander more.
+var (
+	catIdleTimes   = [catMoodCount][2]float64{CatSad: {2, 5}, CatHappy: {0.5, 2}}
+	catWanderTimes = [catMoodCount][2]float64{CatSad: {0.5, 1.5}, CatHappy: {1, 3}}
+)
+
+// catSpriteSheet is created the first time a cat is spawned
+var catSpriteSheet *ebiten.Image
+
+// Cat is a stray cat NPC. It idles and wanders along whatever it stands on, turning back at
+// walls and edges, and stops to face the player when they come close. A sad cat asks for an
+// energy heart; giving it one makes it happy.
+type Cat struct {
+	// Position, size, velocity, gravity and ground state
+	Body
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	Key         string  // Identifies the cat in saved progress
+	Mood        CatMood // Use SetMood to change it so the animation follows
+	FacingRight bool
+	WalkSpeed   float64 // Wandering speed (px/s) while sad
+
+	// OnHelp is called when the player interacts with a sad cat. It returns whether the
+	// player could help, such as by having a heart to spare; the cat cheers up if so.
+	OnHelp func(cat *Cat) bool
+
+	animations   [catMoodCount]*AnimationController
+	level        collision.Checker
+	rng          *rand.Rand
+	wandering    bool
+	stateTimer   float64 // Time left before the cat changes between idling and wandering
+	playerNearby bool
+	time         float64 // Seconds since the cat was created, for the heart prompt's pulse
+	probe        collision.Result
+}
+
+// NewCat creates a sad cat standing with its top-left corner at (x, y). Cats start at
+// different points in their idle and wander cycles depending on where they are placed.
+func NewCat(key string, x, y float64) *Cat {
+	cat := &Cat{
+		Body:        NewBody(x, y, CatWidth, CatHeight, CatGravity),
+		EntityBase:  NewEntityBase(TagCat),
+		Key:         key,
+		FacingRight: true,
+		WalkSpeed:   DefaultCatWalkSpeed,
+		rng:         rand.New(rand.NewPCG(uint64(math.Float64bits(x)), uint64(math.Float64bits(y)))),
+	}
+
+	sheet := CatSpriteSheet()
+	for mood := range catMoodCount {
+		animations := NewAnimationController(sheet, CatWidth, CatHeight)
+		first := int(mood) * 4
+		animations.AddAnimation(AnimationIdle, first, 2, 0.5, true)
+		animations.AddAnimation(AnimationWalk, first+2, 2, 0.15, true)
+		cat.animations[mood] = animations
+	}
+	cat.stateTimer = cat.randomBetween(catIdleTimes[CatSad])
+	return cat
+}
+
+// SetLevel sets what the cat collides with; without a level it moves freely
+func (c *Cat) SetLevel(level collision.Checker) {
+	c.level = level
+}
+
+// SetMood changes how the cat feels and switches to that mood's animations
+func (c *Cat) SetMood(mood CatMood) {
+	if mood == c.Mood {
+		return
+	}
+	c.Mood = mood
+	c.wandering = false
+	c.stateTimer = c.randomBetween(catIdleTimes[mood])
+	c.animations[mood].SetState(AnimationIdle)
+}
+
+// IsHappy returns whether the player has cheered the cat up
+func (c *Cat) IsHappy() bool {
+	return c.Mood == CatHappy
+}
+
+// IsWandering returns whether the cat is walking rather than idling
+func (c *Cat) IsWandering() bool {
+	return c.wandering
+}
+
+// IsPlayerNearby returns whether the player was in reach at the last update
+func (c *Cat) IsPlayerNearby() bool {
+	return c.playerNearby
+}
+
+// GetAnimationState returns the current animation state
+func (c *Cat) GetAnimationState() AnimationState {
+	return c.animations[c.Mood].GetCurrentState()
+}
+
+// Speed returns how fast the cat wanders in its current mood
+func (c *Cat) Speed() float64 {
+	if c.Mood == CatHappy {
+		return c.WalkSpeed * 2
+	}
+	return c.WalkSpeed
+}
+
+// Update decides whether to idle, wander or wait for the player, then moves the cat
+func (c *Cat) Update(deltaTime float64) {
+	c.time += deltaTime
+
+	c.playerNearby = false
+	if world := c.World(); world != nil {
+		if player := world.FirstWithTag(TagPlayer); player != nil && withinReach(c, player, InteractRange) {
+			c.playerNearby = true
+			c.facePlayer(player)
+		}
+	}
+
+	if c.playerNearby {
+		c.wandering = false
+	} else {
+		c.updateWander(deltaTime)
+	}
+
+	c.VelocityX = 0
+	if c.wandering {
+		if c.OnGround && !c.groundAhead() {
+			c.FacingRight = !c.FacingRight
+		}
+		c.VelocityX = c.Speed()
+		if !c.FacingRight {
+			c.VelocityX = -c.VelocityX
+		}
+	}
+
+	if result := c.Body.Update(c.level, deltaTime); result.HitWall() {
+		c.FacingRight = !c.FacingRight
+	}
+
+	animations := c.animations[c.Mood]
+	if c.wandering {
+		animations.SetState(AnimationWalk)
+	} else {
+		animations.SetState(AnimationIdle)
+	}
+	animations.Update(deltaTime)
+}
+
+// updateWander counts down to the cat's next change between idling and wandering. Each
+// wander sets off in a random direction.
+func (c *Cat) updateWander(deltaTime float64) {
+	c.stateTimer -= deltaTime
+	if c.stateTimer > 0 {
+		return
+	}
+
+	c.wandering = !c.wandering
+	if c.wandering {
+		c.FacingRight = c.rng.IntN(2) == 0
+		c.stateTimer = c.randomBetween(catWanderTimes[c.Mood])
+	} else {
+		c.stateTimer = c.randomBetween(catIdleTimes[c.Mood])
+	}
+}
+
+// groundAhead returns whether there is safe ground under the cat's front foot, so it can
+// turn back at platform edges and in front of hazards instead of walking off
+func (c *Cat) groundAhead() bool {
+	if c.level == nil {
+		return true
+	}
+
+	footX := c.X + c.Width
+	if !c.FacingRight {
+		footX = c.X - 1
+	}
+	c.level.CheckCollisionInto(footX, c.Y+c.Height, 1, catEdgeProbeDepth, &c.probe)
+	return (c.probe.Collided || c.probe.OnGround) && !c.probe.DangerousTile
+}
+
+// facePlayer turns the cat towards the player
+func (c *Cat) facePlayer(player Entity) {
+	playerX, _, playerWidth, _ := player.GetBounds()
+	c.FacingRight = playerX+playerWidth/2 > c.X+c.Width/2
+}
+
+// randomBetween returns a random duration in a [shortest, longest] range
+func (c *Cat) randomBetween(times [2]float64) float64 {
+	return times[0] + c.rng.Float64()*(times[1]-times[0])
+}
+
+// CanInteract returns whether the player is in reach of a cat that still needs cheering up
+func (c *Cat) CanInteract(player *Player) bool {
+	return c.Mood == CatSad && withinReach(c, player, InteractRange)
+}
+
+// Interact asks OnHelp whether the player can help and, if so, cheers the cat up with a
+// burst of hearts
+func (c *Cat) Interact(player *Player) bool {
+	if !c.CanInteract(player) {
+		return false
+	}
+	if c.OnHelp != nil && !c.OnHelp(c) {
+		return false
+	}
+
+	c.SetMood(CatHappy)
+	if world := c.World(); world != nil {
+		world.Spawn(NewBurstEffect(c.X+c.Width/2, c.Y, heartColour))
+	}
+	return true
+}
+
+// Draw renders the cat, flipped to face its direction, with a pulsing heart over a sad
+// cat's head while the player is in reach
+func (c *Cat) Draw(screen *ebiten.Image) {
+	if frame := c.animations[c.Mood].GetCurrentFrame(); frame != nil {
+		op := &ebiten.DrawImageOptions{}
+		if !c.FacingRight {
+			op.GeoM.Scale(-1, 1)
+			op.GeoM.Translate(c.Width, 0)
+		}
+		op.GeoM.Translate(math.Round(c.X), math.Round(c.Y))
+		screen.DrawImage(frame, op)
+	}
+
+	if c.playerNearby && c.Mood == CatSad {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(0.5, 0.5)
+		op.GeoM.Translate(math.Round(c.X+(c.Width-HeartSize/2)/2), math.Round(c.Y-HeartSize/2-2))
+		op.ColorScale.ScaleAlpha(float32(0.65 + 0.35*math.Sin(2*math.Pi*c.time)))
+		screen.DrawImage(HeartSprite(), op)
+	}
+}
+
+// catColours are each mood's fur colour: a washed-out grey for sad, ginger for happy
+var catColours = [catMoodCount]color.RGBA{
+	CatSad:   {130, 138, 160, 255},
+	CatHappy: {240, 160, 60, 255},
+}
+
+// CatSpriteSheet returns the placeholder cat sprite sheet: a row of frames per mood, facing
+// right, with two idle frames followed by two walk frames. Sad cats droop their ears and
+// tail; happy cats hold their tail up.
+func CatSpriteSheet() *ebiten.Image {
+	if catSpriteSheet != nil {
+		return catSpriteSheet
+	}
+
+	catSpriteSheet = ebiten.NewImage(CatWidth*4, CatHeight*int(catMoodCount))
+	eye := color.RGBA{30, 30, 40, 255}
+	for mood := range catMoodCount {
+		fur := catColours[mood]
+		for frame := 0; frame < 4; frame++ {
+			originX, originY := frame*CatWidth, int(mood)*CatHeight
+			fill := func(x, y, width, height int, colour color.Color) {
+				rect := image.Rect(originX+x, originY+y, originX+x+width, originY+y+height)
+				catSpriteSheet.SubImage(rect).(*ebiten.Image).Fill(colour)
+			}
+
+			// The head bobs on the second idle frame; walk frames swap leg pairs
+			headY := 3
+			if frame == 1 {
+				headY = 4
+			}
+			if mood == CatSad {
+				headY++
+			}
+
+			fill(3, 9, 16, 7, fur)       // Body
+			fill(13, headY, 9, 7, fur)   // Head
+			fill(19, headY+3, 1, 1, eye) // Eye
+			if mood == CatHappy {
+				fill(14, headY-2, 2, 2, fur) // Ears up
+				fill(19, headY-2, 2, 2, fur)
+				fill(1, 2, 2, 8, fur) // Tail up
+			} else {
+				fill(12, headY+1, 2, 2, fur) // Ears drooping
+				fill(21, headY+1, 2, 2, fur)
+				fill(0, 13, 4, 2, fur) // Tail down
+			}
+
+			legShift := [4]int{0, 0, 1, -1}[frame]
+			for i, legX := range []int{4, 7, 14, 17} {
+				shift := legShift
+				if i%2 == 1 {
+					shift = -shift
+				}
+				fill(legX+shift, 16, 2, 4, fur)
+			}
+		}
+	}
+	return catSpriteSheet
+}
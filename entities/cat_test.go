@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// ledgeChecker is a level with a single 32px thick platform from left to right, its top at floorY
+type ledgeChecker struct {
+	floorY, left, right float64
+}
+
+func (l *ledgeChecker) overlapsPlatform(x, width float64) bool {
+	return x < l.right && x+width > l.left
+}
+
+func (l *ledgeChecker) CheckCollisionInto(x, y, width, height float64, result *collision.Result) {
+	result.Reset()
+	if !l.overlapsPlatform(x, width) {
+		return
+	}
+	bottom := y + height
+	result.OnGround = bottom >= l.floorY && bottom <= l.floorY+2
+	result.Collided = result.OnGround || (bottom > l.floorY && y < l.floorY+32)
+}
+
+func (l *ledgeChecker) Sweep(x, y, width, height, deltaX, deltaY float64, landOnOneWay bool) collision.SweepResult {
+	result := collision.SweepResult{Time: 1, X: x + deltaX, Y: y + deltaY}
+	if deltaY > 0 && l.overlapsPlatform(x, width) && y+height <= l.floorY && y+height+deltaY > l.floorY {
+		result = collision.SweepResult{Hit: true, Time: (l.floorY - y - height) / deltaY, X: x, Y: l.floorY - height, NormalY: -1}
+	}
+	return result
+}
+
+func TestCat_WandersOnPlatform(t *testing.T) {
+	ledge := &ledgeChecker{floorY: 200, left: 100, right: 260}
+	cat := NewCat("c", 150, 200-CatHeight)
+	cat.SetLevel(ledge)
+
+	for _, mood := range []CatMood{CatSad, CatHappy} {
+		cat.SetMood(mood)
+		minX, maxX := cat.X, cat.X
+		wandered, idled := false, false
+		for i := 0; i < 60*60; i++ {
+			cat.Update(1.0 / 60.0)
+			minX, maxX = min(minX, cat.X), max(maxX, cat.X)
+			if cat.IsWandering() {
+				wandered = true
+				if cat.GetAnimationState() != AnimationWalk {
+					t.Fatalf("Expected a wandering %s cat to walk, got %v", mood, cat.GetAnimationState())
+				}
+			} else {
+				idled = true
+			}
+		}
+
+		if !wandered || !idled {
+			t.Errorf("Expected a %s cat to both idle and wander in a minute, wandered=%v idled=%v", mood, wandered, idled)
+		}
+		if maxX-minX < 40 {
+			t.Errorf("Expected a %s cat to wander along the platform, covered %v px", mood, maxX-minX)
+		}
+		if minX < ledge.left-1 || maxX+CatWidth > ledge.right+1 {
+			t.Errorf("Expected a %s cat to stay on the platform, wandered %v to %v", mood, minX, maxX+CatWidth)
+		}
+		if cat.Y != ledge.floorY-CatHeight || !cat.IsOnGround() {
+			t.Errorf("Expected a %s cat to stay standing on the platform, y=%v", mood, cat.Y)
+		}
+	}
+}
+
+func TestCat_Interact(t *testing.T) {
+	ledge := &ledgeChecker{floorY: 200, left: 0, right: 400}
+	world := NewWorld(collision.NewSpatialHash(32))
+	cat := NewCat("c", 200, 200-CatHeight)
+	cat.SetLevel(ledge)
+	player := NewPlayer(50, 200-32, CreateTestSpriteSheet())
+	player.SetLevel(ledge)
+	world.Spawn(player)
+	world.Spawn(cat)
+
+	spare := false
+	helped := 0
+	cat.OnHelp = func(c *Cat) bool {
+		if spare {
+			helped++
+		}
+		return spare
+	}
+
+	world.Update(1.0 / 60.0)
+	if cat.IsPlayerNearby() || player.Interact() {
+		t.Fatal("Expected no interaction with the player out of reach")
+	}
+
+	// Walk up to the cat, which stops and turns to face the player
+	player.SetPosition(cat.X-player.Width-InteractRange+4, player.Y)
+	cat.FacingRight = true
+	world.Update(1.0 / 60.0)
+	if !cat.IsPlayerNearby() || cat.FacingRight || cat.IsWandering() {
+		t.Fatalf("Expected the cat to stop and face the player, nearby=%v right=%v", cat.IsPlayerNearby(), cat.FacingRight)
+	}
+	cat.Draw(ebiten.NewImage(400, 300))
+
+	// Without a heart to spare the cat stays sad
+	if player.Interact() || cat.IsHappy() {
+		t.Fatal("Expected the cat to stay sad when the player can't help")
+	}
+
+	spare = true
+	sadFrame := cat.animations[CatSad].GetCurrentFrame()
+	if !player.Interact() || !cat.IsHappy() || helped != 1 {
+		t.Fatalf("Expected the cat to cheer up once, happy=%v helped=%d", cat.IsHappy(), helped)
+	}
+	if frame := cat.animations[cat.Mood].GetCurrentFrame(); frame == sadFrame {
+		t.Error("Expected a happy cat to use its own animation")
+	}
+	if cat.CanInteract(player) || player.Interact() || helped != 1 {
+		t.Errorf("Expected nothing more to do for a happy cat, helped %d times", helped)
+	}
+
+	world.Update(1.0 / 60.0)
+	entities := world.Entities(nil)
+	if _, ok := entities[len(entities)-1].(*BurstEffect); !ok {
+		t.Errorf("Expected a burst when the cat cheers up, got %T", entities[len(entities)-1])
+	}
+}
+
+func TestCat_InteractPicksNearest(t *testing.T) {
+	world := NewWorld(nil)
+	player := NewPlayer(100, 100, CreateTestSpriteSheet())
+	near := NewCat("near", 140, 110)
+	far := NewCat("far", 40, 110)
+	world.Spawn(player)
+	world.Spawn(far)
+	world.Spawn(near)
+	world.Update(0)
+
+	player.SetPosition(100, 100)
+	near.SetPosition(140, 110)
+	far.SetPosition(62, 110)
+	if !player.Interact() || !near.IsHappy() || far.IsHappy() {
+		t.Errorf("Expected only the nearest cat to be helped, near=%v far=%v", near.Mood, far.Mood)
+	}
+}
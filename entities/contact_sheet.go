@@ -0,0 +1,154 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Contact sheet layout constants
+const (
+	contactSheetScale   = 2  // Frames are drawn at 2x so pixel art is readable
+	contactSheetPadding = 6  // Space around each cell
+	contactSheetLineH   = 13 // Line height of basicfont.Face7x13
+	contactSheetLines   = 2  // Label lines per cell (frame number, animation names)
+	contactSheetHeaderH = 3 * contactSheetLineH
+)
+
+var (
+	contactSheetBackground = color.RGBA{30, 30, 40, 255}
+	contactSheetCellColour = color.RGBA{55, 55, 70, 255}
+	contactSheetTextColour = color.RGBA{230, 230, 230, 255}
+	contactSheetUnused     = color.RGBA{90, 90, 90, 255}
+	contactSheetIssue      = color.RGBA{220, 20, 60, 255}
+
+	// Border colours per animation, matching the test sprite sheet where possible
+	contactSheetColours = map[AnimationState]color.RGBA{
+		AnimationIdle:      {100, 149, 237, 255},
+		AnimationWalk:      {72, 209, 204, 255},
+		AnimationJump:      {255, 215, 0, 255},
+		AnimationFall:      {255, 140, 0, 255},
+		AnimationClimb:     {144, 238, 144, 255},
+		AnimationDamage:    {255, 105, 180, 255},
+		AnimationJumpStart: {255, 215, 0, 255},
+		AnimationLand:      {255, 215, 0, 255},
+	}
+)
+
+// RenderContactSheet draws every frame of a sheet in a labelled grid, with each frame's
+// number and the animations that use it. Frames with validation issues get a red border.
+func RenderContactSheet(img image.Image, layout SpriteSheetLayout, title string) *image.RGBA {
+	face := basicfont.Face7x13
+	charWidth := face.Advance
+
+	// Issues keyed by frame so cells can be highlighted
+	problemFrames := make(map[int]bool)
+	validationErr := ValidateSpriteSheet(img, layout)
+	var sheetErr *SpriteSheetError
+	if errors.As(validationErr, &sheetErr) {
+		for _, issue := range sheetErr.Issues {
+			if issue.Frame >= 0 {
+				problemFrames[issue.Frame] = true
+			}
+		}
+	}
+
+	columns, rows := max(layout.Columns, 1), max(layout.Rows, 1)
+	frameW := max(layout.FrameWidth, 1) * contactSheetScale
+	frameH := max(layout.FrameHeight, 1) * contactSheetScale
+	cellW := max(frameW, 12*charWidth) + 2*contactSheetPadding
+	cellH := frameH + contactSheetLines*contactSheetLineH + 2*contactSheetPadding
+
+	sheet := image.NewRGBA(image.Rect(0, 0, columns*cellW, contactSheetHeaderH+rows*cellH))
+	draw.Draw(sheet, sheet.Bounds(), image.NewUniform(contactSheetBackground), image.Point{}, draw.Src)
+
+	// Header: title, size and validation summary
+	summary := "OK"
+	if sheetErr != nil {
+		summary = fmt.Sprintf("%d issue(s)", len(sheetErr.Issues))
+	}
+	bounds := img.Bounds()
+	drawLabel(sheet, face, title, 4, contactSheetLineH, contactSheetTextColour)
+	drawLabel(sheet, face, fmt.Sprintf("%dx%d, %dx%d frames: %s", bounds.Dx(), bounds.Dy(), layout.FrameWidth, layout.FrameHeight, summary), 4, 2*contactSheetLineH, contactSheetTextColour)
+
+	for frame := 0; frame < columns*rows; frame++ {
+		cellX := (frame % columns) * cellW
+		cellY := contactSheetHeaderH + (frame/columns)*cellH
+		cell := image.Rect(cellX+2, cellY+2, cellX+cellW-2, cellY+cellH-2)
+
+		users := layout.AnimationsForFrame(frame)
+		border := contactSheetUnused
+		if len(users) > 0 {
+			border = contactSheetColours[users[0]]
+		}
+		if problemFrames[frame] {
+			border = contactSheetIssue
+		}
+		draw.Draw(sheet, cell, image.NewUniform(border), image.Point{}, draw.Src)
+		draw.Draw(sheet, cell.Inset(2), image.NewUniform(contactSheetCellColour), image.Point{}, draw.Src)
+
+		// Frame pixels, scaled up with nearest neighbour sampling
+		src := layout.FrameRect(frame).Add(bounds.Min)
+		dstX := cellX + contactSheetPadding
+		dstY := cellY + contactSheetPadding
+		for y := 0; y < frameH; y++ {
+			for x := 0; x < frameW; x++ {
+				p := image.Pt(src.Min.X+x/contactSheetScale, src.Min.Y+y/contactSheetScale)
+				if !p.In(bounds) {
+					continue
+				}
+				sheet.Set(dstX+x, dstY+y, blendOver(sheet.RGBAAt(dstX+x, dstY+y), img.At(p.X, p.Y)))
+			}
+		}
+
+		// Labels: frame number, then the animations using it
+		labelY := dstY + frameH + contactSheetLineH - 2
+		drawLabel(sheet, face, fmt.Sprintf("#%d", frame), dstX, labelY, contactSheetTextColour)
+
+		names := make([]string, len(users))
+		for i, state := range users {
+			names[i] = state.String()
+		}
+		label := strings.Join(names, ",")
+		if label == "" {
+			label = "unused"
+		}
+		maxChars := (cellW - 2*contactSheetPadding) / charWidth
+		if len(label) > maxChars {
+			label = label[:maxChars-1] + "~"
+		}
+		drawLabel(sheet, face, label, dstX, labelY+contactSheetLineH, border)
+	}
+
+	return sheet
+}
+
+// drawLabel writes a line of text with its baseline at (x, y)
+func drawLabel(dst draw.Image, face font.Face, text string, x, y int, c color.Color) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// blendOver composites a source colour over an opaque destination pixel
+func blendOver(dst color.RGBA, src color.Color) color.RGBA {
+	r, g, b, a := src.RGBA()
+	inv := 0xffff - a
+	return color.RGBA{
+		R: uint8((r + uint32(dst.R)*0x101*inv/0xffff) >> 8),
+		G: uint8((g + uint32(dst.G)*0x101*inv/0xffff) >> 8),
+		B: uint8((b + uint32(dst.B)*0x101*inv/0xffff) >> 8),
+		A: 255,
+	}
+}
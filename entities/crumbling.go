@@ -0,0 +1,210 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// Tags of crumbling blocks
+const (
+	TagCrumbling = "crumbling"
+)
+
+// Crumbling block tuning
+const (
+	CrumbleStandTolerance = 2.0 // How far the player's feet may be from a block's top and still stand on it
+
+	crumbleShakeDistance = 2.0  // Furthest a shaking block is drawn from its place (px)
+	crumbleShakeRate     = 18.0 // Shakes per second
+)
+
+// CrumbleState is what a crumbling block is doing
+type CrumbleState int
+
+const (
+	CrumbleIntact    CrumbleState = iota // Solid, waiting for the player to stand on it
+	CrumbleShaking                       // Stood on, and collapsing once its delay is up
+	CrumbleCollapsed                     // Gone, and returning once its respawn delay is up
+)
+
+// String returns a readable name for the state
+func (s CrumbleState) String() string {
+	switch s {
+	case CrumbleIntact:
+		return "Intact"
+	case CrumbleShaking:
+		return "Shaking"
+	case CrumbleCollapsed:
+		return "Collapsed"
+	default:
+		return "Unknown"
+	}
+}
+
+// crumbleCrackColour darkens the cracks drawn across a crumbling block
+var crumbleCrackColour = color.RGBA{0, 0, 0, 90}
+
+// CrumblingBlock times and draws a crumbling tile. Once the player stands on it, it shakes
+// for Delay seconds and collapses, whether or not they stay. RespawnDelay seconds later it
+// returns, as soon as the player isn't in the way.
+//
+// The block only decides when: OnCollapse and OnRestore change the level's tiles.
+type CrumblingBlock struct {
+	// Where the block's tile is
+	collision.Rect
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	Delay        float64       // Seconds the block shakes before collapsing
+	RespawnDelay float64       // Seconds the block stays collapsed (0 never returns)
+	Colour       color.RGBA    // Placeholder colour when there's no sprite
+	Sprite       *ebiten.Image // Image of the tile, drawn instead of the colour
+
+	OnCollapse func(block *CrumblingBlock) // Called as the block collapses, to remove its tile
+	OnRestore  func(block *CrumblingBlock) // Called as the block returns, to put its tile back
+
+	state CrumbleState
+	timer float64 // Seconds in the current state
+}
+
+// NewCrumblingBlock creates an intact crumbling block covering a tile
+func NewCrumblingBlock(x, y, width, height, delay, respawnDelay float64, colour color.RGBA) *CrumblingBlock {
+	return &CrumblingBlock{
+		Rect:         collision.Rect{X: x, Y: y, Width: width, Height: height},
+		EntityBase:   NewEntityBase(TagCrumbling),
+		Delay:        delay,
+		RespawnDelay: respawnDelay,
+		Colour:       colour,
+	}
+}
+
+// GetState returns what the block is doing
+func (b *CrumblingBlock) GetState() CrumbleState {
+	return b.state
+}
+
+// IsStoodOn returns whether the player is standing on the block: on the ground, with
+// their feet within CrumbleStandTolerance of its top and overlapping it horizontally
+func (b *CrumblingBlock) IsStoodOn(player *Player) bool {
+	return isStandingOn(player, b.Rect)
+}
+
+// isStandingOn returns whether the player is standing on top of an area: on the ground,
+// with their feet within CrumbleStandTolerance of its top and overlapping it horizontally
+func isStandingOn(player *Player, area collision.Rect) bool {
+	if !player.OnGround || player.VelocityY < 0 {
+		return false
+	}
+	x, y, width, height := player.GetBounds()
+	return math.Abs(y+height-area.Y) <= CrumbleStandTolerance && x < area.X+area.Width && x+width > area.X
+}
+
+// Update starts the block shaking when the player stands on it, collapses it once it has
+// shaken for Delay and restores it once it has been gone for RespawnDelay
+func (b *CrumblingBlock) Update(deltaTime float64) {
+	player := b.findPlayer()
+
+	switch b.state {
+	case CrumbleIntact:
+		if player != nil && !player.IsDead && b.IsStoodOn(player) {
+			b.setState(CrumbleShaking)
+		}
+	case CrumbleShaking:
+		b.timer += deltaTime
+		if b.timer >= b.Delay {
+			b.collapse(player)
+		}
+	case CrumbleCollapsed:
+		if b.RespawnDelay <= 0 {
+			return
+		}
+		b.timer += deltaTime
+
+		// Never return around the player, who would be stuck inside
+		if b.timer >= b.RespawnDelay && (player == nil || !b.Overlaps(collision.Rect{X: player.X, Y: player.Y, Width: player.Width, Height: player.Height})) {
+			b.setState(CrumbleIntact)
+			if b.OnRestore != nil {
+				b.OnRestore(b)
+			}
+		}
+	}
+}
+
+// collapse removes the block in a puff of dust. A player standing still on it would be
+// kept up by their ground hysteresis, so they are told the ground has gone.
+func (b *CrumblingBlock) collapse(player *Player) {
+	if player != nil && b.IsStoodOn(player) {
+		player.OnGround = false
+	}
+	b.setState(CrumbleCollapsed)
+	b.World().Spawn(NewBurstEffect(b.X+b.Width/2, b.Y+b.Height/2, b.Colour))
+	if b.OnCollapse != nil {
+		b.OnCollapse(b)
+	}
+}
+
+// setState changes the block's state and restarts its timer
+func (b *CrumblingBlock) setState(state CrumbleState) {
+	b.state = state
+	b.timer = 0
+}
+
+// GetBounds returns the tile the block covers
+func (b *CrumblingBlock) GetBounds() (float64, float64, float64, float64) {
+	return b.X, b.Y, b.Width, b.Height
+}
+
+// ShakeOffset returns how far the block is drawn from its place, shaking harder as it
+// nears collapse
+func (b *CrumblingBlock) ShakeOffset() float64 {
+	if b.state != CrumbleShaking {
+		return 0
+	}
+	strength := 1.0
+	if b.Delay > 0 {
+		strength = 0.5 + 0.5*min(b.timer/b.Delay, 1)
+	}
+	return math.Round(math.Sin(b.timer*crumbleShakeRate*2*math.Pi) * crumbleShakeDistance * strength)
+}
+
+// Draw renders the block with its cracks, shaking while it collapses. Collapsed blocks
+// draw nothing.
+func (b *CrumblingBlock) Draw(screen *ebiten.Image) {
+	if b.state == CrumbleCollapsed {
+		return
+	}
+	x, y := math.Round(b.X)+b.ShakeOffset(), math.Round(b.Y)
+
+	if b.Sprite != nil {
+		op := &ebiten.DrawImageOptions{}
+		bounds := b.Sprite.Bounds()
+		op.GeoM.Scale(b.Width/float64(bounds.Dx()), b.Height/float64(bounds.Dy()))
+		op.GeoM.Translate(x, y)
+		screen.DrawImage(b.Sprite, op)
+		return
+	}
+
+	fill := &ebiten.DrawImageOptions{}
+	fill.GeoM.Scale(b.Width, b.Height)
+	fill.GeoM.Translate(x, y)
+	fill.ColorScale.ScaleWithColor(b.Colour)
+	screen.DrawImage(whitePixel(), fill)
+
+	// A zigzag crack warns that the block won't hold
+	for _, crack := range [...]struct{ x, y, width, height float64 }{
+		{0.25, 0.15, 0, 0.4},
+		{0.25, 0.55, 0.35, 0},
+		{0.6, 0.55, 0, 0.3},
+	} {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(math.Max(crack.width*b.Width, 2), math.Max(crack.height*b.Height, 2))
+		op.GeoM.Translate(x+crack.x*b.Width, y+crack.y*b.Height)
+		op.ColorScale.ScaleWithColor(crumbleCrackColour)
+		screen.DrawImage(whitePixel(), op)
+	}
+}
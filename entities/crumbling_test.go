@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newCrumblingWorld creates a removable tile world with a crumbling block over the tile.
+// Collapsing removes the tile and restoring puts it back.
+func newCrumblingWorld(delay, respawnDelay float64) (*World, *Player, *CrumblingBlock, *int) {
+	world, player, removeTile, restoreTile := newRemovableTileWorld()
+
+	collapses := 0
+	block := NewCrumblingBlock(100, 300, 32, 32, delay, respawnDelay, color.RGBA{190, 150, 100, 255})
+	block.OnCollapse = func(block *CrumblingBlock) {
+		collapses++
+		removeTile()
+	}
+	block.OnRestore = func(block *CrumblingBlock) {
+		restoreTile()
+	}
+	world.Spawn(block)
+	return world, player, block, &collapses
+}
+
+func TestCrumblingBlock_IsStoodOn(t *testing.T) {
+	block := NewCrumblingBlock(100, 300, 32, 32, 1, 1, color.RGBA{})
+
+	tests := []struct {
+		name      string
+		x, y      float64
+		onGround  bool
+		velocityY float64
+		want      bool
+	}{
+		{"standing on top", 100, 268, true, 0, true},
+		{"on its edge", 80, 268, true, 0, true},
+		{"feet slightly sunk", 100, 269.5, true, 0, true},
+		{"beside it", 132, 268, true, 0, false},
+		{"jumping off", 100, 268, true, -200, false},
+		{"in the air above", 100, 268, false, 0, false},
+		{"standing higher up", 100, 250, true, 0, false},
+		{"underneath", 100, 332, true, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := NewPlayer(tt.x, tt.y, CreateTestSpriteSheet())
+			player.OnGround = tt.onGround
+			player.VelocityY = tt.velocityY
+			if got := block.IsStoodOn(player); got != tt.want {
+				t.Errorf("Expected stood on=%v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCrumblingBlock_CollapsesAndReturns(t *testing.T) {
+	world, player, block, collapses := newCrumblingWorld(0.5, 2)
+
+	// Walking past on the floor leaves it alone
+	for i := 0; i < 60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if block.GetState() != CrumbleIntact {
+		t.Fatalf("Expected the block untouched, got %v", block.GetState())
+	}
+
+	// Standing on it starts it shaking, and it collapses after its delay even once the
+	// player has stepped off
+	player.SetPosition(100, 268)
+	world.Update(1.0 / 60.0)
+	if block.GetState() != CrumbleShaking {
+		t.Fatalf("Expected standing on the block to shake it, got %v", block.GetState())
+	}
+	player.SetPosition(300, 368)
+	frames := 0
+	for block.GetState() == CrumbleShaking && frames < 120 {
+		world.Update(1.0 / 60.0)
+		frames++
+	}
+	if block.GetState() != CrumbleCollapsed || *collapses != 1 {
+		t.Fatalf("Expected the block to collapse once, got %v after %d collapses", block.GetState(), *collapses)
+	}
+	if shaken := float64(frames) / 60; math.Abs(shaken-block.Delay) > 1.5/60 {
+		t.Errorf("Expected to shake for %vs, shook for %vs", block.Delay, shaken)
+	}
+	if len(world.WithTag(TagCrumbling, nil)) != 1 || world.Len() != 3 {
+		t.Errorf("Expected the block to stay in the world with a puff of dust, got %d entities", world.Len())
+	}
+
+	// It doesn't come back around the player, but does once they are out of the way
+	player.SetPosition(110, 310)
+	for i := 0; i < 3*60; i++ {
+		world.Update(1.0 / 60.0)
+		player.SetPosition(110, 310)
+	}
+	if block.GetState() != CrumbleCollapsed {
+		t.Fatalf("Expected the block to wait for the player to move, got %v", block.GetState())
+	}
+	player.SetPosition(300, 368)
+	world.Update(1.0 / 60.0)
+	if block.GetState() != CrumbleIntact {
+		t.Errorf("Expected the block back once the player moved, got %v", block.GetState())
+	}
+
+	block.Draw(ebiten.NewImage(400, 400))
+}
+
+func TestCrumblingBlock_DropsThePlayer(t *testing.T) {
+	world, player, block, _ := newCrumblingWorld(0.25, 0)
+	player.SetPosition(100, 268)
+
+	for i := 0; i < 3*60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if !player.OnGround || player.Y != 368 {
+		t.Errorf("Expected the player to fall through to the floor, got Y=%v on ground=%v", player.Y, player.OnGround)
+	}
+
+	// Without a respawn delay it never returns
+	for i := 0; i < 10*60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if block.GetState() != CrumbleCollapsed {
+		t.Errorf("Expected the block to stay gone, got %v", block.GetState())
+	}
+	block.Draw(ebiten.NewImage(400, 400))
+}
+
+func TestCrumblingBlock_ShakeOffset(t *testing.T) {
+	block := NewCrumblingBlock(0, 0, 32, 32, 1, 1, color.RGBA{})
+	if block.ShakeOffset() != 0 {
+		t.Error("Expected an intact block not to shake")
+	}
+	block.setState(CrumbleShaking)
+	largest := 0.0
+	for range 60 {
+		block.timer += 1.0 / 60.0
+		largest = math.Max(largest, math.Abs(block.ShakeOffset()))
+	}
+	if largest == 0 || largest > crumbleShakeDistance {
+		t.Errorf("Expected shaking up to %vpx, got %v", crumbleShakeDistance, largest)
+	}
+}
@@ -0,0 +1,220 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// Tags of hazards
+const (
+	TagHazard = "hazard"
+	TagDebris = "debris"
+)
+
+// Falling debris size, trigger and fall defaults
+const (
+	DebrisSize = 16 // Width and height of debris placed as a point
+
+	DefaultDebrisTriggerRange = 192.0 // Furthest below the debris the player sets it off (px)
+	DefaultDebrisWarnTime     = 0.4   // Seconds the debris shakes before it falls
+	DefaultDebrisRestTime     = 2.0   // Seconds landed debris lies before crumbling away
+	DebrisGravity             = 900.0 // Downward acceleration while falling (px/s²)
+
+	DebrisKnockbackSpeed = 120.0 // Horizontal speed the player is thrown away at (px/s)
+	DebrisKnockbackLift  = 100.0 // Upward speed the player is thrown at (px/s)
+
+	debrisShakeDistance = 1.0  // Furthest warning debris is drawn from its place (px)
+	debrisFallLimit     = 10.0 // Seconds debris falls without landing before it is dropped
+)
+
+// DebrisState is what a piece of debris is doing
+type DebrisState int
+
+const (
+	DebrisHanging DebrisState = iota // Waiting for the player to pass beneath
+	DebrisShaking                    // Set off, about to fall
+	DebrisFalling                    // Falling, and harmful to touch
+	DebrisLanded                     // Lying where it landed, harmless
+)
+
+// String returns a readable name for the state
+func (s DebrisState) String() string {
+	switch s {
+	case DebrisHanging:
+		return "Hanging"
+	case DebrisShaking:
+		return "Shaking"
+	case DebrisFalling:
+		return "Falling"
+	case DebrisLanded:
+		return "Landed"
+	default:
+		return "Unknown"
+	}
+}
+
+// Debris colours
+var (
+	debrisColour     = color.RGBA{120, 105, 90, 255}
+	debrisEdgeColour = color.RGBA{70, 60, 50, 255}
+)
+
+// Debris is a loose rock that hangs until the player passes beneath it within TriggerRange,
+// shakes for WarnTime and falls. Falling debris lands on tiles like any body and damages
+// and knocks back the player if it touches them on the way down. Landed debris is harmless
+// and crumbles away after RestTime.
+type Debris struct {
+	// Position, size and velocity; debris only falls once it is set off
+	Body
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	TriggerRange float64 // Furthest below the debris the player sets it off (px)
+	WarnTime     float64 // Seconds the debris shakes before it falls
+	RestTime     float64 // Seconds landed debris lies before crumbling away (0 stays)
+
+	state          DebrisState
+	timer          float64 // Seconds in the current state
+	level          collision.Checker
+	sight          collision.SightChecker
+	touchingPlayer *Player // The player, while overlapping the debris
+}
+
+// NewDebris creates hanging debris with its top-left corner at (x, y)
+func NewDebris(x, y, width, height float64) *Debris {
+	return &Debris{
+		Body:         NewBody(x, y, width, height, DebrisGravity),
+		EntityBase:   NewEntityBase(TagHazard, TagDebris),
+		TriggerRange: DefaultDebrisTriggerRange,
+		WarnTime:     DefaultDebrisWarnTime,
+		RestTime:     DefaultDebrisRestTime,
+	}
+}
+
+// SetLevel sets what the debris lands on. Levels that implement collision.SightChecker
+// also stop the player being seen through walls and floors, so debris isn't set off from
+// another storey.
+func (d *Debris) SetLevel(level collision.Checker) {
+	d.level = level
+	d.sight, _ = level.(collision.SightChecker)
+}
+
+// GetState returns what the debris is doing
+func (d *Debris) GetState() DebrisState {
+	return d.state
+}
+
+// IsBeneath returns whether the player is under the debris: overlapping it horizontally,
+// below it within TriggerRange and with nothing in the level between them
+func (d *Debris) IsBeneath(player *Player) bool {
+	x, y, width, height := player.GetBounds()
+	bottom := d.Y + d.Height
+	if x >= d.X+d.Width || x+width <= d.X || y < bottom || y-bottom > d.TriggerRange {
+		return false
+	}
+	if d.sight == nil {
+		return true
+	}
+	return d.sight.LineOfSight(d.X+d.Width/2, bottom, x+width/2, y+height/2)
+}
+
+// Update sets the debris off when the player passes beneath, then lets it shake, fall,
+// land and crumble away
+func (d *Debris) Update(deltaTime float64) {
+	d.timer += deltaTime
+
+	switch d.state {
+	case DebrisHanging:
+		if player := d.findPlayer(); player != nil && !player.IsDead && d.IsBeneath(player) {
+			d.setState(DebrisShaking)
+		}
+	case DebrisShaking:
+		if d.timer >= d.WarnTime {
+			d.setState(DebrisFalling)
+		}
+	case DebrisFalling:
+		result := d.Body.Update(d.level, deltaTime)
+		if result.Landed() || d.OnGround {
+			d.VelocityX, d.VelocityY = 0, 0
+			d.setState(DebrisLanded)
+			return
+		}
+		if d.timer >= debrisFallLimit {
+			d.World().Despawn(d)
+			return
+		}
+
+		// The player may still be touching the debris when their damage immunity wears off
+		if d.touchingPlayer != nil {
+			d.hit(d.touchingPlayer)
+		}
+	case DebrisLanded:
+		if d.RestTime > 0 && d.timer >= d.RestTime {
+			world := d.World()
+			world.Despawn(d)
+			world.Spawn(NewBurstEffect(d.X+d.Width/2, d.Y+d.Height/2, debrisColour))
+		}
+	}
+}
+
+// setState changes the debris' state and restarts its timer
+func (d *Debris) setState(state DebrisState) {
+	d.state = state
+	d.timer = 0
+}
+
+// OnContactEnter damages the player when falling debris touches them
+func (d *Debris) OnContactEnter(other Entity) {
+	if player, ok := other.(*Player); ok {
+		d.touchingPlayer = player
+		d.hit(player)
+	}
+}
+
+// OnContactExit stops tracking the player once they are no longer touching
+func (d *Debris) OnContactExit(other Entity) {
+	if other == d.touchingPlayer {
+		d.touchingPlayer = nil
+	}
+}
+
+// hit damages the player if the debris is falling and they aren't still recovering from
+// the last hit, and knocks them out from under it
+func (d *Debris) hit(player *Player) {
+	if d.state != DebrisFalling || player.IsDamaged {
+		return
+	}
+	player.TakeDamage()
+
+	px, _, pw, _ := player.GetBounds()
+	direction := 1.0
+	if px+pw/2 < d.X+d.Width/2 {
+		direction = -1
+	}
+	player.Knockback(direction*DebrisKnockbackSpeed, -DebrisKnockbackLift)
+}
+
+// Draw renders the debris as a rock, shaking while it warns the player
+func (d *Debris) Draw(screen *ebiten.Image) {
+	x, y := math.Round(d.X), math.Round(d.Y)
+	if d.state == DebrisShaking {
+		x += math.Round(math.Sin(d.timer*20*2*math.Pi) * debrisShakeDistance)
+	}
+
+	edge := &ebiten.DrawImageOptions{}
+	edge.GeoM.Scale(d.Width, d.Height)
+	edge.GeoM.Translate(x, y)
+	edge.ColorScale.ScaleWithColor(debrisEdgeColour)
+	screen.DrawImage(whitePixel(), edge)
+
+	fill := &ebiten.DrawImageOptions{}
+	fill.GeoM.Scale(math.Max(d.Width-4, 0), math.Max(d.Height-4, 0))
+	fill.GeoM.Translate(x+2, y+2)
+	fill.ColorScale.ScaleWithColor(debrisColour)
+	screen.DrawImage(whitePixel(), fill)
+}
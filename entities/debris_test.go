@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+func TestDebris_IsBeneath(t *testing.T) {
+	tests := []struct {
+		name   string
+		x, y   float64
+		hidden bool
+		want   bool
+	}{
+		{"directly below", 100, 300, false, true},
+		{"just under an edge", 85, 300, false, true},
+		{"right beneath it", 100, 116, false, true},
+		{"beside the fall", 116, 300, false, false},
+		{"above it", 100, 60, false, false},
+		{"beyond its range", 100, 116 + DefaultDebrisTriggerRange + 1, false, false},
+		{"out of sight", 100, 300, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debris := NewDebris(100, 100, DebrisSize, DebrisSize)
+			debris.SetLevel(&sightChecker{floorChecker: floorChecker{floorY: 600, wallX: math.Inf(1)}, screenRaised: tt.hidden})
+			player := NewPlayer(tt.x, tt.y, CreateTestSpriteSheet())
+			if got := debris.IsBeneath(player); got != tt.want {
+				t.Errorf("Expected beneath=%v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDebris_FallsAndLands(t *testing.T) {
+	level := &floorChecker{floorY: 400, wallX: math.Inf(1)}
+	world := NewWorld(collision.NewSpatialHash(32))
+	player := NewPlayer(300, 368, CreateTestSpriteSheet())
+	player.SetLevel(level)
+	player.OnGround = true
+	debris := NewDebris(108, 200, DebrisSize, DebrisSize)
+	debris.SetLevel(level)
+	world.Spawn(player)
+	world.Spawn(debris)
+
+	for i := 0; i < 60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if debris.GetState() != DebrisHanging {
+		t.Fatalf("Expected the debris to hang while the player is away, got %v", debris.GetState())
+	}
+
+	// Passing beneath sets it off: it shakes in place, then falls onto the player
+	player.SetPosition(96, 368)
+	world.Update(1.0 / 60.0)
+	if debris.GetState() != DebrisShaking {
+		t.Fatalf("Expected the debris to shake once the player is beneath, got %v", debris.GetState())
+	}
+	for i := 0; i < int(debris.WarnTime*60)-1; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if debris.GetState() != DebrisShaking || debris.Y != 200 {
+		t.Fatalf("Expected the debris to warn in place for %vs, got %v at Y=%v", debris.WarnTime, debris.GetState(), debris.Y)
+	}
+	debris.Draw(ebiten.NewImage(400, 400))
+
+	for i := 0; i < 2*60 && !player.IsDamaged; i++ {
+		player.SetPosition(96, 368)
+		world.Update(1.0 / 60.0)
+	}
+	if !player.IsDamaged || debris.GetState() != DebrisFalling {
+		t.Fatalf("Expected the falling debris to hit the player, damaged=%v state=%v", player.IsDamaged, debris.GetState())
+	}
+	if player.VelocityX >= 0 || player.VelocityY >= 0 {
+		t.Errorf("Expected the player knocked up and out to the left, got velocity (%v, %v)", player.VelocityX, player.VelocityY)
+	}
+
+	// It lands on the floor, lies there harmlessly, then crumbles away
+	for i := 0; i < 60 && debris.GetState() == DebrisFalling; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if debris.GetState() != DebrisLanded || debris.Y != 400-DebrisSize {
+		t.Fatalf("Expected the debris to land on the floor at Y=%v, got %v at Y=%v", 400-DebrisSize, debris.GetState(), debris.Y)
+	}
+	for i := 0; i < int(debris.RestTime*60)+2 && debris.World() != nil; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if len(world.WithTag(TagDebris, nil)) != 0 {
+		t.Error("Expected landed debris to crumble away after its rest time")
+	}
+}
+
+func TestDebris_OnlyFallingDebrisHurts(t *testing.T) {
+	for _, state := range []DebrisState{DebrisHanging, DebrisShaking, DebrisLanded} {
+		t.Run(state.String(), func(t *testing.T) {
+			world := NewWorld(collision.NewSpatialHash(32))
+			player := NewPlayer(100, 368, CreateTestSpriteSheet())
+			player.SetLevel(&floorChecker{floorY: 400, wallX: math.Inf(1)})
+			player.OnGround = true
+			debris := NewDebris(108, 384, DebrisSize, DebrisSize)
+			debris.TriggerRange = 0
+			debris.WarnTime = math.Inf(1)
+			debris.state = state
+			world.Spawn(player)
+			world.Spawn(debris)
+
+			world.Update(1.0 / 60.0)
+			if player.IsDamaged {
+				t.Errorf("Expected %v debris to be harmless", state)
+			}
+		})
+	}
+}
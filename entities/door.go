@@ -0,0 +1,166 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// Tags of doors
+const (
+	TagDoor = "door"
+)
+
+// Energy barrier knockback and shimmer
+const (
+	BarrierKnockbackSpeed = 150.0 // Speed the player is thrown away from a barrier at (px/s)
+	BarrierKnockbackLift  = 100.0 // Upward speed the player is thrown at (px/s)
+
+	barrierTouchDistance = 1.0  // How close the player must be to a barrier to touch it (px)
+	barrierShimmerRate   = 1.5  // Bands of light passing over a barrier per second
+	barrierShimmerAlpha  = 0.35 // Brightness of the bands
+	barrierShimmerBand   = 6.0  // Height of each band (px)
+)
+
+// Door opens and closes a group of tiles on its inputs' signals: it opens while any input
+// is on, or while none is if inverted. A door that would close around the player waits
+// until they are out of the way. Energy barriers are doors that shimmer while they're up
+// and hurt the player who touches them.
+//
+// The door only decides when: OnOpen and OnClose change the level's tiles.
+type Door struct {
+	// Which tiles the door covers
+	collision.Rect
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	Inputs  []string   // Names of the signals the door reads
+	Invert  bool       // Opens while its inputs are off instead of on
+	Barrier bool       // Shimmers and hurts to touch while closed, like the energy field it is
+	Colour  color.RGBA // Colour of the sparks as the door opens
+
+	OnOpen  func(door *Door) // Called as the door opens, to remove its tiles
+	OnClose func(door *Door) // Called as the door closes, to put its tiles back
+
+	signals *Signals
+	open    bool
+	time    float64 // Seconds since the door was spawned, for the shimmer
+}
+
+// NewDoor creates a closed door that reads signals
+func NewDoor(signals *Signals, x, y, width, height float64, inputs ...string) *Door {
+	return &Door{
+		Rect:       collision.Rect{X: x, Y: y, Width: width, Height: height},
+		EntityBase: NewEntityBase(TagDoor),
+		Inputs:     inputs,
+		signals:    signals,
+	}
+}
+
+// IsOpen returns whether the door is open
+func (d *Door) IsOpen() bool {
+	return d.open
+}
+
+// IsPowered returns whether the door's inputs say it should be open
+func (d *Door) IsPowered() bool {
+	return d.signals.AnyOn(d.Inputs) != d.Invert
+}
+
+// Update opens or closes the door to match its inputs, and lets a closed barrier hurt the
+// player touching it
+func (d *Door) Update(deltaTime float64) {
+	d.time += deltaTime
+	player := d.findPlayer()
+	powered := d.IsPowered()
+	if powered == d.open {
+		if d.Barrier && !d.open && player != nil {
+			d.shock(player)
+		}
+		return
+	}
+
+	if powered {
+		d.openDoor(player)
+		return
+	}
+
+	// Never close around the player, who would be stuck inside
+	if player == nil || !d.Overlaps(playerRect(player)) {
+		d.open = false
+		if d.OnClose != nil {
+			d.OnClose(d)
+		}
+	}
+}
+
+// openDoor opens the door in a shower of sparks. A player standing still on a door that
+// opens beneath them, such as a trapdoor, is told the ground has gone.
+func (d *Door) openDoor(player *Player) {
+	if player != nil && isStandingOn(player, d.Rect) {
+		player.OnGround = false
+	}
+	d.open = true
+	d.World().Spawn(NewBurstEffect(d.X+d.Width/2, d.Y+d.Height/2, d.Colour))
+	if d.OnOpen != nil {
+		d.OnOpen(d)
+	}
+}
+
+// shock damages and throws back a player touching the barrier, unless they are still
+// recovering from the last hit. The player can't overlap the barrier's solid tiles, so
+// touching means being within barrierTouchDistance of it.
+func (d *Door) shock(player *Player) {
+	if player.IsDamaged || player.IsDead {
+		return
+	}
+	reach := collision.Rect{
+		X:      d.X - barrierTouchDistance,
+		Y:      d.Y - barrierTouchDistance,
+		Width:  d.Width + 2*barrierTouchDistance,
+		Height: d.Height + 2*barrierTouchDistance,
+	}
+	if !reach.Overlaps(playerRect(player)) {
+		return
+	}
+	player.TakeDamage()
+
+	px, _, pw, _ := player.GetBounds()
+	direction := 1.0
+	if px+pw/2 < d.X+d.Width/2 {
+		direction = -1
+	}
+	player.Knockback(direction*BarrierKnockbackSpeed, -BarrierKnockbackLift)
+}
+
+// GetBounds returns the tiles the door covers
+func (d *Door) GetBounds() (float64, float64, float64, float64) {
+	return d.X, d.Y, d.Width, d.Height
+}
+
+// Draw renders bands of light rising over a closed energy barrier. The door's tiles draw
+// the rest, and doors that aren't barriers draw nothing of their own.
+func (d *Door) Draw(screen *ebiten.Image) {
+	if !d.Barrier || d.open {
+		return
+	}
+	x, y := math.Round(d.X), math.Round(d.Y)
+	spacing := 3 * barrierShimmerBand
+	offset := math.Mod(d.time*barrierShimmerRate*spacing, spacing)
+	for top := d.Height - offset; top > -barrierShimmerBand; top -= spacing {
+		bandTop := math.Max(top, 0)
+		bandBottom := math.Min(top+barrierShimmerBand, d.Height)
+		if bandBottom <= bandTop {
+			continue
+		}
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(d.Width, bandBottom-bandTop)
+		op.GeoM.Translate(x, y+math.Round(bandTop))
+		op.ColorScale.ScaleAlpha(barrierShimmerAlpha)
+		screen.DrawImage(whitePixel(), op)
+	}
+}
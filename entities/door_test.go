@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newDoorWorld creates a removable tile world with a door over the tile, wired to the
+// "switch" signal. Opening the door removes the tile and closing it puts it back.
+func newDoorWorld() (*World, *Player, *Door, *Signals) {
+	world, player, removeTile, restoreTile := newRemovableTileWorld()
+
+	signals := NewSignals()
+	door := NewDoor(signals, 100, 300, 32, 32, "switch")
+	door.Colour = color.RGBA{80, 100, 130, 255}
+	door.OnOpen = func(door *Door) {
+		removeTile()
+	}
+	door.OnClose = func(door *Door) {
+		restoreTile()
+	}
+	world.Spawn(door)
+	return world, player, door, signals
+}
+
+func TestDoor_OpensAndClosesOnItsSignal(t *testing.T) {
+	world, player, door, signals := newDoorWorld()
+	opened, closed := 0, 0
+	onOpen, onClose := door.OnOpen, door.OnClose
+	door.OnOpen = func(door *Door) {
+		opened++
+		onOpen(door)
+	}
+	door.OnClose = func(door *Door) {
+		closed++
+		onClose(door)
+	}
+
+	world.Update(1.0 / 60.0)
+	if door.IsOpen() || opened != 0 {
+		t.Fatal("Expected the door closed without a signal")
+	}
+
+	signals.Set("switch", true)
+	world.Update(1.0 / 60.0)
+	if !door.IsOpen() || opened != 1 {
+		t.Fatalf("Expected the door to open once, open=%v opened=%d", door.IsOpen(), opened)
+	}
+
+	// It doesn't close around the player, but does once they are out of the way
+	signals.Set("switch", false)
+	for range 30 {
+		player.SetPosition(110, 300)
+		world.Update(1.0 / 60.0)
+	}
+	if !door.IsOpen() || closed != 0 {
+		t.Fatal("Expected the door to wait for the player to move")
+	}
+	player.SetPosition(300, 368)
+	world.Update(1.0 / 60.0)
+	if door.IsOpen() || closed != 1 {
+		t.Errorf("Expected the door to close once the player moved, open=%v closed=%d", door.IsOpen(), closed)
+	}
+}
+
+func TestDoor_Inverted(t *testing.T) {
+	world, _, door, signals := newDoorWorld()
+	door.Invert = true
+
+	world.Update(1.0 / 60.0)
+	if !door.IsOpen() {
+		t.Fatal("Expected an inverted door open without a signal")
+	}
+	signals.Set("switch", true)
+	world.Update(1.0 / 60.0)
+	if door.IsOpen() {
+		t.Error("Expected an inverted door to close on its signal")
+	}
+}
+
+func TestDoor_TrapdoorDropsThePlayer(t *testing.T) {
+	world, player, _, signals := newDoorWorld()
+	player.SetPosition(100, 268)
+	world.Update(1.0 / 60.0)
+	if !player.OnGround {
+		t.Fatal("Expected the player to stand on the closed door")
+	}
+
+	signals.Set("switch", true)
+	for range 60 {
+		world.Update(1.0 / 60.0)
+	}
+	if !player.OnGround || player.Y != 368 {
+		t.Errorf("Expected the player to fall through to the floor, got Y=%v on ground=%v", player.Y, player.OnGround)
+	}
+}
+
+func TestDoor_BarrierHurtsWhileUp(t *testing.T) {
+	world, player, door, signals := newDoorWorld()
+	door.Barrier = true
+	screen := ebiten.NewImage(200, 400)
+
+	// Touching the side of a barrier that's up hurts and throws the player back
+	player.SetPosition(door.X-player.Width, door.Y)
+	world.Update(1.0 / 60.0)
+	door.Draw(screen)
+	if !player.IsDamaged || player.VelocityX >= 0 {
+		t.Fatalf("Expected the barrier to hurt and throw back the player, damaged=%v velocity=%v", player.IsDamaged, player.VelocityX)
+	}
+
+	// Switched off, it is harmless
+	for i := 0; i < 5*60 && player.IsDamaged; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	signals.Set("switch", true)
+	world.Update(1.0 / 60.0)
+	player.SetPosition(door.X-player.Width, door.Y)
+	world.Update(1.0 / 60.0)
+	door.Draw(screen)
+	if !door.IsOpen() || player.IsDamaged {
+		t.Errorf("Expected the barrier switched off and harmless, open=%v damaged=%v", door.IsOpen(), player.IsDamaged)
+	}
+}
@@ -0,0 +1,342 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// Tags of enemies
+const (
+	TagEnemy = "enemy"
+	TagDrone = "drone"
+)
+
+// Drone size, movement and detection defaults
+const (
+	DroneWidth  = 24
+	DroneHeight = 16
+
+	DefaultDronePatrolSpeed  = 50.0  // Patrolling and returning speed (px/s)
+	DefaultDroneChaseSpeed   = 110.0 // Top speed while chasing (px/s)
+	DefaultDroneAcceleration = 300.0 // How quickly the drone changes velocity (px/s²)
+	DefaultDroneSightRange   = 160.0 // Furthest the drone can see the player, centre to centre (px)
+	DefaultDroneLoseTime     = 1.5   // Seconds out of sight before the drone gives up a chase
+
+	DroneKnockbackSpeed = 160.0 // Horizontal speed the player is thrown away at (px/s)
+	DroneKnockbackLift  = 140.0 // Upward speed the player is thrown at (px/s)
+
+	droneArriveDistance = 2.0 // How close the drone must get to a point to have reached it
+	droneArriveRate     = 4.0 // Speed per pixel of distance left, so the drone slows into points
+)
+
+// DroneState is what a drone is doing
+type DroneState int
+
+const (
+	DronePatrol DroneState = iota // Following its waypoints
+	DroneChase                    // Flying at the player it has seen
+	DroneReturn                   // Flying back to where it left its patrol
+)
+
+// String returns a readable name for the state
+func (s DroneState) String() string {
+	switch s {
+	case DronePatrol:
+		return "Patrol"
+	case DroneChase:
+		return "Chase"
+	case DroneReturn:
+		return "Return"
+	default:
+		return "Unknown"
+	}
+}
+
+// droneEyeColours light the drone's eye by state
+var droneEyeColours = [...]color.RGBA{
+	DronePatrol: {80, 230, 120, 255}, // Green
+	DroneChase:  {255, 50, 50, 255},  // Red
+	DroneReturn: {255, 200, 40, 255}, // Amber
+}
+
+// dronePattern is the placeholder drone sprite, one character per pixel: '#' is the hull,
+// '=' is the rotor, '-' is the rotor's other blade position. The eye is drawn separately.
+var dronePattern = [DroneHeight]string{
+	"..======........------..",
+	"...........##...........",
+	"...........##...........",
+	"......############......",
+	"....################....",
+	"...##################...",
+	"..####################..",
+	"..####################..",
+	"..####################..",
+	"..####################..",
+	"...##################...",
+	"....################....",
+	"......############......",
+	".......#..........#.....",
+	"......##..........##....",
+	"........................",
+}
+
+// droneSprites are the drone's two rotor frames, created the first time a drone is drawn
+var droneSprites [2]*ebiten.Image
+
+// Waypoint is a point on a drone's patrol path: where its top-left corner flies to
+type Waypoint struct {
+	X, Y float64
+}
+
+// Drone is a flying enemy. It patrols its waypoints in a loop until it sees the player,
+// chases them at up to ChaseSpeed, and flies back to its patrol once it has lost sight of
+// them for LoseTime. Touching the player damages and knocks them back. Drones ignore
+// gravity but are stopped by solid tiles.
+type Drone struct {
+	// Position, size and velocity; drones don't fall
+	Body
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	Waypoints    []Waypoint // Patrol path, visited in order and looped; empty to hover in place
+	PatrolSpeed  float64    // Speed while patrolling and returning (px/s)
+	ChaseSpeed   float64    // Top speed while chasing (px/s)
+	Acceleration float64    // How quickly velocity changes (px/s²)
+	SightRange   float64    // Furthest the player can be seen, centre to centre (px)
+	LoseTime     float64    // Seconds out of sight before a chase is given up
+	FacingRight  bool
+
+	state          DroneState
+	home           Waypoint // Where a drone without waypoints hovers
+	target         int      // Waypoint being flown to
+	returnX        float64  // Where the drone left its patrol to chase
+	returnY        float64
+	lostTimer      float64 // Seconds since the player was last seen during a chase
+	level          collision.Checker
+	sight          collision.SightChecker
+	touchingPlayer *Player // The player, while overlapping the drone
+	time           float64 // Seconds since the drone was created, for the rotor
+}
+
+// NewDrone creates a patrolling drone with its top-left corner at (x, y)
+func NewDrone(x, y float64, waypoints ...Waypoint) *Drone {
+	drone := &Drone{
+		Body:         NewBody(x, y, DroneWidth, DroneHeight, 0),
+		EntityBase:   NewEntityBase(TagEnemy, TagDrone),
+		Waypoints:    waypoints,
+		PatrolSpeed:  DefaultDronePatrolSpeed,
+		ChaseSpeed:   DefaultDroneChaseSpeed,
+		Acceleration: DefaultDroneAcceleration,
+		SightRange:   DefaultDroneSightRange,
+		LoseTime:     DefaultDroneLoseTime,
+		FacingRight:  true,
+	}
+	drone.home = Waypoint{X: x, Y: y}
+	drone.returnX, drone.returnY = x, y
+	return drone
+}
+
+// SetLevel sets what the drone collides with. Levels that implement
+// collision.SightChecker also block its view of the player; without one it sees through
+// everything in range.
+func (d *Drone) SetLevel(level collision.Checker) {
+	d.level = level
+	d.sight, _ = level.(collision.SightChecker)
+}
+
+// GetState returns what the drone is doing
+func (d *Drone) GetState() DroneState {
+	return d.state
+}
+
+// Target returns the waypoint the drone is patrolling towards, or where it hovers if it
+// has no waypoints
+func (d *Drone) Target() Waypoint {
+	if len(d.Waypoints) == 0 {
+		return d.home
+	}
+	return d.Waypoints[d.target]
+}
+
+// Update looks for the player, picks a destination for the drone's state and flies
+// towards it
+func (d *Drone) Update(deltaTime float64) {
+	d.time += deltaTime
+
+	player := d.findPlayer()
+	seen := player != nil && d.CanSee(player)
+
+	switch d.state {
+	case DronePatrol, DroneReturn:
+		if seen {
+			if d.state == DronePatrol {
+				d.returnX, d.returnY = d.X, d.Y
+			}
+			d.state = DroneChase
+			d.lostTimer = 0
+		}
+	case DroneChase:
+		if seen {
+			d.lostTimer = 0
+			break
+		}
+		d.lostTimer += deltaTime
+		if d.lostTimer >= d.LoseTime || player == nil {
+			d.state = DroneReturn
+		}
+	}
+
+	switch d.state {
+	case DronePatrol:
+		target := d.Target()
+		if d.flyTowards(target.X, target.Y, d.PatrolSpeed, deltaTime) && len(d.Waypoints) > 0 {
+			d.target = (d.target + 1) % len(d.Waypoints)
+		}
+	case DroneChase:
+		if player != nil {
+			px, py, pw, ph := player.GetBounds()
+			d.flyTowards(px+(pw-d.Width)/2, py+(ph-d.Height)/2, d.ChaseSpeed, deltaTime)
+		}
+	case DroneReturn:
+		if d.flyTowards(d.returnX, d.returnY, d.PatrolSpeed, deltaTime) {
+			d.state = DronePatrol
+		}
+	}
+
+	// The player may still be touching the drone when its damage immunity wears off
+	if d.touchingPlayer != nil {
+		d.hit(d.touchingPlayer)
+	}
+}
+
+// CanSee returns whether an entity is within the drone's sight range with nothing in the
+// level blocking the line between their centres
+func (d *Drone) CanSee(other Entity) bool {
+	if centreDistance(d, other) > d.SightRange {
+		return false
+	}
+	if d.sight == nil {
+		return true
+	}
+	ox, oy, ow, oh := other.GetBounds()
+	return d.sight.LineOfSight(d.X+d.Width/2, d.Y+d.Height/2, ox+ow/2, oy+oh/2)
+}
+
+// flyTowards steers the drone's top-left corner towards a point at up to maxSpeed,
+// slowing as it arrives, and moves it. It returns whether the drone has reached the point.
+func (d *Drone) flyTowards(x, y, maxSpeed, deltaTime float64) bool {
+	dx, dy := x-d.X, y-d.Y
+	distance := math.Hypot(dx, dy)
+	if distance <= droneArriveDistance {
+		d.VelocityX, d.VelocityY = 0, 0
+		return true
+	}
+
+	// Steer the velocity towards the desired one, changing by at most Acceleration
+	speed := math.Min(maxSpeed, distance*droneArriveRate)
+	steerX := dx/distance*speed - d.VelocityX
+	steerY := dy/distance*speed - d.VelocityY
+	if steer, limit := math.Hypot(steerX, steerY), d.Acceleration*deltaTime; steer > limit {
+		steerX, steerY = steerX/steer*limit, steerY/steer*limit
+	}
+	d.VelocityX += steerX
+	d.VelocityY += steerY
+
+	// Never faster than the top speed, however the drone was pushed
+	if current := math.Hypot(d.VelocityX, d.VelocityY); current > maxSpeed {
+		d.VelocityX, d.VelocityY = d.VelocityX/current*maxSpeed, d.VelocityY/current*maxSpeed
+	}
+	if d.VelocityX != 0 {
+		d.FacingRight = d.VelocityX > 0
+	}
+
+	d.Body.Update(d.level, deltaTime)
+	return false
+}
+
+// OnContactEnter damages the player when the drone touches them
+func (d *Drone) OnContactEnter(other Entity) {
+	if player, ok := other.(*Player); ok {
+		d.touchingPlayer = player
+		d.hit(player)
+	}
+}
+
+// OnContactExit stops tracking the player once they are no longer touching
+func (d *Drone) OnContactExit(other Entity) {
+	if other == d.touchingPlayer {
+		d.touchingPlayer = nil
+	}
+}
+
+// hit damages the player, unless they are still recovering from the last hit, and knocks
+// them away from the drone. The drone recoils the other way.
+func (d *Drone) hit(player *Player) {
+	if player.IsDamaged {
+		return
+	}
+	player.TakeDamage()
+
+	px, _, pw, _ := player.GetBounds()
+	direction := 1.0
+	if px+pw/2 < d.X+d.Width/2 {
+		direction = -1
+	}
+	player.Knockback(direction*DroneKnockbackSpeed, -DroneKnockbackLift)
+	d.VelocityX, d.VelocityY = -direction*d.PatrolSpeed, 0
+}
+
+// Draw renders the drone with its eye lit for its state
+func (d *Drone) Draw(screen *ebiten.Image) {
+	sprites := DroneSprites()
+	frame := sprites[int(d.time*20)%len(sprites)]
+
+	op := &ebiten.DrawImageOptions{}
+	if !d.FacingRight {
+		op.GeoM.Scale(-1, 1)
+		op.GeoM.Translate(d.Width, 0)
+	}
+	op.GeoM.Translate(math.Round(d.X), math.Round(d.Y))
+	screen.DrawImage(frame, op)
+
+	// The eye sits towards the front of the hull
+	eyeX := d.X + 14
+	if !d.FacingRight {
+		eyeX = d.X + d.Width - 14 - 4
+	}
+	eye := &ebiten.DrawImageOptions{}
+	eye.GeoM.Scale(4, 3)
+	eye.GeoM.Translate(math.Round(eyeX), math.Round(d.Y+6))
+	eye.ColorScale.ScaleWithColor(droneEyeColours[d.state])
+	screen.DrawImage(whitePixel(), eye)
+}
+
+// DroneSprites returns the drone's two rotor frames
+func DroneSprites() [2]*ebiten.Image {
+	if droneSprites[0] != nil {
+		return droneSprites
+	}
+
+	hull := color.RGBA{70, 75, 90, 255}
+	rotor := color.RGBA{200, 200, 210, 255}
+	for frame := range droneSprites {
+		sprite := ebiten.NewImage(DroneWidth, DroneHeight)
+		for y, row := range dronePattern {
+			for x, pixel := range row {
+				switch {
+				case pixel == '#':
+					sprite.Set(x, y, hull)
+				case pixel == '=' && frame == 0, pixel == '-' && frame == 1:
+					sprite.Set(x, y, rotor)
+				}
+			}
+		}
+		droneSprites[frame] = sprite
+	}
+	return droneSprites
+}
@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// sightChecker is a floor the player stands on, with a screen that blocks sight while it
+// is raised but never blocks movement
+type sightChecker struct {
+	floorChecker
+	screenRaised bool
+}
+
+func (s *sightChecker) LineOfSight(x0, y0, x1, y1 float64) bool {
+	return !s.screenRaised
+}
+
+func TestDrone_Patrol(t *testing.T) {
+	square := []Waypoint{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	drone := NewDrone(0, 0, square...)
+
+	var visited []int
+	for i := 0; i < 20*60; i++ {
+		target := drone.target
+		drone.Update(1.0 / 60.0)
+		if drone.target != target {
+			visited = append(visited, target)
+		}
+		if speed := math.Hypot(drone.VelocityX, drone.VelocityY); speed > drone.PatrolSpeed+1e-9 {
+			t.Fatalf("Expected patrolling at up to %v px/s, got %v", drone.PatrolSpeed, speed)
+		}
+		if drone.GetState() != DronePatrol {
+			t.Fatalf("Expected a drone with nobody around to keep patrolling, got %v", drone.GetState())
+		}
+	}
+
+	if len(visited) < 6 {
+		t.Fatalf("Expected the drone to get round its patrol, visited %v", visited)
+	}
+	for i, waypoint := range visited {
+		if waypoint != i%len(square) {
+			t.Fatalf("Expected waypoints visited in a loop, got %v", visited)
+		}
+	}
+}
+
+func TestDrone_Hover(t *testing.T) {
+	drone := NewDrone(50, 60)
+	drone.VelocityX = 40
+	for i := 0; i < 5*60; i++ {
+		drone.Update(1.0 / 60.0)
+	}
+	if math.Abs(drone.X-50) > droneArriveDistance || math.Abs(drone.Y-60) > droneArriveDistance {
+		t.Errorf("Expected a drone without waypoints to hover at its start, got (%v, %v)", drone.X, drone.Y)
+	}
+	if target := drone.Target(); target != (Waypoint{50, 60}) {
+		t.Errorf("Expected the hover point as the target, got %v", target)
+	}
+}
+
+func TestDrone_ChaseAndReturn(t *testing.T) {
+	level := &sightChecker{floorChecker: floorChecker{floorY: 300, wallX: math.Inf(1)}}
+	world := NewWorld(collision.NewSpatialHash(32))
+	player := NewPlayer(600, 268, CreateTestSpriteSheet())
+	player.SetLevel(level)
+	player.OnGround = true
+	drone := NewDrone(100, 200, Waypoint{100, 200}, Waypoint{200, 200})
+	drone.SetLevel(level)
+	world.Spawn(player)
+	world.Spawn(drone)
+
+	for i := 0; i < 60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if drone.GetState() != DronePatrol {
+		t.Fatalf("Expected the drone not to see the player out of range, got %v", drone.GetState())
+	}
+
+	// Step into sight: the drone notes where it was and gives chase at up to its top speed
+	player.SetPosition(drone.X+drone.SightRange-40, 268)
+	world.Update(1.0 / 60.0)
+	if drone.GetState() != DroneChase {
+		t.Fatalf("Expected the drone to chase the player in sight, got %v", drone.GetState())
+	}
+	leftX, leftY := drone.returnX, drone.returnY
+
+	topSpeed := 0.0
+	for i := 0; i < 30 && drone.GetState() == DroneChase; i++ {
+		player.SetPosition(drone.X+drone.SightRange-40, 268)
+		world.Update(1.0 / 60.0)
+		topSpeed = math.Max(topSpeed, math.Hypot(drone.VelocityX, drone.VelocityY))
+	}
+	if topSpeed > drone.ChaseSpeed+1e-9 || topSpeed < drone.PatrolSpeed {
+		t.Errorf("Expected the chase faster than patrolling but within %v px/s, got %v", drone.ChaseSpeed, topSpeed)
+	}
+	if !drone.FacingRight {
+		t.Error("Expected the drone to face the player it is chasing")
+	}
+
+	// Hidden from view, the drone keeps looking for LoseTime then heads back
+	level.screenRaised = true
+	frames := 0
+	for drone.GetState() == DroneChase && frames < 600 {
+		world.Update(1.0 / 60.0)
+		frames++
+	}
+	if drone.GetState() != DroneReturn {
+		t.Fatalf("Expected the drone to give up the chase, got %v", drone.GetState())
+	}
+	if lost := float64(frames) / 60; math.Abs(lost-drone.LoseTime) > 2.0/60 {
+		t.Errorf("Expected the chase given up after %vs, took %vs", drone.LoseTime, lost)
+	}
+
+	for i := 0; i < 20*60 && drone.GetState() == DroneReturn; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if drone.GetState() != DronePatrol {
+		t.Fatalf("Expected the drone to return to its patrol, got %v", drone.GetState())
+	}
+	if math.Hypot(drone.X-leftX, drone.Y-leftY) > droneArriveDistance {
+		t.Errorf("Expected the drone back where it left its patrol (%v, %v), got (%v, %v)", leftX, leftY, drone.X, drone.Y)
+	}
+
+	drone.Draw(ebiten.NewImage(800, 400))
+}
+
+func TestDrone_ContactDamage(t *testing.T) {
+	level := &floorChecker{floorY: 300, wallX: math.Inf(1)}
+	world := NewWorld(collision.NewSpatialHash(32))
+	player := NewPlayer(110, 268, CreateTestSpriteSheet())
+	player.SetLevel(level)
+	player.OnGround = true
+	drone := NewDrone(100, 276)
+	world.Spawn(player)
+	world.Spawn(drone)
+
+	world.Update(1.0 / 60.0)
+	if !player.IsDamaged {
+		t.Fatal("Expected the drone to damage the player it touches")
+	}
+	if player.VelocityX <= 0 || player.VelocityY >= 0 || player.OnGround {
+		t.Errorf("Expected the player knocked up and away to the right, got velocity (%v, %v)", player.VelocityX, player.VelocityY)
+	}
+
+	// Held against the drone, the player is hurt again once its immunity wears off
+	hits := 1
+	for i := 0; i < int((player.DamageTime+0.5)*60); i++ {
+		timer := player.DamageTimer
+		player.SetPosition(drone.X+10, drone.Y-8)
+		world.Update(1.0 / 60.0)
+		if player.DamageTimer > timer {
+			hits++
+		}
+	}
+	if hits != 2 {
+		t.Errorf("Expected a second hit after %vs of immunity, got %d hits", player.DamageTime, hits)
+	}
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Collection burst defaults
+const (
+	BurstParticles    = 8    // Sparks flying out of the centre
+	BurstRadius       = 20.0 // Pixels the sparks travel
+	BurstDuration     = 0.4  // Seconds until the burst has faded
+	burstParticleSize = 3.0  // Width and height of a spark in pixels
+)
+
+// pixelImage is a single white pixel, scaled and tinted to draw particles
+var pixelImage *ebiten.Image
+
+// BurstEffect is a ring of sparks that flies outwards and fades, shown when something is
+// collected. It despawns itself when it has faded.
+type BurstEffect struct {
+	EntityBase
+	X, Y     float64 // Centre in world pixels
+	Colour   color.RGBA
+	Duration float64 // Seconds until the burst has faded
+
+	age float64
+}
+
+// NewBurstEffect creates a burst centred on a point, drawn above the player
+func NewBurstEffect(x, y float64, colour color.RGBA) *BurstEffect {
+	burst := &BurstEffect{
+		EntityBase: NewEntityBase(),
+		X:          x,
+		Y:          y,
+		Colour:     colour,
+		Duration:   BurstDuration,
+	}
+	burst.Layer = LayerEffects
+	return burst
+}
+
+// Update ages the burst and despawns it once it has faded
+func (b *BurstEffect) Update(deltaTime float64) {
+	b.age += deltaTime
+	if b.age >= b.Duration {
+		b.World().Despawn(b)
+	}
+}
+
+// Progress returns how far through the burst is, from 0 to 1
+func (b *BurstEffect) Progress() float64 {
+	return min(b.age/b.Duration, 1)
+}
+
+// Draw renders the sparks, slowing as they spread and fading out
+func (b *BurstEffect) Draw(screen *ebiten.Image) {
+	progress := b.Progress()
+	distance := BurstRadius * (1 - (1-progress)*(1-progress)) // Ease out
+	for i := 0; i < BurstParticles; i++ {
+		angle := 2 * math.Pi * float64(i) / BurstParticles
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(burstParticleSize, burstParticleSize)
+		op.GeoM.Translate(
+			b.X+math.Cos(angle)*distance-burstParticleSize/2,
+			b.Y+math.Sin(angle)*distance-burstParticleSize/2,
+		)
+		op.ColorScale.ScaleWithColor(b.Colour)
+		op.ColorScale.ScaleAlpha(float32(1 - progress))
+		screen.DrawImage(whitePixel(), op)
+	}
+}
+
+// GetBounds returns an empty box at the centre; effects never touch anything
+func (b *BurstEffect) GetBounds() (x, y, width, height float64) {
+	return b.X, b.Y, 0, 0
+}
+
+// whitePixel returns pixelImage, creating it the first time it is needed
+func whitePixel() *ebiten.Image {
+	if pixelImage == nil {
+		pixelImage = ebiten.NewImage(1, 1)
+		pixelImage.Fill(color.White)
+	}
+	return pixelImage
+}
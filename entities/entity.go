@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"slices"
+
+	"ebiten-platformer/collision"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// EntityID identifies an entity within its world. It doubles as the entity's ID in the
+// world's spatial hash.
+type EntityID = collision.ID
+
+// Draw layers. Entities on higher layers are drawn on top; within a layer, higher Z is on top.
+const (
+	LayerBackground = -10 // Scenery drawn behind everything else
+	LayerDefault    = 0   // Pickups, NPCs and enemies
+	LayerPlayer     = 10  // The player
+	LayerEffects    = 20  // Particles and other effects drawn over the player
+)
+
+// Tags for finding well-known entities
+const (
+	TagPlayer = "player"
+)
+
+// Entity is anything other than tiles that lives in a level: the player, pickups, NPCs,
+// enemies and debris. Entities embed EntityBase, which supplies ID, tags and draw order.
+type Entity interface {
+	ID() EntityID
+	Update(deltaTime float64)
+	Draw(screen *ebiten.Image)
+	GetBounds() (x, y, width, height float64)
+
+	entityBase() *EntityBase
+}
+
+// ContactListener is implemented by entities that react to touching other entities, such
+// as pickups. The world calls it when it has a spatial hash.
+type ContactListener interface {
+	OnContactEnter(other Entity) // Started overlapping other
+	OnContactExit(other Entity)  // Stopped overlapping other, or other was despawned
+}
+
+// EntityBase is embedded by every entity. Its ID is assigned when the entity is spawned.
+type EntityBase struct {
+	Layer int     // Draw layer, see the Layer constants
+	Z     float64 // Draw order within the layer
+
+	id      EntityID
+	tags    []string
+	world   *World
+	removed bool // Despawned, waiting for the world to drop it
+}
+
+// NewEntityBase creates an entity base on the default layer with the given tags
+func NewEntityBase(tags ...string) EntityBase {
+	return EntityBase{Layer: LayerDefault, tags: tags}
+}
+
+// ID returns the entity's ID, or 0 before it has been spawned
+func (e *EntityBase) ID() EntityID {
+	return e.id
+}
+
+// Tags returns the entity's tags
+func (e *EntityBase) Tags() []string {
+	return e.tags
+}
+
+// HasTag returns whether the entity has a tag
+func (e *EntityBase) HasTag(tag string) bool {
+	return slices.Contains(e.tags, tag)
+}
+
+// AddTag gives the entity a tag if it doesn't have it already
+func (e *EntityBase) AddTag(tag string) {
+	if !e.HasTag(tag) {
+		e.tags = append(e.tags, tag)
+	}
+}
+
+// World returns the world the entity was spawned into, or nil
+func (e *EntityBase) World() *World {
+	return e.world
+}
+
+// findPlayer returns the player in the entity's world, or nil
+func (e *EntityBase) findPlayer() *Player {
+	if e.world == nil {
+		return nil
+	}
+	player, _ := e.world.FirstWithTag(TagPlayer).(*Player)
+	return player
+}
+
+// IsAlive returns whether the entity is in a world and hasn't been despawned
+func (e *EntityBase) IsAlive() bool {
+	return e.world != nil && !e.removed
+}
+
+func (e *EntityBase) entityBase() *EntityBase {
+	return e
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"math"
+
+	"ebiten-platformer/collision"
+)
+
+// newRemovableTileWorld creates a world holding the player, standing on a floor at Y=400,
+// beside a 32x32 tile whose top-left is at (100, 300). The tile is a static solid that
+// removeTile takes away and restoreTile puts back, as the game does with level tiles.
+func newRemovableTileWorld() (world *World, player *Player, removeTile, restoreTile func()) {
+	tile := &collision.Solid{Rect: collision.Rect{X: 100, Y: 300, Width: 32, Height: 32}}
+	tiles := collision.NewSolids(&floorChecker{floorY: 400, wallX: math.Inf(1)})
+	tiles.Add(tile)
+
+	player = NewPlayer(300, 368, CreateTestSpriteSheet())
+	player.SetLevel(tiles)
+	player.OnGround = true
+
+	world = NewWorld(nil)
+	world.Spawn(player)
+	return world, player, func() { tiles.Remove(tile) }, func() { tiles.Add(tile) }
+}
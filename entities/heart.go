@@ -0,0 +1,140 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TagHeart is the tag of every energy heart
+const TagHeart = "heart"
+
+// Energy heart size and idle animation
+const (
+	HeartSize             = 16  // Width and height in pixels
+	DefaultHeartBobHeight = 3.0 // Pixels above and below the rest position
+	DefaultHeartBobSpeed  = 0.8 // Bobs per second
+)
+
+// heartColour is the energy heart's fill colour, also used by its collection burst
+var heartColour = color.RGBA{255, 80, 140, 255}
+
+// heartPattern is the placeholder heart sprite, one character per pixel: '#' is fill,
+// '+' is highlight
+var heartPattern = [HeartSize]string{
+	"................",
+	"..####....####..",
+	".##++##..######.",
+	"##+++###########",
+	"##++############",
+	"################",
+	"################",
+	".##############.",
+	"..############..",
+	"...##########...",
+	"....########....",
+	".....######.....",
+	"......####......",
+	".......##.......",
+	"................",
+	"................",
+}
+
+// heartSprite is created the first time a heart is drawn
+var heartSprite *ebiten.Image
+
+// Heart is an energy heart: a collectible that bobs gently in place until the player
+// touches it, then bursts and disappears
+type Heart struct {
+	EntityBase
+	Key       string  // Identifies the heart in saved progress
+	X, Y      float64 // Top-left corner of the rest position
+	BobHeight float64 // Pixels above and below the rest position
+	BobSpeed  float64 // Bobs per second
+
+	OnCollect func(heart *Heart) // Called once, when the player collects the heart
+
+	time      float64 // Seconds since the heart was created, offset so hearts don't bob in step
+	collected bool
+}
+
+// NewHeart creates an energy heart resting at a position
+func NewHeart(key string, x, y float64) *Heart {
+	return &Heart{
+		EntityBase: NewEntityBase(TagHeart),
+		Key:        key,
+		X:          x,
+		Y:          y,
+		BobHeight:  DefaultHeartBobHeight,
+		BobSpeed:   DefaultHeartBobSpeed,
+		time:       x / 200,
+	}
+}
+
+// Update advances the idle animation
+func (h *Heart) Update(deltaTime float64) {
+	h.time += deltaTime
+}
+
+// BobOffset returns how far the heart is drawn below its rest position
+func (h *Heart) BobOffset() float64 {
+	return h.BobHeight * math.Sin(2*math.Pi*h.BobSpeed*h.time)
+}
+
+// Draw renders the heart at its current bob position
+func (h *Heart) Draw(screen *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(h.X, math.Round(h.Y+h.BobOffset()))
+	screen.DrawImage(HeartSprite(), op)
+}
+
+// GetBounds returns the heart's rest position, so bobbing never moves it out of reach
+func (h *Heart) GetBounds() (x, y, width, height float64) {
+	return h.X, h.Y, HeartSize, HeartSize
+}
+
+// IsCollected returns whether the player has collected the heart
+func (h *Heart) IsCollected() bool {
+	return h.collected
+}
+
+// OnContactEnter collects the heart when the player touches it
+func (h *Heart) OnContactEnter(other Entity) {
+	if h.collected || !other.entityBase().HasTag(TagPlayer) {
+		return
+	}
+	h.collected = true
+
+	world := h.World()
+	world.Despawn(h)
+	world.Spawn(NewBurstEffect(h.X+HeartSize/2, h.Y+h.BobOffset()+HeartSize/2, heartColour))
+
+	if h.OnCollect != nil {
+		h.OnCollect(h)
+	}
+}
+
+// OnContactExit implements ContactListener
+func (h *Heart) OnContactExit(other Entity) {}
+
+// HeartSprite returns the energy heart image, for the heart itself and the HUD
+func HeartSprite() *ebiten.Image {
+	if heartSprite != nil {
+		return heartSprite
+	}
+
+	highlight := color.RGBA{255, 200, 220, 255}
+	heartSprite = ebiten.NewImage(HeartSize, HeartSize)
+	for y, row := range heartPattern {
+		for x, pixel := range row {
+			switch pixel {
+			case '#':
+				heartSprite.Set(x, y, heartColour)
+			case '+':
+				heartSprite.Set(x, y, highlight)
+			}
+		}
+	}
+	return heartSprite
+}
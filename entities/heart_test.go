@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+func TestHeart_Bobbing(t *testing.T) {
+	heart := NewHeart("h", 100, 50)
+	x, y, _, _ := heart.GetBounds()
+
+	lowest, highest := 0.0, 0.0
+	for i := 0; i < 120; i++ {
+		heart.Update(1.0 / 60.0)
+		lowest = math.Max(lowest, heart.BobOffset())
+		highest = math.Min(highest, heart.BobOffset())
+	}
+
+	if lowest > heart.BobHeight+1e-9 || highest < -heart.BobHeight-1e-9 {
+		t.Errorf("Expected the bob to stay within %v px, got %v to %v", heart.BobHeight, highest, lowest)
+	}
+	if lowest-highest < heart.BobHeight {
+		t.Errorf("Expected the heart to visibly bob over two seconds, moved %v px", lowest-highest)
+	}
+	if bx, by, _, _ := heart.GetBounds(); bx != x || by != y {
+		t.Error("Expected the heart's bounds to stay at its rest position")
+	}
+
+	screen := ebiten.NewImage(200, 100)
+	heart.Draw(screen)
+}
+
+func TestHeart_Collect(t *testing.T) {
+	var log []string
+	world := NewWorld(collision.NewSpatialHash(32))
+	heart := NewHeart("h", 100, 0)
+	collected := 0
+	heart.OnCollect = func(h *Heart) { collected++ }
+	bystander := newTestEntity("bystander", 95, 0, &log)
+	player := newTestEntity("player", 0, 0, &log, TagPlayer)
+	world.Spawn(heart)
+	world.Spawn(bystander)
+	world.Spawn(player)
+
+	world.Update(1.0 / 60.0)
+	if heart.IsCollected() || !heart.IsAlive() {
+		t.Fatal("Expected only the player to collect the heart")
+	}
+
+	player.x = 95
+	world.Update(1.0 / 60.0)
+	if !heart.IsCollected() || heart.IsAlive() || collected != 1 {
+		t.Fatalf("Expected the player to collect the heart once, collected=%v alive=%v callbacks=%d",
+			heart.IsCollected(), heart.IsAlive(), collected)
+	}
+
+	bursts := world.Entities(nil)
+	burst, ok := bursts[len(bursts)-1].(*BurstEffect)
+	if !ok {
+		t.Fatalf("Expected a burst effect to be spawned, got %T", bursts[len(bursts)-1])
+	}
+	if burst.Layer != LayerEffects {
+		t.Errorf("Expected the burst on the effects layer, got %d", burst.Layer)
+	}
+	burst.Draw(ebiten.NewImage(200, 100))
+
+	// The burst fades and removes itself
+	for i := 0; i < int(BurstDuration*60)+2; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if burst.IsAlive() || burst.Progress() != 1 {
+		t.Errorf("Expected the burst to despawn when faded, progress %v", burst.Progress())
+	}
+	if collected != 1 {
+		t.Errorf("Expected a single collection, got %d", collected)
+	}
+}
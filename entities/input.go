@@ -30,9 +30,12 @@ func (ih *InputHandler) Update() {
 		ih.player.MoveRight()
 	}
 	
-	// Jumping
+	// Jumping, or dropping through a one-way platform when down is held
 	if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) || inpututil.IsKeyJustPressed(ebiten.KeyW) {
-		ih.player.Jump()
+		down := ebiten.IsKeyPressed(ebiten.KeyArrowDown) || ebiten.IsKeyPressed(ebiten.KeyS)
+		if !down || !ih.player.DropThrough() {
+			ih.player.Jump()
+		}
 	}
 	
 	// Climbing controls (when near climbable surfaces)
@@ -46,6 +49,11 @@ func (ih *InputHandler) Update() {
 		}
 	}
 	
+	// Interacting with whatever is in reach, such as a sad cat
+	if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+		ih.player.Interact()
+	}
+	
 	// Debug controls (remove in final version)
 	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
 		// Toggle climbing mode for testing
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"math"
+
+	"ebiten-platformer/collision"
+)
+
+// InteractRange is how far apart, in pixels, the player's bounds and an entity's may be
+// for the player to interact with it
+const InteractRange = 24.0
+
+// Interactable is implemented by entities the player can interact with, such as NPCs
+type Interactable interface {
+	Entity
+	CanInteract(player *Player) bool // Whether the player is in reach and there is something to do
+	Interact(player *Player) bool    // Acts on an interaction and returns whether anything happened
+}
+
+// Interact interacts with the nearest entity in reach that has something to do. It returns
+// false when there is nothing to interact with or the entity turned the player down.
+func (p *Player) Interact() bool {
+	world := p.World()
+	if world == nil || p.IsDamaged {
+		return false
+	}
+
+	region := collision.Rect{
+		X:      p.X - InteractRange,
+		Y:      p.Y - InteractRange,
+		Width:  p.Width + 2*InteractRange,
+		Height: p.Height + 2*InteractRange,
+	}
+	var target Interactable
+	closest := math.Inf(1)
+	for _, e := range world.QueryRegion(region, nil) {
+		candidate, ok := e.(Interactable)
+		if !ok || !candidate.CanInteract(p) {
+			continue
+		}
+		if distance := centreDistance(p, e); distance < closest {
+			target, closest = candidate, distance
+		}
+	}
+	if target == nil {
+		return false
+	}
+	return target.Interact(p)
+}
+
+// withinReach returns whether the gap between two entities' bounds is at most reach on
+// both axes
+func withinReach(a, b Entity, reach float64) bool {
+	ax, ay, aw, ah := a.GetBounds()
+	bx, by, bw, bh := b.GetBounds()
+	gapX := max(bx-(ax+aw), ax-(bx+bw), 0)
+	gapY := max(by-(ay+ah), ay-(by+bh), 0)
+	return gapX <= reach && gapY <= reach
+}
+
+// centreDistance returns the distance between the centres of two entities' bounds
+func centreDistance(a, b Entity) float64 {
+	ax, ay, aw, ah := a.GetBounds()
+	bx, by, bw, bh := b.GetBounds()
+	return math.Hypot(bx+bw/2-(ax+aw/2), by+bh/2-(ay+ah/2))
+}
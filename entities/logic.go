@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// Tags of logic gates
+const (
+	TagLogic = "logic"
+)
+
+// DefaultTimerDuration is how many seconds a timer stays on unless told otherwise
+const DefaultTimerDuration = 3.0
+
+// LogicKind is how a logic gate turns its inputs into its signal
+type LogicKind int
+
+const (
+	LogicAnd    LogicKind = iota // On while every input is on
+	LogicOr                      // On while any input is on
+	LogicToggle                  // Flips on or off each time its inputs turn on
+	LogicTimer                   // On for Duration each time its inputs turn on
+)
+
+// logicKindNames are the names used for logic kinds in level data, indexed by kind
+var logicKindNames = []string{
+	LogicAnd:    "and",
+	LogicOr:     "or",
+	LogicToggle: "toggle",
+	LogicTimer:  "timer",
+}
+
+// String returns the kind's name in level data
+func (k LogicKind) String() string {
+	if k < 0 || int(k) >= len(logicKindNames) {
+		return "unknown"
+	}
+	return logicKindNames[k]
+}
+
+// LookupLogicKind returns the logic kind with a name used in level data
+func LookupLogicKind(name string) (LogicKind, bool) {
+	for kind, kindName := range logicKindNames {
+		if kindName == name {
+			return LogicKind(kind), true
+		}
+	}
+	return 0, false
+}
+
+// LogicGate reads signals and sends one of its own, so switches can be combined and timed
+// without code. Its inputs "turn on" when none was on and one now is. Inverted gates send
+// the opposite of their result, which makes NOT, NAND and NOR gates, or a timer that
+// switches something off for a while.
+type LogicGate struct {
+	// Where the gate is placed; it has no size and isn't drawn
+	collision.Rect
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	Kind     LogicKind
+	Signal   string   // Name of the signal the gate sends
+	Inputs   []string // Names of the signals the gate reads
+	Invert   bool     // Sends the opposite of its result
+	Duration float64  // Seconds a timer stays on after its inputs turn on
+
+	signals   *Signals
+	on        bool    // The gate's result, before inverting
+	inputOn   bool    // Whether any input was on last frame
+	remaining float64 // Seconds left before a timer turns off
+}
+
+// NewLogicGate creates a gate that is off until its inputs say otherwise
+func NewLogicGate(signals *Signals, signal string, kind LogicKind, x, y float64, inputs ...string) *LogicGate {
+	return &LogicGate{
+		Rect:       collision.Rect{X: x, Y: y},
+		EntityBase: NewEntityBase(TagLogic),
+		Kind:       kind,
+		Signal:     signal,
+		Inputs:     inputs,
+		Duration:   DefaultTimerDuration,
+		signals:    signals,
+	}
+}
+
+// IsOn returns whether the gate is sending its signal
+func (g *LogicGate) IsOn() bool {
+	return g.on != g.Invert
+}
+
+// Remaining returns the seconds left before a timer turns off
+func (g *LogicGate) Remaining() float64 {
+	return g.remaining
+}
+
+// Update works out the gate's result from its inputs and sends its signal
+func (g *LogicGate) Update(deltaTime float64) {
+	inputOn := g.signals.AnyOn(g.Inputs)
+	turnedOn := inputOn && !g.inputOn
+	g.inputOn = inputOn
+
+	switch g.Kind {
+	case LogicAnd:
+		g.on = g.signals.AllOn(g.Inputs)
+	case LogicOr:
+		g.on = inputOn
+	case LogicToggle:
+		if turnedOn {
+			g.on = !g.on
+		}
+	case LogicTimer:
+		g.remaining = max(g.remaining-deltaTime, 0)
+		if turnedOn {
+			g.remaining = g.Duration
+		}
+		g.on = g.remaining > 0
+	}
+	g.signals.Set(g.Signal, g.IsOn())
+}
+
+// GetBounds returns where the gate is placed
+func (g *LogicGate) GetBounds() (float64, float64, float64, float64) {
+	return g.X, g.Y, g.Width, g.Height
+}
+
+// Draw does nothing: logic is hidden wiring
+func (g *LogicGate) Draw(screen *ebiten.Image) {}
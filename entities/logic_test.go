@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogicGate_Kinds(t *testing.T) {
+	// Each step sets the inputs a and b for one frame and expects the gate's signal
+	type step struct {
+		a, b bool
+		want bool
+	}
+	tests := []struct {
+		kind   LogicKind
+		invert bool
+		steps  []step
+	}{
+		{LogicAnd, false, []step{{false, false, false}, {true, false, false}, {true, true, true}, {false, true, false}}},
+		{LogicOr, false, []step{{false, false, false}, {true, false, true}, {true, true, true}, {false, true, true}}},
+		{LogicOr, true, []step{{false, false, true}, {true, false, false}, {false, false, true}}},
+		{LogicToggle, false, []step{
+			{false, false, false},
+			{true, false, true},  // Turning on flips it on
+			{true, true, true},   // A second input while the first is held isn't a new press
+			{false, false, true}, // Letting go leaves it on
+			{false, true, false}, // Another press flips it off
+			{false, false, false},
+		}},
+		{LogicAnd, true, []step{{true, true, false}, {true, false, true}}},
+	}
+
+	for _, tt := range tests {
+		name := tt.kind.String()
+		if tt.invert {
+			name = "not " + name
+		}
+		t.Run(name, func(t *testing.T) {
+			signals := NewSignals()
+			gate := NewLogicGate(signals, "out", tt.kind, 0, 0, "a", "b")
+			gate.Invert = tt.invert
+			for i, step := range tt.steps {
+				signals.Set("a", step.a)
+				signals.Set("b", step.b)
+				gate.Update(1.0 / 60.0)
+				if signals.IsOn("out") != step.want || gate.IsOn() != step.want {
+					t.Errorf("Step %d (a=%v b=%v): expected %v, got %v", i, step.a, step.b, step.want, signals.IsOn("out"))
+				}
+			}
+		})
+	}
+}
+
+func TestLogicGate_Timer(t *testing.T) {
+	signals := NewSignals()
+	timer := NewLogicGate(signals, "out", LogicTimer, 0, 0, "lever")
+	timer.Duration = 2
+
+	// Holding the input on doesn't keep the timer going: it runs from when it turned on
+	signals.Set("lever", true)
+	frames := 0
+	for timer.Update(1.0 / 60.0); signals.IsOn("out") && frames < 5*60; timer.Update(1.0 / 60.0) {
+		frames++
+	}
+	if seconds := float64(frames) / 60; math.Abs(seconds-timer.Duration) > 1.5/60 {
+		t.Fatalf("Expected the timer on for %vs, was on for %vs", timer.Duration, seconds)
+	}
+
+	// Turning the input on again restarts it, even while it's still running
+	signals.Set("lever", false)
+	timer.Update(1.0 / 60.0)
+	signals.Set("lever", true)
+	for range 60 {
+		timer.Update(1.0 / 60.0)
+	}
+	signals.Set("lever", false)
+	timer.Update(1.0 / 60.0)
+	signals.Set("lever", true)
+	timer.Update(1.0 / 60.0)
+	if timer.Remaining() != timer.Duration || !signals.IsOn("out") {
+		t.Errorf("Expected turning on again to restart the timer, %vs remaining", timer.Remaining())
+	}
+}
+
+func TestLookupLogicKind(t *testing.T) {
+	for _, kind := range []LogicKind{LogicAnd, LogicOr, LogicToggle, LogicTimer} {
+		if found, exists := LookupLogicKind(kind.String()); !exists || found != kind {
+			t.Errorf("Expected %q to look up %v, got %v (exists=%v)", kind.String(), kind, found, exists)
+		}
+	}
+	if _, exists := LookupLogicKind("xor"); exists {
+		t.Error("Expected an unknown kind not to look up")
+	}
+	if LogicKind(99).String() != "unknown" {
+		t.Error("Expected an invalid kind to be named unknown")
+	}
+}
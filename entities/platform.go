@@ -0,0 +1,309 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// Tags of moving platforms
+const (
+	TagPlatform = "platform"
+)
+
+// Moving platform defaults
+const (
+	DefaultPlatformSpeed = 60.0 // Speed along the path (px/s)
+
+	PlatformRideTolerance = 2.0 // How far the player's feet may be from a platform's top and still ride it
+	PlatformCrushDepth    = 1.0 // How far a platform may squeeze into the player against a tile before crushing them
+)
+
+// PlatformMode is how a moving platform travels along its path
+type PlatformMode int
+
+const (
+	PlatformLinear    PlatformMode = iota // Travels the path once and stops at its end
+	PlatformLoop                          // Travels the path, then straight back to its start, over and over
+	PlatformPingPong                      // Travels the path to its end and back, over and over
+	PlatformTriggered                     // Waits at an end until triggered, then travels to the other end
+)
+
+// platformModeNames are the names used for platform modes in level data, indexed by mode
+var platformModeNames = []string{
+	PlatformLinear:    "linear",
+	PlatformLoop:      "loop",
+	PlatformPingPong:  "ping_pong",
+	PlatformTriggered: "triggered",
+}
+
+// String returns the mode's name in level data
+func (m PlatformMode) String() string {
+	if m < 0 || int(m) >= len(platformModeNames) {
+		return "unknown"
+	}
+	return platformModeNames[m]
+}
+
+// LookupPlatformMode returns the platform mode with a name used in level data
+func LookupPlatformMode(name string) (PlatformMode, bool) {
+	for mode, modeName := range platformModeNames {
+		if modeName == name {
+			return PlatformMode(mode), true
+		}
+	}
+	return 0, false
+}
+
+// Platform colours
+var (
+	platformBodyColour = color.RGBA{90, 100, 120, 255}
+	platformTopColour  = color.RGBA{170, 180, 200, 255}
+)
+
+// Platform is a solid box that moves along a path at a steady speed, ignoring gravity and
+// tiles. The player can stand on it and is carried wherever it goes. A platform moving
+// into the player pushes them out of the way, and crushes them if a solid tile is in the
+// way. One-way platforms only carry; the player can jump up through them.
+//
+// The platform's Solid is what entities collide with: add it to the collision.Solids the
+// player moves through.
+type Platform struct {
+	// Position, size and surface that the player collides with
+	collision.Solid
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	Path  []Waypoint   // Points the top-left corner travels through, starting where the platform was placed
+	Mode  PlatformMode // How the path is travelled
+	Speed float64      // Speed along the path (px/s)
+
+	VelocityX, VelocityY float64 // How fast the platform moved last frame (px/s)
+
+	target    int  // Path point being travelled to
+	direction int  // 1 while travelling forward along the path, -1 while travelling back
+	moving    bool // False once a linear platform has finished, or while a triggered one waits
+	carrying  bool // Whether the player was riding last frame, so triggered platforms set off as they step on
+	level     collision.Checker
+}
+
+// NewPlatform creates a moving platform with its top-left corner at (x, y) that travels
+// through the path's points after its starting position. Triggered platforms wait to be
+// triggered; the others set off straight away.
+func NewPlatform(x, y, width, height float64, mode PlatformMode, path ...Waypoint) *Platform {
+	platform := &Platform{
+		Solid:      collision.Solid{Rect: collision.Rect{X: x, Y: y, Width: width, Height: height}},
+		EntityBase: NewEntityBase(TagPlatform),
+		Path:       append([]Waypoint{{X: x, Y: y}}, path...),
+		Mode:       mode,
+		Speed:      DefaultPlatformSpeed,
+		direction:  1,
+	}
+	platform.moving = mode != PlatformTriggered && len(path) > 0
+	return platform
+}
+
+// SetLevel sets the tiles the platform carries and pushes the player through. It should
+// be the level itself rather than collision.Solids holding the platform. Without a level
+// the player is moved freely and never crushed.
+func (p *Platform) SetLevel(level collision.Checker) {
+	p.level = level
+}
+
+// Trigger sets a waiting triggered platform off towards the other end of its path
+func (p *Platform) Trigger() {
+	if !p.moving && p.Mode == PlatformTriggered && len(p.Path) > 1 {
+		p.moving = true
+	}
+}
+
+// IsMoving returns whether the platform is travelling along its path
+func (p *Platform) IsMoving() bool {
+	return p.moving
+}
+
+// IsCarrying returns whether the player is standing on the platform, so it carries them.
+// A rising player is leaving it, and a player dropping through a one-way platform isn't on it.
+func (p *Platform) IsCarrying(player *Player) bool {
+	if player.VelocityY < 0 || (p.OneWay && player.IgnoreOneWay) {
+		return false
+	}
+	x, y, width, height := player.GetBounds()
+	return math.Abs(y+height-p.Y) <= PlatformRideTolerance && x < p.X+p.Width && x+width > p.X
+}
+
+// Update moves the platform along its path, carrying or pushing the player
+func (p *Platform) Update(deltaTime float64) {
+	player := p.findPlayer()
+	carrying := player != nil && !player.IsDead && p.IsCarrying(player)
+
+	// Triggered platforms set off as the player steps on
+	if p.Mode == PlatformTriggered && carrying && !p.carrying {
+		p.Trigger()
+	}
+	p.carrying = carrying
+
+	startX, startY := p.X, p.Y
+	x, y := p.travel(p.Speed * deltaTime)
+
+	// One axis at a time, like bodies, so the player is pushed out of the side they are on
+	p.moveAxis(player, carrying, x-p.X, 0)
+	p.moveAxis(player, carrying, 0, y-p.Y)
+
+	if deltaTime > 0 {
+		p.VelocityX = (p.X - startX) / deltaTime
+		p.VelocityY = (p.Y - startY) / deltaTime
+	}
+}
+
+// travel follows the path for a distance from the platform's position, moving on to the
+// next point at each one it reaches, and returns where the platform ends up
+func (p *Platform) travel(distance float64) (x, y float64) {
+	x, y = p.X, p.Y
+
+	// Each point is passed at most twice a frame, so paths of repeated points can't stall it
+	for range 2 * len(p.Path) {
+		if !p.moving {
+			break
+		}
+		target := p.Path[p.target]
+		dx, dy := target.X-x, target.Y-y
+		length := math.Hypot(dx, dy)
+		if length > distance {
+			return x + dx/length*distance, y + dy/length*distance
+		}
+		x, y = target.X, target.Y
+		distance -= length
+		p.arrive()
+	}
+	return x, y
+}
+
+// arrive picks the point to travel to after reaching the target, or stops the platform
+func (p *Platform) arrive() {
+	last := len(p.Path) - 1
+	switch p.Mode {
+	case PlatformLinear:
+		if p.target == last {
+			p.moving = false
+			return
+		}
+		p.target++
+	case PlatformLoop:
+		p.target = (p.target + 1) % len(p.Path)
+	case PlatformPingPong:
+		if next := p.target + p.direction; next < 0 || next > last {
+			p.direction = -p.direction
+		}
+		p.target += p.direction
+	case PlatformTriggered:
+		if next := p.target + p.direction; next < 0 || next > last {
+			p.direction = -p.direction
+			p.moving = false
+			return
+		}
+		p.target += p.direction
+	}
+}
+
+// moveAxis moves the platform along one axis. A riding player is carried along, then a
+// player the platform has moved into is pushed out of its way. If a solid tile stops the
+// push before the player is clear, they are crushed.
+func (p *Platform) moveAxis(player *Player, carrying bool, deltaX, deltaY float64) {
+	if deltaX == 0 && deltaY == 0 {
+		return
+	}
+	p.X += deltaX
+	p.Y += deltaY
+	if player == nil || player.IsDead {
+		return
+	}
+
+	if carrying {
+		p.shove(player, deltaX, deltaY)
+	}
+	if p.OneWay {
+		return
+	}
+
+	// Feet resting on the top aren't in the way of sideways movement
+	bounds := collision.Rect{X: player.X, Y: player.Y, Width: player.Width, Height: player.Height}
+	if deltaX != 0 {
+		bounds.Height -= PlatformRideTolerance
+	}
+	if !p.Overlaps(bounds) {
+		return
+	}
+
+	var push, moved float64
+	switch {
+	case deltaX > 0:
+		push = p.X + p.Width - player.X
+	case deltaX < 0:
+		push = p.X - (player.X + player.Width)
+	case deltaY > 0:
+		push = p.Y + p.Height - player.Y
+	default:
+		push = p.Y - (player.Y + player.Height)
+	}
+	if deltaX != 0 {
+		moved = p.shove(player, push, 0)
+	} else {
+		moved = p.shove(player, 0, push)
+	}
+
+	if math.Abs(push-moved) > PlatformCrushDepth {
+		player.Kill()
+	}
+}
+
+// shove moves the player by (deltaX, deltaY), which is along one axis, stopping at solid
+// tiles, and returns how far they moved
+func (p *Platform) shove(player *Player, deltaX, deltaY float64) float64 {
+	if p.level == nil {
+		player.X += deltaX
+		player.Y += deltaY
+		return deltaX + deltaY
+	}
+
+	landOnOneWay := deltaY > 0 && !player.IgnoreOneWay
+	result := p.level.Sweep(player.X, player.Y, player.Width, player.Height, deltaX, deltaY, landOnOneWay)
+	moved := result.X - player.X + result.Y - player.Y
+	player.X, player.Y = result.X, result.Y
+	return moved
+}
+
+// GetBounds returns the platform's collision rectangle
+func (p *Platform) GetBounds() (float64, float64, float64, float64) {
+	return p.X, p.Y, p.Width, p.Height
+}
+
+// GetVelocity returns how fast the platform moved last frame
+func (p *Platform) GetVelocity() (float64, float64) {
+	return p.VelocityX, p.VelocityY
+}
+
+// Draw renders the platform as a block with a lighter top to stand on. One-way platforms
+// are just the top.
+func (p *Platform) Draw(screen *ebiten.Image) {
+	const topHeight = 4
+	x, y := math.Round(p.X), math.Round(p.Y)
+
+	if !p.OneWay {
+		body := &ebiten.DrawImageOptions{}
+		body.GeoM.Scale(p.Width, p.Height)
+		body.GeoM.Translate(x, y)
+		body.ColorScale.ScaleWithColor(platformBodyColour)
+		screen.DrawImage(whitePixel(), body)
+	}
+
+	top := &ebiten.DrawImageOptions{}
+	top.GeoM.Scale(p.Width, math.Min(topHeight, p.Height))
+	top.GeoM.Translate(x, y)
+	top.ColorScale.ScaleWithColor(platformTopColour)
+	screen.DrawImage(whitePixel(), top)
+}
@@ -0,0 +1,254 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"ebiten-platformer/collision"
+)
+
+// newPlatformWorld creates a world holding the player, spawned first as in the game, and a
+// platform. A floor with its top at Y=400 and static solids for the walls given stand in
+// for the level's tiles.
+func newPlatformWorld(platform *Platform, playerX, playerY float64, walls ...collision.Rect) (*World, *Player) {
+	tiles := collision.NewSolids(&floorChecker{floorY: 400, wallX: math.Inf(1)})
+	for _, wall := range walls {
+		tiles.Add(&collision.Solid{Rect: wall})
+	}
+	solids := collision.NewSolids(tiles)
+	solids.Add(&platform.Solid)
+	platform.SetLevel(tiles)
+
+	player := NewPlayer(playerX, playerY, CreateTestSpriteSheet())
+	player.SetLevel(solids)
+
+	world := NewWorld(nil)
+	world.Spawn(player)
+	world.Spawn(platform)
+	return world, player
+}
+
+func TestPlatformModeNames(t *testing.T) {
+	for _, mode := range []PlatformMode{PlatformLinear, PlatformLoop, PlatformPingPong, PlatformTriggered} {
+		found, exists := LookupPlatformMode(mode.String())
+		if !exists || found != mode {
+			t.Errorf("Expected %q to look up as itself, got %v (exists=%v)", mode, found, exists)
+		}
+	}
+	if _, exists := LookupPlatformMode("teleport"); exists {
+		t.Error("Expected an unknown name not to look up")
+	}
+}
+
+func TestPlatform_Paths(t *testing.T) {
+	type stop struct {
+		time   float64 // Seconds since the platform was created
+		x, y   float64
+		moving bool
+	}
+	tests := []struct {
+		name  string
+		mode  PlatformMode
+		path  []Waypoint
+		stops []stop
+	}{
+		{"linear stops at the end", PlatformLinear, []Waypoint{{100, 0}, {100, 100}}, []stop{
+			{1, 100, 0, true}, {1.5, 100, 50, true}, {2, 100, 100, false}, {5, 100, 100, false},
+		}},
+		{"loop returns to the start", PlatformLoop, []Waypoint{{100, 0}, {100, 100}}, []stop{
+			{1, 100, 0, true}, {2, 100, 100, true}, {2 + math.Sqrt2, 0, 0, true}, {3 + math.Sqrt2, 100, 0, true},
+		}},
+		{"ping-pong comes back the same way", PlatformPingPong, []Waypoint{{100, 0}, {100, 100}}, []stop{
+			{1, 100, 0, true}, {2, 100, 100, true}, {3, 100, 0, true}, {4, 0, 0, true}, {4.5, 50, 0, true},
+		}},
+		{"nowhere to go", PlatformPingPong, nil, []stop{{1, 0, 0, false}}},
+		{"triggered waits", PlatformTriggered, []Waypoint{{100, 0}}, []stop{{3, 0, 0, false}}},
+	}
+
+	const frame = 0.25
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform := NewPlatform(0, 0, 64, 16, tt.mode, tt.path...)
+			platform.Speed = 100
+			elapsed := 0.0
+			for _, stop := range tt.stops {
+				for elapsed < stop.time-1e-9 {
+					step := math.Min(frame, stop.time-elapsed)
+					platform.Update(step)
+					elapsed += step
+				}
+				if math.Abs(platform.X-stop.x) > 1e-9 || math.Abs(platform.Y-stop.y) > 1e-9 || platform.IsMoving() != stop.moving {
+					t.Errorf("After %vs expected (%v, %v) moving=%v, got (%v, %v) moving=%v",
+						stop.time, stop.x, stop.y, stop.moving, platform.X, platform.Y, platform.IsMoving())
+				}
+			}
+		})
+	}
+
+	// Velocity is last frame's movement
+	platform := NewPlatform(0, 0, 64, 16, PlatformLinear, Waypoint{100, 0})
+	platform.Speed = 80
+	platform.Update(0.5)
+	if vx, vy := platform.GetVelocity(); vx != 80 || vy != 0 {
+		t.Errorf("Expected a velocity of (80, 0), got (%v, %v)", vx, vy)
+	}
+}
+
+func TestPlatform_Triggered(t *testing.T) {
+	platform := NewPlatform(100, 300, 96, 16, PlatformTriggered, Waypoint{300, 300})
+	platform.Speed = 200
+	world, player := newPlatformWorld(platform, 120, 268)
+
+	// Stepping on sets it off, and it waits at the other end while the player stays on
+	for i := 0; i < 2*60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if platform.X != 300 || platform.IsMoving() {
+		t.Fatalf("Expected the platform to wait at the end, got X=%v moving=%v", platform.X, platform.IsMoving())
+	}
+	if math.Abs(player.X-320) > 1e-9 || math.Abs(player.Y-268) > 1e-9 {
+		t.Errorf("Expected the player to have been carried to (320, 268), got (%v, %v)", player.X, player.Y)
+	}
+
+	// The player stepping back on sends it home
+	player.Jump()
+	for i := 0; i < 3*60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if platform.X != 100 || platform.IsMoving() {
+		t.Errorf("Expected stepping on again to send the platform back, got X=%v moving=%v", platform.X, platform.IsMoving())
+	}
+	if math.Abs(player.X-120) > 1e-9 || !platform.IsCarrying(player) {
+		t.Errorf("Expected the player to ride it back to X=120, got (%v, %v)", player.X, player.Y)
+	}
+
+	// Game code can set it off too
+	platform.Trigger()
+	world.Update(1.0 / 60.0)
+	if !platform.IsMoving() || platform.X <= 100 {
+		t.Errorf("Expected Trigger to set the platform off again, got X=%v moving=%v", platform.X, platform.IsMoving())
+	}
+}
+
+func TestPlatform_CarriesPlayerAtHighSpeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Waypoint
+	}{
+		{"up and down", Waypoint{100, 0}, Waypoint{100, 360}},
+		{"side to side", Waypoint{0, 300}, Waypoint{600, 300}},
+		{"diagonally", Waypoint{0, 0}, Waypoint{400, 300}},
+	}
+
+	for _, speed := range []float64{300, 900} {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s at %v px/s", tt.name, speed), func(t *testing.T) {
+				platform := NewPlatform(tt.from.X, tt.from.Y, 96, 16, PlatformPingPong, tt.to)
+				platform.Speed = speed
+				world, player := newPlatformWorld(platform, tt.from.X+20, tt.from.Y-32)
+
+				// Several trips, turning round at full speed at each end
+				for i := 0; i < 4*60; i++ {
+					world.Update(1.0 / 60.0)
+					if player.IsDead {
+						t.Fatalf("Expected a free ride, the player was crushed after %d frames", i)
+					}
+					if math.Abs(player.X-platform.X-20) > 1e-6 || math.Abs(player.Y+player.Height-platform.Y) > 1e-6 {
+						t.Fatalf("Expected the player to ride at (+20, on top) at %v px/s, got (%+v, %+v) after %d frames",
+							speed, player.X-platform.X, player.Y+player.Height-platform.Y, i)
+					}
+					if !player.OnGround || !platform.IsCarrying(player) {
+						t.Fatalf("Expected the player to stand on the platform after %d frames, on ground=%v", i, player.OnGround)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPlatform_JumpOff(t *testing.T) {
+	platform := NewPlatform(100, 300, 96, 16, PlatformPingPong, Waypoint{100, 100})
+	world, player := newPlatformWorld(platform, 120, 268)
+	for i := 0; i < 10; i++ {
+		world.Update(1.0 / 60.0)
+	}
+
+	// Jumping leaves the rising platform behind, and landing back on it rides again
+	player.Jump()
+	world.Update(1.0 / 60.0)
+	if platform.IsCarrying(player) || player.Y+player.Height >= platform.Y {
+		t.Fatalf("Expected the jump to leave the platform, feet %v above it", platform.Y-player.Y-player.Height)
+	}
+	for i := 0; i < 2*60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if !platform.IsCarrying(player) || !player.OnGround {
+		t.Errorf("Expected the player to land back on the platform, feet at %v with its top at %v", player.Y+player.Height, platform.Y)
+	}
+}
+
+func TestPlatform_PushesPlayer(t *testing.T) {
+	// A block as tall as the player sweeps along the floor and pushes them ahead of it
+	platform := NewPlatform(0, 352, 64, 48, PlatformLinear, Waypoint{300, 352})
+	platform.Speed = 240
+	world, player := newPlatformWorld(platform, 100, 368)
+
+	for i := 0; i < 2*60; i++ {
+		world.Update(1.0 / 60.0)
+		if player.X < platform.X+platform.Width-1e-9 && player.X+player.Width > platform.X {
+			t.Fatalf("Expected the player to be kept out of the platform, got X=%v against its right side at %v", player.X, platform.X+platform.Width)
+		}
+	}
+	if player.IsDead || player.X != 364 {
+		t.Errorf("Expected the player pushed to X=364 unharmed, got X=%v dead=%v", player.X, player.IsDead)
+	}
+}
+
+func TestPlatform_Crushes(t *testing.T) {
+	wall := collision.Rect{X: 300, Y: 0, Width: 32, Height: 400}
+	ceiling := collision.Rect{X: 0, Y: 0, Width: 600, Height: 100}
+
+	tests := []struct {
+		name             string
+		from, to         Waypoint
+		width, height    float64
+		oneWay           bool
+		playerX, playerY float64
+		walls            []collision.Rect
+		wantDead         bool
+	}{
+		{"against a wall", Waypoint{100, 352}, Waypoint{400, 352}, 96, 48, false, 240, 368, []collision.Rect{wall}, true},
+		{"onto the floor", Waypoint{100, 200}, Waypoint{100, 384}, 96, 16, false, 120, 368, nil, true},
+		{"up into a ceiling", Waypoint{100, 300}, Waypoint{100, 110}, 96, 16, false, 120, 268, []collision.Rect{ceiling}, true},
+		{"short of a ceiling", Waypoint{100, 300}, Waypoint{100, 140}, 96, 16, false, 120, 268, []collision.Rect{ceiling}, false},
+		{"one-way up into a ceiling", Waypoint{100, 300}, Waypoint{100, 110}, 96, 16, true, 120, 268, []collision.Rect{ceiling}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform := NewPlatform(tt.from.X, tt.from.Y, tt.width, tt.height, PlatformLinear, tt.to)
+			platform.OneWay = tt.oneWay
+			platform.Speed = 120
+			world, player := newPlatformWorld(platform, tt.playerX, tt.playerY, tt.walls...)
+
+			for i := 0; i < 4*60 && !player.IsDead; i++ {
+				world.Update(1.0 / 60.0)
+			}
+			if player.IsDead != tt.wantDead {
+				t.Errorf("Expected dead=%v, got %v with the player at (%v, %v) and the platform at (%v, %v)",
+					tt.wantDead, player.IsDead, player.X, player.Y, platform.X, platform.Y)
+			}
+		})
+	}
+
+	// The dead don't move
+	platform := NewPlatform(100, 200, 96, 16, PlatformLinear, Waypoint{100, 384})
+	world, player := newPlatformWorld(platform, 120, 368)
+	player.Kill()
+	player.MoveRight()
+	world.Update(1.0 / 60.0)
+	if player.X != 120 || player.VelocityX != 0 {
+		t.Errorf("Expected a dead player to stay put, got X=%v moving at %v", player.X, player.VelocityX)
+	}
+}
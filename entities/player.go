@@ -4,54 +4,77 @@ import (
 	"fmt"
 	"math"
 
+	"ebiten-platformer/collision"
+
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // Constants for collision detection tuning
 const (
-	// BinarySearchMaxIterations defines the maximum number of iterations
-	// for binary search collision detection. Higher values provide more
-	// precision but cost more CPU cycles.
-	BinarySearchMaxIterations = 50
-
-	// BinarySearchMaxIterationsY defines the maximum number of iterations
-	// for Y-axis binary search collision detection. Uses fewer iterations
-	// to prevent over-precision in vertical movement.
-	BinarySearchMaxIterationsY = 30
-
-	// BinarySearchTolerance defines the minimum distance threshold for
-	// binary search convergence. Smaller values provide higher precision.
-	BinarySearchTolerance = 0.01
-
-	// BinarySearchToleranceY defines the tolerance for Y-axis binary search.
-	// Slightly larger than X-axis to balance precision and stability.
-	BinarySearchToleranceY = 0.1
+	// SlopeSnapTolerance is the extra distance, beyond the drop caused by horizontal
+	// movement, that a grounded player is pulled down to stay on a descending slope.
+	SlopeSnapTolerance = 2.0
+
+	// DefaultMaxSlopeAngle is the steepest slope, in degrees, the player can walk up.
+	// 22.5° slopes are walkable; 45° slopes are steep and the player slides down them.
+	DefaultMaxSlopeAngle = 35.0
+
+	// OneWayLandingTolerance is how far the feet may already be below a one-way platform's
+	// top and still land on it. It matches the level's ground tolerance.
+	OneWayLandingTolerance = 2.0
+
+	// DropThroughDepth is how far the player is moved down when dropping through a one-way
+	// platform, just past the ground tolerance so the platform stops supporting it.
+	DropThroughDepth = OneWayLandingTolerance + 1.0
+
+	// MinBounceSpeed is the slowest bounce off a bouncy surface; slower bounces settle
+	// onto the surface instead so the player comes to rest.
+	MinBounceSpeed = 40.0
 )
 
 // Player represents the ROBO-9 character
 type Player struct {
-	// Position and movement
-	X, Y          float64
-	VelocityX     float64
-	VelocityY     float64
-	Width, Height float64
+	// Position, size, velocity, gravity and ground state
+	Body
+
+	// ID, tags and draw order in the world
+	EntityBase
 
 	// Physics constants
-	Speed     float64
+	Speed     float64 // Top walking speed (px/s)
 	JumpSpeed float64
-	Gravity   float64
-	Friction  float64
+
+	// Horizontal acceleration rates (px/s²). Ground rates are scaled by the surface's friction.
+	GroundAcceleration float64 // Speeding up towards walking speed on the ground
+	GroundDeceleration float64 // Slowing down on the ground: no input, turning or over speed
+	AirAcceleration    float64 // Speeding up in the air
+	AirDeceleration    float64 // Slowing down in the air
 
 	// State
-	OnGround    bool
 	FacingRight bool
+	moveInput   float64 // Direction requested by MoveLeft/MoveRight this frame (-1, 0 or 1)
 	IsJumping   bool
 	IsMoving    bool
 	IsClimbing  bool
 	IsDamaged   bool
+	IsDead      bool // Killed, such as by being crushed; the game respawns a dead player
+
+	// Slopes
+	OnSlope       bool    // Standing on a sloped tile
+	SlopeGradient float64 // Surface Y change per pixel moved right (negative rises to the right)
+	Sliding       bool    // On a slope steeper than MaxSlopeAngle
+	MaxSlopeAngle float64 // Steepest walkable slope in degrees
+
+	// Surface materials
+	GroundMaterial collision.Material // Surface under the player's feet (collision.DefaultMaterial in the air)
+
+	// One-way platforms
+	DropThroughTime  float64 // How long one-way platforms are ignored after dropping through
+	DropThroughTimer float64 // Time left before one-way platforms can catch the player again
 
 	// Animation
 	AnimationController *AnimationController
+	AnimationMachine    *AnimationStateMachine
 
 	// Timing
 	DamageTimer float64
@@ -63,7 +86,7 @@ type Player struct {
 	WasOnGroundPhysics bool // Previous frame physics ground state
 
 	// Collision
-	level CollisionChecker
+	level collision.Checker
 }
 
 // NewPlayer creates a new ROBO-9 player instance
@@ -73,55 +96,114 @@ func NewPlayer(x, y float64, spriteSheet *ebiten.Image) *Player {
 	frameHeight := 32
 
 	player := &Player{
-		X:           x,
-		Y:           y,
-		Width:       float64(frameWidth),
-		Height:      float64(frameHeight),
-		Speed:       120.0, // pixels per second
-		JumpSpeed:   200.0,
-		Gravity:     500.0,
-		Friction:    0.8,
+		Body:       NewBody(x, y, float64(frameWidth), float64(frameHeight), 500.0),
+		EntityBase: NewEntityBase(TagPlayer),
+		Speed:      120.0, // pixels per second
+		JumpSpeed:  200.0,
+
+		GroundAcceleration: 1200.0, // Full speed in 0.1s
+		GroundDeceleration: 1200.0, // Stops from full speed within 6px
+		AirAcceleration:    600.0,
+		AirDeceleration:    300.0, // Jumps keep most of their momentum
+
 		FacingRight: true,
 		DamageTime:  1.0, // 1 second of damage immunity
 		CoyoteTime:  0.1, // 100ms of coyote time (standard for platform edge jumps)
+
+		MaxSlopeAngle:   DefaultMaxSlopeAngle,
+		GroundMaterial:  collision.DefaultMaterial,
+		DropThroughTime: 0.25, // Long enough to clear a platform before landing is possible again
 	}
 
+	player.Layer = LayerPlayer
+
 	// Initialize animation controller
 	player.AnimationController = NewAnimationController(spriteSheet, frameWidth, frameHeight)
 	player.setupAnimations()
 
 	// Start with idle animation
-	player.AnimationController.SetState(AnimationIdle)
+	player.AnimationMachine = NewAnimationStateMachine(player.AnimationController, AnimationIdle)
+	player.setupAnimationStates()
 
 	return player
 }
 
-// setupAnimations configures all the player animations
+// playerAnimationTimings are the seconds per frame and looping of each player animation.
+// Which frames they play comes from Robo9SpriteLayout.
+var playerAnimationTimings = map[AnimationState]struct {
+	frameTime float64
+	loop      bool
+}{
+	AnimationIdle:      {0.2, true},
+	AnimationWalk:      {0.1, true},
+	AnimationJump:      {0.1, false},
+	AnimationFall:      {0.15, true},
+	AnimationClimb:     {0.15, true},
+	AnimationDamage:    {0.1, false},
+	AnimationJumpStart: {0.05, false}, // Crouch before leaving the ground
+	AnimationLand:      {0.08, false}, // Squash on touching down
+}
+
+// setupAnimations configures all the player animations from the ROBO-9 sheet layout
 func (p *Player) setupAnimations() {
-	// Define animation sequences (frame start, count, timing, loop)
-	// These values would need to be adjusted based on your actual sprite sheet layout
+	for _, anim := range Robo9SpriteLayout().Animations {
+		timing := playerAnimationTimings[anim.State]
+		p.AnimationController.AddAnimation(anim.State, anim.StartFrame, anim.FrameCount, timing.frameTime, timing.loop)
+	}
+
+	// Footstep events on the walk frames where a foot touches down
+	p.AnimationController.AddFrameEvent(AnimationWalk, "footstep", 1, 3)
+}
+
+// setupAnimationStates declares the player's animation states and transitions
+func (p *Player) setupAnimationStates() {
+	sm := p.AnimationMachine
+
+	// Clips that must play through before ordinary movement takes over
+	sm.AddState(AnimationStateConfig{State: AnimationJumpStart, Priority: 45})
+	sm.AddState(AnimationStateConfig{State: AnimationLand, Priority: 20, PlayOnce: true, ReturnTo: AnimationIdle})
 
-	// Idle animation: frames 0-3, 0.2 seconds per frame, loops
-	p.AnimationController.AddAnimation(AnimationIdle, 0, 4, 0.2, true)
+	airborne := func() bool { return !p.OnGround }
+	rising := func() bool { return !p.OnGround && p.VelocityY < 0 }
+	grounded := func() bool { return p.OnGround }
 
-	// Walk animation: frames 4-7, 0.1 seconds per frame, loops
-	p.AnimationController.AddAnimation(AnimationWalk, 4, 4, 0.1, true)
+	// Priority 1: Damage state (overrides all others)
+	sm.AddTransition(AnimationTransition{To: AnimationDamage, Priority: 100, Guard: func() bool { return p.IsDamaged }})
 
-	// Jump animation: frames 8-9, 0.1 seconds per frame, doesn't loop
-	p.AnimationController.AddAnimation(AnimationJump, 8, 2, 0.1, false)
+	// Priority 2: Climbing state
+	sm.AddTransition(AnimationTransition{To: AnimationClimb, Priority: 90, Guard: func() bool { return p.IsClimbing }})
 
-	// Fall animation: frames 10-11, 0.15 seconds per frame, loops
-	p.AnimationController.AddAnimation(AnimationFall, 10, 2, 0.15, true)
+	// Priority 3: Airborne states, with an anticipation clip when leaving the ground
+	sm.AddTransition(AnimationTransition{
+		From:     []AnimationState{AnimationIdle, AnimationWalk, AnimationLand},
+		To:       AnimationJump,
+		Via:      []AnimationState{AnimationJumpStart},
+		Priority: 50,
+		Guard:    rising,
+	})
+	sm.AddTransition(AnimationTransition{To: AnimationJump, Priority: 40, Guard: rising})
+	sm.AddTransition(AnimationTransition{To: AnimationFall, Priority: 35, Guard: airborne})
 
-	// Climb animation: frames 12-15, 0.15 seconds per frame, loops
-	p.AnimationController.AddAnimation(AnimationClimb, 12, 4, 0.15, true)
+	// Priority 4: Touching down from the air squashes before returning to idle
+	sm.AddTransition(AnimationTransition{
+		From:     []AnimationState{AnimationJump, AnimationFall},
+		To:       AnimationLand,
+		Priority: 30,
+		Guard:    grounded,
+	})
 
-	// Damage animation: frames 16-17, 0.1 seconds per frame, doesn't loop
-	p.AnimationController.AddAnimation(AnimationDamage, 16, 2, 0.1, false)
+	// Priority 5: Ground-based movement
+	sm.AddTransition(AnimationTransition{To: AnimationWalk, Priority: 10, Guard: func() bool { return p.OnGround && p.IsMoving }})
+	sm.AddTransition(AnimationTransition{To: AnimationIdle, Priority: 0, Guard: grounded})
 }
 
 // Update updates the player's state and animation
 func (p *Player) Update(deltaTime float64) {
+	// The dead stay where they fell until respawned
+	if p.IsDead {
+		return
+	}
+
 	// Update damage timer
 	if p.DamageTimer > 0 {
 		p.DamageTimer -= deltaTime
@@ -130,8 +212,17 @@ func (p *Player) Update(deltaTime float64) {
 		}
 	}
 
-	// Apply physics
+	// Update drop-through grace window
+	if p.DropThroughTimer > 0 {
+		p.DropThroughTimer -= deltaTime
+		if p.DropThroughTimer < 0 {
+			p.DropThroughTimer = 0
+		}
+	}
+
+	// Apply physics, then clear this frame's movement input
 	p.updatePhysics(deltaTime)
+	p.moveInput = 0
 
 	// Update coyote timer (after physics so we have correct OnGround state)
 	p.updateCoyoteTime(deltaTime)
@@ -148,7 +239,8 @@ func (p *Player) updateCoyoteTime(deltaTime float64) {
 	// Simple coyote time logic: activate when leaving ground, count down over time
 	
 	// If player was on ground last frame but isn't now (and not from jumping), start coyote time
-	if p.WasOnGroundPhysics && !p.OnGround && !p.IsJumping {
+	// Dropping through a platform is deliberate, so it doesn't give coyote time either
+	if p.WasOnGroundPhysics && !p.OnGround && !p.IsJumping && p.DropThroughTimer <= 0 {
 		if p.CoyoteTimer <= 0 { // Only start if not already active
 			p.CoyoteTimer = p.CoyoteTime
 		}
@@ -174,21 +266,22 @@ func (p *Player) updateCoyoteTime(deltaTime float64) {
 
 // updatePhysics handles movement and gravity with tile-based collision
 func (p *Player) updatePhysics(deltaTime float64) {
-	// Store previous position for collision resolution
-	prevX := p.X
-	prevY := p.Y
-
 	// Apply gravity if not on ground
-	if !p.OnGround {
-		p.VelocityY += p.Gravity * deltaTime
-	}
+	deltaY := p.Fall(deltaTime)
 
-	// Apply friction to horizontal movement
-	p.VelocityX *= p.Friction
+	// Steep slopes pull the player downhill and ignore movement input
+	var deltaX float64
+	if p.Sliding {
+		deltaX = p.applySlide(deltaTime)
+	} else {
+		deltaX = p.integrateHorizontal(deltaTime)
+	}
 
-	// Calculate intended movement
-	deltaX := p.VelocityX * deltaTime
-	deltaY := p.VelocityY * deltaTime
+	// Conveyors carry the player without changing its velocity
+	if p.OnGround {
+		deltaX += p.GroundMaterial.ConveyorSpeed * deltaTime
+	}
+	landingSpeed := p.VelocityY
 
 	// Check if moving horizontally
 	p.IsMoving = math.Abs(p.VelocityX) > 10.0
@@ -205,13 +298,18 @@ func (p *Player) updatePhysics(deltaTime float64) {
 	// Reset ground state
 	p.OnGround = false
 
-	// Use swept collision detection for more robust movement
-	finalX, finalY := p.performSweptMovement(prevX, prevY, deltaX, deltaY)
-	p.X = finalX
-	p.Y = finalY
+	// Use swept collision detection for more robust movement. One-way platforms can't
+	// catch the player while it drops through them.
+	p.IgnoreOneWay = p.DropThroughTimer > 0
+	p.MoveAndCollide(p.level, deltaX, deltaY)
+
+	// Keep feet on slope surfaces: step up going uphill and stay grounded going downhill
+	if p.VelocityY >= 0 {
+		p.followSlope(prevOnGround, deltaX)
+	}
 
 	// Final collision check to set ground state and handle any remaining issues
-	result := p.level.CheckCollision(p.X, p.Y, p.Width, p.Height)
+	result := p.checkCollision(p.level, p.X, p.Y)
 
 	// Update climbing state based on collision
 	if result.ClimbableSurface && !p.IsDamaged {
@@ -219,26 +317,13 @@ func (p *Player) updatePhysics(deltaTime float64) {
 		// The climbing mode is still controlled by input (C key for debug)
 	}
 
-	// Handle ground state (swept movement should have already set OnGround for most cases)
-	if result.OnGround && !p.OnGround {
-		p.OnGround = true
+	// Handle ground state, with hysteresis to reduce jitter
+	if p.updateGround(result, prevOnGround) {
 		p.IsJumping = false
-		if p.VelocityY > 0 {
-			p.VelocityY = 0
-		}
 	}
 
-	// Use hysteresis for ground state to reduce jitter, but only for very small movements
-	if !result.OnGround && !result.CollisionY {
-		// Only apply hysteresis if the player is essentially stationary both horizontally and vertically
-		// This prevents false ground detection when walking off platforms
-		if prevOnGround && math.Abs(p.VelocityY) < 0.5 && math.Abs(p.VelocityX) < 5.0 {
-			// Much stricter tolerance to prevent interference with edge walking
-			p.OnGround = true
-		} else {
-			p.OnGround = false
-		}
-	}
+	p.updateGroundMaterial(result, prevOnGround, landingSpeed)
+	p.updateSlopeState(result)
 
 	// Handle dangerous tiles
 	if result.DangerousTile && !p.IsDamaged {
@@ -251,8 +336,153 @@ func (p *Player) updatePhysics(deltaTime float64) {
 	}
 }
 
+// updateGroundMaterial records the surface under the player's feet and bounces the player
+// off bouncy surfaces it has landed on
+func (p *Player) updateGroundMaterial(result *collision.Result, wasOnGround bool, landingSpeed float64) {
+	if !p.OnGround {
+		p.GroundMaterial = collision.DefaultMaterial
+		return
+	}
+	if !result.OnGround {
+		return // Held on the ground by hysteresis, keep the last surface
+	}
+
+	p.GroundMaterial = result.Material
+	if p.GroundMaterial.Name == "" {
+		p.GroundMaterial = collision.DefaultMaterial
+	}
+
+	// Launch pads fire whenever stood on; bounciness only returns speed from a landing
+	bounceSpeed := p.GroundMaterial.LaunchSpeed
+	if !wasOnGround {
+		bounceSpeed = math.Max(bounceSpeed, landingSpeed*p.GroundMaterial.Bounciness)
+	}
+	if bounceSpeed >= MinBounceSpeed {
+		p.VelocityY = -bounceSpeed
+		p.OnGround = false
+		p.IsJumping = true
+	}
+}
+
+// horizontalRates returns the acceleration and deceleration for the player's current
+// state: air rates when airborne, ground rates scaled by the surface's friction otherwise
+func (p *Player) horizontalRates() (acceleration, deceleration float64) {
+	if !p.OnGround {
+		return p.AirAcceleration, p.AirDeceleration
+	}
+	friction := p.GroundMaterial.Friction
+	return p.GroundAcceleration * friction, p.GroundDeceleration * friction
+}
+
+// integrateHorizontal updates VelocityX for this frame's movement input and returns the
+// distance moved. Velocity brakes towards rest (or walking speed when over it) at the
+// deceleration rate, then speeds up towards walking speed at the acceleration rate.
+// Both phases change velocity linearly, so the distance is exact at any frame rate.
+func (p *Player) integrateHorizontal(deltaTime float64) float64 {
+	acceleration, deceleration := p.horizontalRates()
+	target := p.moveInput * p.Speed * p.GroundMaterial.SpeedScale
+
+	distance := 0.0
+	remaining := deltaTime
+
+	// Brake: no input, turning around, or faster than walking speed
+	if p.VelocityX != 0 && (target == 0 || target*p.VelocityX < 0 || math.Abs(p.VelocityX) > math.Abs(target)) {
+		brakeTarget := 0.0
+		if target*p.VelocityX > 0 {
+			brakeTarget = target
+		}
+		var moved, used float64
+		p.VelocityX, moved, used = approach(p.VelocityX, brakeTarget, deceleration, remaining)
+		distance += moved
+		remaining -= used
+	}
+
+	// Accelerate towards walking speed with whatever time is left, then hold it
+	if remaining > 0 {
+		var moved, used float64
+		p.VelocityX, moved, used = approach(p.VelocityX, target, acceleration, remaining)
+		distance += moved + p.VelocityX*(remaining-used)
+	}
+
+	return distance
+}
+
+// approach changes a velocity towards a target at a constant rate for up to duration
+// seconds. It returns the new velocity, the exact distance covered while changing and
+// the time that took, which is less than duration when the target is reached early.
+func approach(velocity, target, rate, duration float64) (newVelocity, distance, used float64) {
+	gap := target - velocity
+	if rate <= 0 {
+		return velocity, velocity * duration, duration
+	}
+
+	reachTime := math.Abs(gap) / rate
+	if reachTime >= duration {
+		newVelocity = velocity + math.Copysign(rate*duration, gap)
+		return newVelocity, (velocity + newVelocity) / 2 * duration, duration
+	}
+	return target, (velocity + target) / 2 * reachTime, reachTime
+}
+
+// followSlope moves the player onto the slope surface under its centre. Walking uphill
+// lifts the player out of the slope; when the player was grounded it is also pulled down
+// by as much as the slope can fall over this frame's horizontal movement.
+func (p *Player) followSlope(wasOnGround bool, deltaX float64) {
+	snap := 0.0
+	if wasOnGround {
+		// Slopes are at most 45°, so the surface can't drop more than the distance moved
+		snap = math.Abs(deltaX) + SlopeSnapTolerance
+	}
+
+	result := p.checkCollision(p.level, p.X, p.Y+snap)
+	if !result.OnSlope {
+		// Stepping off the bottom of a slope can leave the feet a fraction above flat ground
+		if wasOnGround && p.OnSlope && (result.OnGround || result.CollisionY) {
+			p.MoveAndCollide(p.level, 0, snap)
+		}
+		return
+	}
+
+	p.Y = result.SurfaceY - p.Height
+	p.OnGround = true
+	p.IsJumping = false
+	p.VelocityY = 0
+}
+
+// updateSlopeState records the slope under the player and whether it is too steep to walk on
+func (p *Player) updateSlopeState(result *collision.Result) {
+	p.OnSlope = p.OnGround && result.OnSlope
+	if !p.OnSlope {
+		p.SlopeGradient = 0
+		p.Sliding = false
+		return
+	}
+
+	p.SlopeGradient = result.SlopeGradient
+	angle := math.Atan(math.Abs(p.SlopeGradient)) * 180 / math.Pi
+	p.Sliding = angle > p.MaxSlopeAngle
+}
+
+// applySlide accelerates the player down a steep slope and returns the distance moved.
+// The player can't walk uphill while sliding, and the slide speed is capped at twice
+// the walking speed.
+func (p *Player) applySlide(deltaTime float64) float64 {
+	downhill := math.Copysign(1, p.SlopeGradient)
+	if p.VelocityX*downhill < 0 {
+		p.VelocityX = 0
+	}
+
+	// Gravity along the surface: g·sinθ, projected back onto X: g·sinθ·cosθ = g·m/(1+m²)
+	gradient := math.Abs(p.SlopeGradient)
+	slideAcceleration := p.Gravity * gradient / (1 + gradient*gradient)
+
+	velocity, distance, used := approach(p.VelocityX, downhill*p.Speed*2, slideAcceleration, deltaTime)
+	p.VelocityX = velocity
+	return distance + velocity*(deltaTime-used)
+}
+
 // handleCollisionResult processes collision results and updates player state
-func (p *Player) handleCollisionResult(result *CollisionResult, prevX, prevY float64) bool {
+func (p *Player) handleCollisionResult(result *collision.Result, prevX, prevY float64) bool {
 	if result == nil || !result.Collided {
 		p.OnGround = false
 		return false
@@ -297,44 +527,35 @@ func (p *Player) updateSimplePhysics(deltaTime, deltaX, deltaY float64) {
 
 // updateAnimationState determines which animation should be playing
 func (p *Player) updateAnimationState() {
-	if p.IsDamaged {
-		p.AnimationController.SetState(AnimationDamage)
-		return
-	}
+	p.AnimationMachine.Update()
 
-	if p.IsClimbing {
-		p.AnimationController.SetState(AnimationClimb)
-		return
-	}
-
-	if !p.OnGround {
-		if p.VelocityY < 0 {
-			p.AnimationController.SetState(AnimationJump)
-		} else {
-			p.AnimationController.SetState(AnimationFall)
-		}
-		return
+	if p.AnimationMachine.Current() == AnimationWalk {
+		p.AnimationController.SetSpeed(AnimationWalk, p.walkAnimationSpeed())
 	}
+}
 
-	if p.IsMoving {
-		p.AnimationController.SetState(AnimationWalk)
-	} else {
-		p.AnimationController.SetState(AnimationIdle)
+// walkAnimationSpeed scales the walk cycle with horizontal speed so the feet don't slide
+func (p *Player) walkAnimationSpeed() float64 {
+	if p.Speed <= 0 {
+		return 1.0
 	}
+	return math.Max(0.25, math.Abs(p.VelocityX)/p.Speed)
 }
 
-// MoveLeft makes the player move left
+// MoveLeft accelerates the player left during the next Update. Call it every frame the
+// input is held; without it the player decelerates to a stop.
 func (p *Player) MoveLeft() {
 	if !p.IsDamaged {
-		p.VelocityX = -p.Speed
+		p.moveInput = -1
 		p.FacingRight = false
 	}
 }
 
-// MoveRight makes the player move right
+// MoveRight accelerates the player right during the next Update. Call it every frame the
+// input is held; without it the player decelerates to a stop.
 func (p *Player) MoveRight() {
 	if !p.IsDamaged {
-		p.VelocityX = p.Speed
+		p.moveInput = 1
 		p.FacingRight = true
 	}
 }
@@ -345,13 +566,47 @@ func (p *Player) Jump() {
 	canJump := (p.OnGround || p.CoyoteTimer > 0) && !p.IsDamaged
 
 	if canJump {
-		p.VelocityY = -p.JumpSpeed
+		p.VelocityY = -p.JumpSpeed * p.GroundMaterial.JumpScale
 		p.IsJumping = true
 		p.OnGround = false
 		p.CoyoteTimer = 0 // Consume coyote time
 	}
 }
 
+// DropThrough drops the player through the one-way platform it is standing on and ignores
+// one-way platforms for DropThroughTime. It returns false, leaving the player unchanged,
+// when the player isn't standing on a one-way platform or solid ground is also underfoot.
+func (p *Player) DropThrough() bool {
+	if !p.OnGround || p.IsDamaged || p.IsClimbing || p.level == nil {
+		return false
+	}
+
+	if result := p.checkCollision(p.level, p.X, p.Y); !result.OneWayPlatform {
+		return false
+	}
+
+	// Anything still holding the player up once it is past the platform's top is solid
+	below := p.checkCollision(p.level, p.X, p.Y+DropThroughDepth)
+	if below.OnGround || below.CollisionX || below.CollisionY {
+		return false
+	}
+
+	p.Y += DropThroughDepth
+	p.OnGround = false
+	p.IsJumping = false
+	p.CoyoteTimer = 0
+	p.DropThroughTimer = p.DropThroughTime
+	if p.VelocityY < 0 {
+		p.VelocityY = 0
+	}
+	return true
+}
+
+// IsDroppingThrough returns whether one-way platforms are currently being ignored
+func (p *Player) IsDroppingThrough() bool {
+	return p.DropThroughTimer > 0
+}
+
 // StartClimbing puts the player in climbing mode
 func (p *Player) StartClimbing() {
 	if !p.IsDamaged {
@@ -390,14 +645,29 @@ func (p *Player) TakeDamage() {
 	}
 }
 
-// SetLevel sets the level for collision detection
-func (p *Player) SetLevel(level CollisionChecker) {
-	p.level = level
+// Kill kills the player, such as when a moving platform crushes it
+func (p *Player) Kill() {
+	p.IsDead = true
+	p.VelocityX = 0
+	p.VelocityY = 0
+}
+
+// Knockback throws the player with a velocity, such as away from an enemy that has hurt
+// it. It knocks the player off the ground and out of climbing.
+func (p *Player) Knockback(velocityX, velocityY float64) {
+	if p.IsClimbing {
+		p.StopClimbing()
+	}
+	p.VelocityX = velocityX
+	p.VelocityY = velocityY
+	p.OnGround = false
+	p.IsJumping = false
+	p.CoyoteTimer = 0
 }
 
-// GetBounds returns the player's collision rectangle
-func (p *Player) GetBounds() (float64, float64, float64, float64) {
-	return p.X, p.Y, p.Width, p.Height
+// SetLevel sets the level for collision detection
+func (p *Player) SetLevel(level collision.Checker) {
+	p.level = level
 }
 
 // Draw renders the player
@@ -430,37 +700,11 @@ func (p *Player) Draw(screen *ebiten.Image) {
 	screen.DrawImage(currentFrame, op)
 }
 
-// GetPosition returns the player's current position
-func (p *Player) GetPosition() (float64, float64) {
-	return p.X, p.Y
-}
-
-// SetPosition sets the player's position
-func (p *Player) SetPosition(x, y float64) {
-	p.X = x
-	p.Y = y
-}
-
-// GetVelocity returns the player's current velocity
-func (p *Player) GetVelocity() (float64, float64) {
-	return p.VelocityX, p.VelocityY
-}
-
 // GetCoyoteTimer returns the current coyote time remaining
 func (p *Player) GetCoyoteTimer() float64 {
 	return p.CoyoteTimer
 }
 
-// IsOnGround returns whether the player is currently on ground
-func (p *Player) IsOnGround() bool {
-	return p.OnGround
-}
-
-// GetVelocityY returns the player's Y velocity
-func (p *Player) GetVelocityY() float64 {
-	return p.VelocityY
-}
-
 // IsFacingRight returns whether the player is facing right
 func (p *Player) IsFacingRight() bool {
 	return p.FacingRight
@@ -471,175 +715,6 @@ func (p *Player) GetAnimationState() AnimationState {
 	return p.AnimationController.GetCurrentState()
 }
 
-// performSweptMovement performs swept collision detection to prevent tunneling
-// and ensure accurate collision response regardless of movement speed
-func (p *Player) performSweptMovement(startX, startY, deltaX, deltaY float64) (float64, float64) {
-	if p.level == nil {
-		return startX + deltaX, startY + deltaY
-	}
-
-	finalX := startX
-	finalY := startY
-
-	// Step 1: Handle horizontal movement with swept collision
-	if deltaX != 0 {
-		finalX = p.sweptHorizontalMovement(startX, startY, deltaX)
-	}
-
-	// Step 2: Handle vertical movement with swept collision
-	if deltaY != 0 {
-		finalY = p.sweptVerticalMovement(finalX, startY, deltaY)
-	}
-
-	return finalX, finalY
-}
-
-// sweptHorizontalMovement handles horizontal movement with collision detection
-func (p *Player) sweptHorizontalMovement(startX, y, deltaX float64) float64 {
-	targetX := startX + deltaX
-
-	// Check if the target position would cause collision
-	result := p.level.CheckCollision(targetX, y, p.Width, p.Height)
-	if !result.CollisionX {
-		// No collision, move to target position
-		return targetX
-	}
-
-	// Use binary search for precise collision point detection
-	return p.binarySearchCollisionX(startX, y, deltaX)
-}
-
-// binarySearchCollisionX uses binary search to find the exact collision point on X axis
-func (p *Player) binarySearchCollisionX(startX, y, deltaX float64) float64 {
-	left := startX
-	right := startX + deltaX
-
-	// Ensure left is valid, right causes collision
-	if deltaX > 0 {
-		// Moving right
-		for i := 0; i < BinarySearchMaxIterations; i++ {
-			mid := (left + right) / 2
-
-			if math.Abs(right-left) < BinarySearchTolerance {
-				p.VelocityX = 0
-				return left
-			}
-
-			result := p.level.CheckCollision(mid, y, p.Width, p.Height)
-			if result.CollisionX {
-				right = mid
-			} else {
-				left = mid
-			}
-		}
-	} else {
-		// Moving left
-		for i := 0; i < BinarySearchMaxIterations; i++ {
-			mid := (left + right) / 2
-
-			if math.Abs(right-left) < BinarySearchTolerance {
-				p.VelocityX = 0
-				return right
-			}
-
-			result := p.level.CheckCollision(mid, y, p.Width, p.Height)
-			if result.CollisionX {
-				left = mid
-			} else {
-				right = mid
-			}
-		}
-	}
-
-	p.VelocityX = 0
-	if deltaX > 0 {
-		return left
-	} else {
-		return right
-	}
-}
-
-// sweptVerticalMovement handles vertical movement with collision detection
-func (p *Player) sweptVerticalMovement(x, startY, deltaY float64) float64 {
-	targetY := startY + deltaY
-
-	// Check if the target position would cause collision
-	result := p.level.CheckCollision(x, targetY, p.Width, p.Height)
-	if !result.CollisionY && !result.OnGround {
-		// No collision, move to target position
-		return targetY
-	}
-
-	// Use binary search for precise collision point detection
-	return p.binarySearchCollisionY(x, startY, deltaY)
-}
-
-// binarySearchCollisionY uses binary search to find the exact collision point on Y axis
-func (p *Player) binarySearchCollisionY(x, startY, deltaY float64) float64 {
-	if deltaY > 0 { // Moving down (falling)
-		left := startY
-		right := startY + deltaY
-		bestValidY := startY
-
-		for i := 0; i < BinarySearchMaxIterationsY; i++ {
-			if math.Abs(right-left) < BinarySearchToleranceY {
-				break
-			}
-
-			mid := (left + right) / 2
-			result := p.level.CheckCollision(x, mid, p.Width, p.Height)
-
-			if result.CollisionY || result.OnGround {
-				right = mid
-			} else {
-				left = mid
-				bestValidY = mid
-			}
-		}
-
-		// Final position check and state setting
-		finalY := bestValidY
-
-		// Check for ground detection in a small range around the final position
-		for offset := 0.0; offset <= 1.0; offset += 0.1 {
-			testY := finalY + offset
-			testResult := p.level.CheckCollision(x, testY, p.Width, p.Height)
-			if testResult.OnGround || testResult.CollisionY {
-				if testResult.OnGround {
-					p.OnGround = true
-					p.IsJumping = false
-				}
-				p.VelocityY = 0
-				return testY
-			}
-		}
-
-		return finalY
-
-	} else { // Moving up (jumping)
-		left := startY + deltaY
-		right := startY
-
-		for i := 0; i < BinarySearchMaxIterationsY; i++ {
-			if math.Abs(right-left) < BinarySearchToleranceY {
-				break
-			}
-
-			mid := (left + right) / 2
-			result := p.level.CheckCollision(x, mid, p.Width, p.Height)
-
-			if result.CollisionY {
-				left = mid
-			} else {
-				right = mid
-			}
-		}
-
-		p.VelocityY = 0
-		return right
-	}
-}
-
 // GetDebugInfo returns debug information about the player's state
 func (p *Player) GetDebugInfo() string {
 	onGroundStr := "false"
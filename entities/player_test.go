@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"math"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -70,8 +71,9 @@ func TestPlayer_Movement(t *testing.T) {
 	img := ebiten.NewImage(320, 320)
 	player := NewPlayer(100, 200, img)
 
-	// Test left movement
+	// Test left movement: input accelerates the player on the next update
 	player.MoveLeft()
+	player.Update(1.0 / 60.0)
 	if player.VelocityX >= 0 {
 		t.Error("Player should have negative velocity when moving left")
 	}
@@ -80,7 +82,10 @@ func TestPlayer_Movement(t *testing.T) {
 	}
 
 	// Test right movement
-	player.MoveRight()
+	for i := 0; i < 30; i++ {
+		player.MoveRight()
+		player.Update(1.0 / 60.0)
+	}
 	if player.VelocityX <= 0 {
 		t.Error("Player should have positive velocity when moving right")
 	}
@@ -271,13 +276,40 @@ func TestPlayer_Physics(t *testing.T) {
 		t.Error("Gravity should increase Y velocity when not on ground")
 	}
 
-	// Test friction application when on ground
+	// Test deceleration when on ground with no input
 	player.OnGround = true
 	player.VelocityX = 100.0 // Set some X velocity
 	initialVelX := player.VelocityX
 	player.updatePhysics(deltaTime)
 
 	if player.VelocityX >= initialVelX {
-		t.Error("Friction should reduce X velocity when on ground")
+		t.Error("Deceleration should reduce X velocity when on ground")
+	}
+}
+
+func TestApproach(t *testing.T) {
+	tests := []struct {
+		name                   string
+		velocity, target, rate float64
+		duration               float64
+		wantVelocity           float64
+		wantDistance, wantUsed float64
+	}{
+		{"speeds up for the whole duration", 0, 120, 1200, 0.05, 60, 1.5, 0.05},
+		{"reaches the target early", 0, 120, 1200, 0.5, 120, 6, 0.1},
+		{"slows down to rest", -120, 0, 1200, 1, 0, -6, 0.1},
+		{"already at the target", 120, 120, 1200, 0.5, 120, 0, 0},
+		{"no rate holds the velocity", 50, 0, 0, 0.5, 50, 25, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			velocity, distance, used := approach(tt.velocity, tt.target, tt.rate, tt.duration)
+			if math.Abs(velocity-tt.wantVelocity) > 1e-9 || math.Abs(distance-tt.wantDistance) > 1e-9 || math.Abs(used-tt.wantUsed) > 1e-9 {
+				t.Errorf("approach(%v, %v, %v, %v) = (%v, %v, %v), want (%v, %v, %v)",
+					tt.velocity, tt.target, tt.rate, tt.duration, velocity, distance, used,
+					tt.wantVelocity, tt.wantDistance, tt.wantUsed)
+			}
+		})
 	}
 }
@@ -0,0 +1,46 @@
+package entities
+
+// Signals are the on/off values that switches, logic gates and doors pass to each other by
+// name. Each signal has one source, which sets it every frame, and any number of entities
+// wired to read it. A signal nothing has set is off.
+//
+// Sources and readers update in spawn order, so a reader spawned before its source sees a
+// change a frame late.
+type Signals struct {
+	values map[string]bool
+}
+
+// NewSignals creates a set of signals that are all off
+func NewSignals() *Signals {
+	return &Signals{values: make(map[string]bool)}
+}
+
+// Set turns a signal on or off
+func (s *Signals) Set(name string, on bool) {
+	s.values[name] = on
+}
+
+// IsOn returns whether a signal is on
+func (s *Signals) IsOn(name string) bool {
+	return s.values[name]
+}
+
+// AnyOn returns whether at least one of the signals is on
+func (s *Signals) AnyOn(names []string) bool {
+	for _, name := range names {
+		if s.values[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// AllOn returns whether every one of the signals is on. No signals are never all on.
+func (s *Signals) AllOn(names []string) bool {
+	for _, name := range names {
+		if !s.values[name] {
+			return false
+		}
+	}
+	return len(names) > 0
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "testing"
+
+func TestSignals_AnyAndAllOn(t *testing.T) {
+	signals := NewSignals()
+	signals.Set("a", true)
+	signals.Set("b", false)
+
+	tests := []struct {
+		name  string
+		names []string
+		any   bool
+		all   bool
+	}{
+		{"one on", []string{"a"}, true, true},
+		{"one of two on", []string{"a", "b"}, true, false},
+		{"none on", []string{"b", "never-set"}, false, false},
+		{"no signals", nil, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signals.AnyOn(tt.names); got != tt.any {
+				t.Errorf("Expected any on=%v, got %v", tt.any, got)
+			}
+			if got := signals.AllOn(tt.names); got != tt.all {
+				t.Errorf("Expected all on=%v, got %v", tt.all, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package entities
+
+import (
+	"fmt"
+	"image"
+	"strings"
+)
+
+// SpriteSheetIssueKind classifies a problem found while validating a sprite sheet
+type SpriteSheetIssueKind int
+
+const (
+	IssueInvalidLayout   SpriteSheetIssueKind = iota // The declared layout itself is inconsistent
+	IssueSheetTooSmall                               // The image is smaller than the declared grid
+	IssueSheetMisaligned                             // The image size is not a whole number of frames
+	IssueFrameOutOfRange                             // An animation references frames beyond the sheet
+	IssueEmptyFrame                                  // A frame used by an animation is fully transparent
+)
+
+// String returns a readable name for the issue kind
+func (k SpriteSheetIssueKind) String() string {
+	switch k {
+	case IssueInvalidLayout:
+		return "InvalidLayout"
+	case IssueSheetTooSmall:
+		return "SheetTooSmall"
+	case IssueSheetMisaligned:
+		return "SheetMisaligned"
+	case IssueFrameOutOfRange:
+		return "FrameOutOfRange"
+	case IssueEmptyFrame:
+		return "EmptyFrame"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(k))
+	}
+}
+
+// SpriteSheetIssue describes a single validation problem
+type SpriteSheetIssue struct {
+	Kind         SpriteSheetIssueKind
+	Animation    AnimationState // Animation the issue relates to
+	HasAnimation bool           // Whether Animation is set
+	Frame        int            // Sheet frame index the issue relates to, or -1
+	Message      string
+}
+
+// String formats the issue for logs and CLI output
+func (i SpriteSheetIssue) String() string {
+	var context []string
+	if i.HasAnimation {
+		context = append(context, "animation "+i.Animation.String())
+	}
+	if i.Frame >= 0 {
+		context = append(context, fmt.Sprintf("frame %d", i.Frame))
+	}
+	if len(context) == 0 {
+		return fmt.Sprintf("%s: %s", i.Kind, i.Message)
+	}
+	return fmt.Sprintf("%s (%s): %s", i.Kind, strings.Join(context, ", "), i.Message)
+}
+
+// SpriteSheetError is returned when a sprite sheet does not match its declared layout
+type SpriteSheetError struct {
+	Issues []SpriteSheetIssue
+}
+
+// Error implements the error interface
+func (e *SpriteSheetError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = issue.String()
+	}
+	return fmt.Sprintf("sprite sheet has %d issue(s):\n%s", len(e.Issues), strings.Join(lines, "\n"))
+}
+
+// SpriteAnimationLayout declares which frames of a sheet an animation occupies
+type SpriteAnimationLayout struct {
+	State      AnimationState
+	StartFrame int
+	FrameCount int
+}
+
+// SpriteSheetLayout declares the expected grid and animation ranges of a sprite sheet.
+// Frames are numbered left to right, top to bottom.
+type SpriteSheetLayout struct {
+	FrameWidth  int
+	FrameHeight int
+	Columns     int
+	Rows        int
+	Animations  []SpriteAnimationLayout
+}
+
+// FrameCount returns the number of frames in the declared grid
+func (l SpriteSheetLayout) FrameCount() int {
+	return l.Columns * l.Rows
+}
+
+// FrameRect returns the pixel rectangle of a frame within the sheet
+func (l SpriteSheetLayout) FrameRect(frame int) image.Rectangle {
+	if l.Columns <= 0 {
+		return image.Rectangle{}
+	}
+	x := (frame % l.Columns) * l.FrameWidth
+	y := (frame / l.Columns) * l.FrameHeight
+	return image.Rect(x, y, x+l.FrameWidth, y+l.FrameHeight)
+}
+
+// AnimationsForFrame returns every animation that uses the given frame
+func (l SpriteSheetLayout) AnimationsForFrame(frame int) []AnimationState {
+	var states []AnimationState
+	for _, anim := range l.Animations {
+		if frame >= anim.StartFrame && frame < anim.StartFrame+anim.FrameCount {
+			states = append(states, anim.State)
+		}
+	}
+	return states
+}
+
+// Robo9SpriteLayout returns the player sheet layout from docs/robo9-sprite-specification.md
+func Robo9SpriteLayout() SpriteSheetLayout {
+	return SpriteSheetLayout{
+		FrameWidth:  32,
+		FrameHeight: 32,
+		Columns:     6,
+		Rows:        3,
+		Animations: []SpriteAnimationLayout{
+			{State: AnimationIdle, StartFrame: 0, FrameCount: 4},
+			{State: AnimationWalk, StartFrame: 4, FrameCount: 4},
+			{State: AnimationJump, StartFrame: 8, FrameCount: 2},
+			{State: AnimationFall, StartFrame: 10, FrameCount: 2},
+			{State: AnimationClimb, StartFrame: 12, FrameCount: 4},
+			{State: AnimationDamage, StartFrame: 16, FrameCount: 2},
+			{State: AnimationJumpStart, StartFrame: 8, FrameCount: 1},
+			{State: AnimationLand, StartFrame: 8, FrameCount: 1},
+		},
+	}
+}
+
+// ValidateSpriteSheetSize checks a sheet's dimensions and the layout's animation ranges.
+// It needs no pixel access, so it is safe to use on ebiten images before the game loop starts.
+func ValidateSpriteSheetSize(width, height int, layout SpriteSheetLayout) error {
+	issues := validateLayout(width, height, layout)
+	if len(issues) > 0 {
+		return &SpriteSheetError{Issues: issues}
+	}
+	return nil
+}
+
+// ValidateSpriteSheet checks a decoded sheet against a layout, including looking
+// for fully transparent frames that an animation relies on
+func ValidateSpriteSheet(img image.Image, layout SpriteSheetLayout) error {
+	bounds := img.Bounds()
+	issues := validateLayout(bounds.Dx(), bounds.Dy(), layout)
+
+	// Pixel checks only make sense once the grid itself is sound
+	if len(issues) == 0 {
+		for frame := 0; frame < layout.FrameCount(); frame++ {
+			users := layout.AnimationsForFrame(frame)
+			if len(users) == 0 {
+				continue
+			}
+			rect := layout.FrameRect(frame).Add(bounds.Min)
+			if isTransparent(img, rect) {
+				issues = append(issues, SpriteSheetIssue{
+					Kind:         IssueEmptyFrame,
+					Animation:    users[0],
+					HasAnimation: true,
+					Frame:        frame,
+					Message:      "frame is fully transparent",
+				})
+			}
+		}
+	}
+
+	if len(issues) > 0 {
+		return &SpriteSheetError{Issues: issues}
+	}
+	return nil
+}
+
+// validateLayout returns the size and range issues for a sheet of the given dimensions
+func validateLayout(width, height int, layout SpriteSheetLayout) []SpriteSheetIssue {
+	var issues []SpriteSheetIssue
+
+	if layout.FrameWidth <= 0 || layout.FrameHeight <= 0 || layout.Columns <= 0 || layout.Rows <= 0 {
+		return append(issues, SpriteSheetIssue{
+			Kind:    IssueInvalidLayout,
+			Frame:   -1,
+			Message: fmt.Sprintf("frame size %dx%d and grid %dx%d must all be positive", layout.FrameWidth, layout.FrameHeight, layout.Columns, layout.Rows),
+		})
+	}
+
+	expectedWidth := layout.Columns * layout.FrameWidth
+	expectedHeight := layout.Rows * layout.FrameHeight
+	if width < expectedWidth || height < expectedHeight {
+		issues = append(issues, SpriteSheetIssue{
+			Kind:    IssueSheetTooSmall,
+			Frame:   -1,
+			Message: fmt.Sprintf("sheet is %dx%d, expected at least %dx%d", width, height, expectedWidth, expectedHeight),
+		})
+	}
+	if width%layout.FrameWidth != 0 || height%layout.FrameHeight != 0 {
+		issues = append(issues, SpriteSheetIssue{
+			Kind:    IssueSheetMisaligned,
+			Frame:   -1,
+			Message: fmt.Sprintf("sheet is %dx%d, not a multiple of the %dx%d frame size", width, height, layout.FrameWidth, layout.FrameHeight),
+		})
+	}
+
+	// Frames that actually fit on the image, which may be fewer than the declared grid
+	availableColumns := min(width/layout.FrameWidth, layout.Columns)
+	availableRows := min(height/layout.FrameHeight, layout.Rows)
+
+	for _, anim := range layout.Animations {
+		if anim.FrameCount <= 0 || anim.StartFrame < 0 {
+			issues = append(issues, SpriteSheetIssue{
+				Kind:         IssueInvalidLayout,
+				Animation:    anim.State,
+				HasAnimation: true,
+				Frame:        -1,
+				Message:      fmt.Sprintf("start frame %d and frame count %d are invalid", anim.StartFrame, anim.FrameCount),
+			})
+			continue
+		}
+
+		for frame := anim.StartFrame; frame < anim.StartFrame+anim.FrameCount; frame++ {
+			column := frame % layout.Columns
+			row := frame / layout.Columns
+			if frame >= layout.FrameCount() || column >= availableColumns || row >= availableRows {
+				issues = append(issues, SpriteSheetIssue{
+					Kind:         IssueFrameOutOfRange,
+					Animation:    anim.State,
+					HasAnimation: true,
+					Frame:        frame,
+					Message:      fmt.Sprintf("frame is outside the %dx%d sheet", width, height),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// isTransparent reports whether every pixel in the rectangle has zero alpha
+func isTransparent(img image.Image, rect image.Rectangle) bool {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newOpaqueSheet creates a decoded-style sheet with every pixel filled
+func newOpaqueSheet(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{100, 149, 237, 255})
+		}
+	}
+	return img
+}
+
+// issueKinds extracts the issue kinds from a validation error
+func issueKinds(t *testing.T, err error) []SpriteSheetIssueKind {
+	t.Helper()
+	var sheetErr *SpriteSheetError
+	if !errors.As(err, &sheetErr) {
+		t.Fatalf("Expected *SpriteSheetError, got %v", err)
+	}
+	kinds := make([]SpriteSheetIssueKind, len(sheetErr.Issues))
+	for i, issue := range sheetErr.Issues {
+		kinds[i] = issue.Kind
+	}
+	return kinds
+}
+
+func TestValidateSpriteSheet_Valid(t *testing.T) {
+	if err := ValidateSpriteSheet(newOpaqueSheet(192, 96), Robo9SpriteLayout()); err != nil {
+		t.Errorf("Expected spec-sized sheet to validate, got %v", err)
+	}
+}
+
+func TestValidateSpriteSheet_TooSmall(t *testing.T) {
+	// One row short: climb and damage frames are missing
+	err := ValidateSpriteSheet(newOpaqueSheet(192, 64), Robo9SpriteLayout())
+	kinds := issueKinds(t, err)
+
+	if kinds[0] != IssueSheetTooSmall {
+		t.Errorf("Expected first issue SheetTooSmall, got %v", kinds[0])
+	}
+
+	var sheetErr *SpriteSheetError
+	errors.As(err, &sheetErr)
+	outOfRange := map[AnimationState]int{}
+	for _, issue := range sheetErr.Issues {
+		if issue.Kind == IssueFrameOutOfRange {
+			outOfRange[issue.Animation]++
+		}
+	}
+	if outOfRange[AnimationClimb] != 4 || outOfRange[AnimationDamage] != 2 {
+		t.Errorf("Expected 4 climb and 2 damage frames out of range, got %v", outOfRange)
+	}
+	if outOfRange[AnimationIdle] != 0 {
+		t.Errorf("Idle frames fit on the sheet and should not be reported, got %d", outOfRange[AnimationIdle])
+	}
+}
+
+func TestValidateSpriteSheet_Misaligned(t *testing.T) {
+	kinds := issueKinds(t, ValidateSpriteSheet(newOpaqueSheet(200, 96), Robo9SpriteLayout()))
+	if len(kinds) != 1 || kinds[0] != IssueSheetMisaligned {
+		t.Errorf("Expected a single SheetMisaligned issue, got %v", kinds)
+	}
+}
+
+func TestValidateSpriteSheet_LayoutOutOfRange(t *testing.T) {
+	layout := Robo9SpriteLayout()
+	layout.Animations = append(layout.Animations, SpriteAnimationLayout{State: AnimationWalk, StartFrame: 17, FrameCount: 2})
+
+	kinds := issueKinds(t, ValidateSpriteSheet(newOpaqueSheet(192, 96), layout))
+	if len(kinds) != 1 || kinds[0] != IssueFrameOutOfRange {
+		t.Errorf("Expected frame 18 to be reported out of range, got %v", kinds)
+	}
+}
+
+func TestValidateSpriteSheet_EmptyFrame(t *testing.T) {
+	img := newOpaqueSheet(192, 96)
+
+	// Clear frame 9 (second jump frame)
+	rect := Robo9SpriteLayout().FrameRect(9)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.Set(x, y, color.RGBA{})
+		}
+	}
+
+	var sheetErr *SpriteSheetError
+	if !errors.As(ValidateSpriteSheet(img, Robo9SpriteLayout()), &sheetErr) {
+		t.Fatal("Expected empty frame to be reported")
+	}
+	issue := sheetErr.Issues[0]
+	if issue.Kind != IssueEmptyFrame || issue.Frame != 9 || issue.Animation != AnimationJump {
+		t.Errorf("Expected EmptyFrame on frame 9 of Jump, got %v", issue)
+	}
+}
+
+func TestValidateSpriteSheet_InvalidLayout(t *testing.T) {
+	kinds := issueKinds(t, ValidateSpriteSheetSize(192, 96, SpriteSheetLayout{}))
+	if len(kinds) != 1 || kinds[0] != IssueInvalidLayout {
+		t.Errorf("Expected InvalidLayout for an empty layout, got %v", kinds)
+	}
+}
+
+func TestAnimationController_ValidateLayout(t *testing.T) {
+	controller := NewAnimationController(CreateTestSpriteSheet(), 32, 32)
+	if err := controller.ValidateLayout(Robo9SpriteLayout()); err != nil {
+		t.Errorf("Test sprite sheet should match the ROBO-9 layout, got %v", err)
+	}
+
+	tiny := NewAnimationController(ebiten.NewImage(1, 1), 32, 32)
+	if err := tiny.ValidateLayout(Robo9SpriteLayout()); err == nil {
+		t.Error("1x1 sheet should fail validation")
+	}
+}
+
+func TestRobo9SpriteLayout_MatchesPlayerAnimations(t *testing.T) {
+	player := NewPlayer(0, 0, CreateTestSpriteSheet())
+
+	for _, anim := range Robo9SpriteLayout().Animations {
+		registered := player.AnimationController.GetAnimation(anim.State)
+		if registered == nil {
+			t.Errorf("Player has no animation for %v", anim.State)
+			continue
+		}
+		if registered.FrameCount != anim.FrameCount {
+			t.Errorf("%v: layout declares %d frames, player uses %d", anim.State, anim.FrameCount, registered.FrameCount)
+		}
+		if registered.FrameTime <= 0 {
+			t.Errorf("%v: player has no frame time for it", anim.State)
+		}
+	}
+}
+
+func TestRenderContactSheet(t *testing.T) {
+	layout := Robo9SpriteLayout()
+	sheet := RenderContactSheet(newOpaqueSheet(192, 96), layout, "player.png")
+
+	bounds := sheet.Bounds()
+	if bounds.Dx() < layout.Columns*layout.FrameWidth*contactSheetScale {
+		t.Errorf("Contact sheet too narrow for scaled frames: %d", bounds.Dx())
+	}
+	if bounds.Dy() < layout.Rows*layout.FrameHeight*contactSheetScale {
+		t.Errorf("Contact sheet too short for scaled frames: %d", bounds.Dy())
+	}
+
+	// Undersized art must still render rather than panic
+	RenderContactSheet(newOpaqueSheet(40, 20), layout, "broken.png")
+}
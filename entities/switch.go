@@ -0,0 +1,185 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+// Tags of switches
+const (
+	TagSwitch = "switch"
+)
+
+// Switch sizes
+const (
+	PlateHeight = 6.0 // Height of a pressure plate placed as a point, lying on the floor
+
+	plateRaise  = 3.0 // How far a plate nobody is standing on sticks up from its frame (px)
+	leverLength = 0.6 // Length of a lever's handle as a share of its height
+	leverAngle  = 0.5 // Angle a lever's handle leans either side of upright (radians)
+)
+
+// Switch colours: red while off and green while on
+var (
+	switchFrameColour = color.RGBA{70, 70, 80, 255}
+	switchOffColour   = color.RGBA{200, 60, 50, 255}
+	switchOnColour    = color.RGBA{80, 210, 90, 255}
+)
+
+// PressurePlate sends its signal while the player is standing on it. It lies on the floor
+// without blocking anything, so standing on it means overlapping it.
+type PressurePlate struct {
+	// Where the plate lies
+	collision.Rect
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	Signal string // Name of the signal the plate sends
+
+	signals *Signals
+	pressed bool
+}
+
+// NewPressurePlate creates a plate that sends a signal while the player stands on it
+func NewPressurePlate(signals *Signals, signal string, x, y, width, height float64) *PressurePlate {
+	return &PressurePlate{
+		Rect:       collision.Rect{X: x, Y: y, Width: width, Height: height},
+		EntityBase: NewEntityBase(TagSwitch),
+		Signal:     signal,
+		signals:    signals,
+	}
+}
+
+// IsPressed returns whether the player is standing on the plate
+func (p *PressurePlate) IsPressed() bool {
+	return p.pressed
+}
+
+// Update presses the plate while the player overlaps it and sends its signal
+func (p *PressurePlate) Update(deltaTime float64) {
+	player := p.findPlayer()
+	p.pressed = player != nil && !player.IsDead && p.Overlaps(playerRect(player))
+	p.signals.Set(p.Signal, p.pressed)
+}
+
+// GetBounds returns the area the player presses
+func (p *PressurePlate) GetBounds() (float64, float64, float64, float64) {
+	return p.X, p.Y, p.Width, p.Height
+}
+
+// Draw renders the plate in its frame, sticking up and red until pressed flat and green
+func (p *PressurePlate) Draw(screen *ebiten.Image) {
+	x, y := math.Round(p.X), math.Round(p.Y)
+
+	frame := &ebiten.DrawImageOptions{}
+	frame.GeoM.Scale(p.Width, p.Height)
+	frame.GeoM.Translate(x, y)
+	frame.ColorScale.ScaleWithColor(switchFrameColour)
+	screen.DrawImage(whitePixel(), frame)
+
+	colour, raise := switchOffColour, plateRaise
+	if p.pressed {
+		colour, raise = switchOnColour, 0
+	}
+	top := &ebiten.DrawImageOptions{}
+	top.GeoM.Scale(math.Max(p.Width-4, 0), math.Max(p.Height/2, 1))
+	top.GeoM.Translate(x+2, y-raise)
+	top.ColorScale.ScaleWithColor(colour)
+	screen.DrawImage(whitePixel(), top)
+}
+
+// Lever sends its signal while pulled on. The player pulls it either way with the interact
+// action, and it stays where it was left.
+type Lever struct {
+	// Where the lever stands
+	collision.Rect
+
+	// ID, tags and draw order in the world
+	EntityBase
+
+	Signal string // Name of the signal the lever sends
+
+	signals *Signals
+	on      bool
+}
+
+// NewLever creates a lever that is off
+func NewLever(signals *Signals, signal string, x, y, width, height float64) *Lever {
+	return &Lever{
+		Rect:       collision.Rect{X: x, Y: y, Width: width, Height: height},
+		EntityBase: NewEntityBase(TagSwitch),
+		Signal:     signal,
+		signals:    signals,
+	}
+}
+
+// IsOn returns whether the lever is pulled on
+func (l *Lever) IsOn() bool {
+	return l.on
+}
+
+// SetOn pulls the lever on or off
+func (l *Lever) SetOn(on bool) {
+	l.on = on
+}
+
+// CanInteract returns whether the player is in reach of the lever
+func (l *Lever) CanInteract(player *Player) bool {
+	return !player.IsDead && withinReach(l, player, InteractRange)
+}
+
+// Interact pulls the lever the other way
+func (l *Lever) Interact(player *Player) bool {
+	if !l.CanInteract(player) {
+		return false
+	}
+	l.on = !l.on
+	return true
+}
+
+// Update sends the lever's signal
+func (l *Lever) Update(deltaTime float64) {
+	l.signals.Set(l.Signal, l.on)
+}
+
+// GetBounds returns the area the lever stands in
+func (l *Lever) GetBounds() (float64, float64, float64, float64) {
+	return l.X, l.Y, l.Width, l.Height
+}
+
+// Draw renders the lever as a handle on a base, leaning left while off and right while on
+func (l *Lever) Draw(screen *ebiten.Image) {
+	x, y := math.Round(l.X), math.Round(l.Y)
+	baseHeight := math.Max(l.Height/4, 2)
+	pivotX, pivotY := x+l.Width/2, y+l.Height-baseHeight
+
+	colour, angle := switchOffColour, -leverAngle
+	if l.on {
+		colour, angle = switchOnColour, leverAngle
+	}
+	length := l.Height * leverLength
+	handle := &ebiten.DrawImageOptions{}
+	handle.GeoM.Scale(3, length)
+	handle.GeoM.Translate(-1.5, -length)
+	handle.GeoM.Rotate(angle)
+	handle.GeoM.Translate(pivotX, pivotY)
+	handle.ColorScale.ScaleWithColor(colour)
+	screen.DrawImage(whitePixel(), handle)
+
+	base := &ebiten.DrawImageOptions{}
+	base.GeoM.Scale(l.Width/2, baseHeight)
+	base.GeoM.Translate(x+l.Width/4, pivotY)
+	base.ColorScale.ScaleWithColor(switchFrameColour)
+	screen.DrawImage(whitePixel(), base)
+}
+
+// playerRect returns the area the player covers
+func playerRect(player *Player) collision.Rect {
+	x, y, width, height := player.GetBounds()
+	return collision.Rect{X: x, Y: y, Width: width, Height: height}
+}
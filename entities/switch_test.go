@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestPressurePlate_PressedWhileStoodOn(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y float64
+		dead bool
+		want bool
+	}{
+		{"standing on it", 100, 368, false, true},
+		{"on its edge", 75, 368, false, true},
+		{"beside it", 140, 368, false, false},
+		{"jumping over it", 100, 300, false, false},
+		{"dead on it", 100, 368, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signals := NewSignals()
+			world := NewWorld(nil)
+			player := NewPlayer(tt.x, tt.y, CreateTestSpriteSheet())
+			player.SetLevel(&floorChecker{floorY: tt.y + 32, wallX: math.Inf(1)})
+			player.OnGround = true
+			player.IsDead = tt.dead
+			plate := NewPressurePlate(signals, "plate", 100, 400-PlateHeight, 32, PlateHeight)
+			world.Spawn(player)
+			world.Spawn(plate)
+
+			world.Update(1.0 / 60.0)
+			if plate.IsPressed() != tt.want || signals.IsOn("plate") != tt.want {
+				t.Errorf("Expected pressed=%v, got %v with signal %v", tt.want, plate.IsPressed(), signals.IsOn("plate"))
+			}
+			plate.Draw(ebiten.NewImage(200, 400))
+		})
+	}
+}
+
+func TestPressurePlate_ReleasedWhenSteppedOff(t *testing.T) {
+	signals := NewSignals()
+	world := NewWorld(nil)
+	player := NewPlayer(100, 368, CreateTestSpriteSheet())
+	player.SetLevel(&floorChecker{floorY: 400, wallX: math.Inf(1)})
+	player.OnGround = true
+	world.Spawn(player)
+	world.Spawn(NewPressurePlate(signals, "plate", 100, 400-PlateHeight, 32, PlateHeight))
+
+	world.Update(1.0 / 60.0)
+	if !signals.IsOn("plate") {
+		t.Fatal("Expected the plate pressed while the player stands on it")
+	}
+	player.SetPosition(300, 368)
+	world.Update(1.0 / 60.0)
+	if signals.IsOn("plate") {
+		t.Error("Expected the plate released once the player stepped off")
+	}
+}
+
+func TestLever_PulledWithInteract(t *testing.T) {
+	signals := NewSignals()
+	world := NewWorld(nil)
+	player := NewPlayer(300, 368, CreateTestSpriteSheet())
+	player.SetLevel(&floorChecker{floorY: 400, wallX: math.Inf(1)})
+	player.OnGround = true
+	lever := NewLever(signals, "lever", 100, 368, 32, 32)
+	world.Spawn(player)
+	world.Spawn(lever)
+
+	world.Update(1.0 / 60.0)
+	if player.Interact() || lever.IsOn() || signals.IsOn("lever") {
+		t.Fatal("Expected the lever out of reach to stay off")
+	}
+
+	// Pulled on, it stays on until pulled back
+	player.SetPosition(lever.X+lever.Width+InteractRange-4, 368)
+	if !player.Interact() || !lever.IsOn() {
+		t.Fatal("Expected the player in reach to pull the lever on")
+	}
+	for range 60 {
+		world.Update(1.0 / 60.0)
+	}
+	if !signals.IsOn("lever") {
+		t.Fatal("Expected the lever to keep sending its signal")
+	}
+	lever.Draw(ebiten.NewImage(200, 400))
+
+	if !player.Interact() || lever.IsOn() {
+		t.Fatal("Expected pulling the lever again to turn it off")
+	}
+	world.Update(1.0 / 60.0)
+	if signals.IsOn("lever") {
+		t.Error("Expected the lever's signal off once pulled back")
+	}
+	lever.Draw(ebiten.NewImage(200, 400))
+}
@@ -0,0 +1,293 @@
+package entities
+
+import (
+	"cmp"
+	"slices"
+
+	"ebiten-platformer/collision"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// World owns the entities in a level. It updates them in spawn order, draws them by layer
+// and Z, and finds them by ID and tag.
+//
+// Entities may be spawned and despawned at any time, including from another entity's
+// Update. Entities spawned during Update join the world once the update finishes, so they
+// are first updated next frame. Despawned entities stop being updated, drawn and found at
+// once, and are dropped at the end of the update.
+//
+// A world with a spatial hash also tracks entity bounds after every update and tells
+// entities implementing ContactListener when they start and stop touching each other.
+type World struct {
+	entities []Entity            // Spawn order
+	byID     map[EntityID]Entity // Every entity in the world, including pending and despawned ones
+	pending  []Entity            // Spawned during Update
+	departed []EntityID          // Dropped last update, kept in byID until their contacts have ended
+	nextID   EntityID
+	updating bool
+	live     int // Entities that haven't been despawned
+
+	drawOrder []Entity               // Reused by Draw
+	contacts  *collision.SpatialHash // Broadphase, nil for none
+	found     []collision.ID         // Reused by QueryRegion
+}
+
+// NewWorld creates an empty world. A spatial hash, usually level.NewSpatialHash(), enables
+// contacts and region queries; pass nil for a world without them.
+func NewWorld(contacts *collision.SpatialHash) *World {
+	w := &World{
+		byID:     make(map[EntityID]Entity),
+		contacts: contacts,
+	}
+	if contacts != nil {
+		contacts.OnEnter = func(a, b collision.ID) { w.dispatchContact(a, b, true) }
+		contacts.OnExit = func(a, b collision.ID) { w.dispatchContact(a, b, false) }
+	}
+	return w
+}
+
+// Spawn adds an entity to the world and returns its new ID. An entity can only be in one
+// world at a time; spawning it again before it has been dropped keeps its ID.
+func (w *World) Spawn(e Entity) EntityID {
+	base := e.entityBase()
+	if base.world == w {
+		if base.removed {
+			base.removed = false
+			w.live++
+		}
+		return base.id
+	}
+
+	w.nextID++
+	base.id = w.nextID
+	base.world = w
+	base.removed = false
+	w.byID[base.id] = e
+	w.live++
+
+	if w.updating {
+		w.pending = append(w.pending, e)
+	} else {
+		w.add(e)
+	}
+	return base.id
+}
+
+// Despawn removes an entity from the world. It is skipped from then on and dropped at the
+// end of the next update.
+func (w *World) Despawn(e Entity) {
+	base := e.entityBase()
+	if base.world != w || base.removed {
+		return
+	}
+	base.removed = true
+	w.live--
+}
+
+// DespawnID removes the entity with the given ID from the world
+func (w *World) DespawnID(id EntityID) {
+	if e, ok := w.byID[id]; ok {
+		w.Despawn(e)
+	}
+}
+
+// Get returns the live entity with the given ID
+func (w *World) Get(id EntityID) (Entity, bool) {
+	e, ok := w.byID[id]
+	if !ok || e.entityBase().removed {
+		return nil, false
+	}
+	return e, true
+}
+
+// Len returns the number of live entities, including ones waiting to join
+func (w *World) Len() int {
+	return w.live
+}
+
+// Entities appends every live entity that has joined the world to result, in spawn order
+func (w *World) Entities(result []Entity) []Entity {
+	for _, e := range w.entities {
+		if !e.entityBase().removed {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// WithTag appends every live entity with a tag to result, in spawn order
+func (w *World) WithTag(tag string, result []Entity) []Entity {
+	for _, e := range w.entities {
+		if base := e.entityBase(); !base.removed && base.HasTag(tag) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// FirstWithTag returns the first live entity spawned with a tag, or nil
+func (w *World) FirstWithTag(tag string) Entity {
+	for _, e := range w.entities {
+		if base := e.entityBase(); !base.removed && base.HasTag(tag) {
+			return e
+		}
+	}
+	return nil
+}
+
+// QueryRegion appends every live entity overlapping a rectangle to result. Worlds with a
+// spatial hash use the bounds from the end of the last update.
+func (w *World) QueryRegion(region collision.Rect, result []Entity) []Entity {
+	if w.contacts == nil {
+		for _, e := range w.entities {
+			if !e.entityBase().removed && region.Overlaps(entityRect(e)) {
+				result = append(result, e)
+			}
+		}
+		return result
+	}
+
+	w.found = w.contacts.QueryRegion(region, w.found[:0])
+	for _, id := range w.found {
+		if e, ok := w.Get(id); ok {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Update updates every live entity in spawn order, then adds entities spawned meanwhile,
+// reports contacts and drops despawned entities
+func (w *World) Update(deltaTime float64) {
+	w.updating = true
+	for _, e := range w.entities {
+		if !e.entityBase().removed {
+			e.Update(deltaTime)
+		}
+	}
+	w.updating = false
+	w.addPending()
+
+	if w.contacts != nil {
+		for _, e := range w.entities {
+			if base := e.entityBase(); base.removed {
+				w.contacts.Remove(base.id)
+			} else {
+				w.contacts.Move(base.id, entityRect(e))
+			}
+		}
+		w.updating = true
+		w.contacts.UpdateContacts()
+		w.updating = false
+		w.addPending()
+	}
+	w.dropDespawned()
+}
+
+// Draw draws every live entity, lowest layer first. Entities with the same layer and Z
+// are drawn in spawn order.
+func (w *World) Draw(screen *ebiten.Image) {
+	w.drawOrder = w.Entities(w.drawOrder[:0])
+	slices.SortStableFunc(w.drawOrder, func(a, b Entity) int {
+		baseA, baseB := a.entityBase(), b.entityBase()
+		if c := cmp.Compare(baseA.Layer, baseB.Layer); c != 0 {
+			return c
+		}
+		return cmp.Compare(baseA.Z, baseB.Z)
+	})
+	for _, e := range w.drawOrder {
+		e.Draw(screen)
+	}
+	clear(w.drawOrder)
+}
+
+// Clear removes every entity at once, without reporting contacts, for unloading a level.
+// It must not be called from an entity's Update.
+func (w *World) Clear() {
+	for _, e := range w.entities {
+		w.Despawn(e)
+	}
+	for _, e := range w.pending {
+		w.Despawn(e)
+	}
+	w.addPending()
+	w.dropDespawned()
+	w.dropDespawned() // Forget the departed too, their contacts won't be reported
+}
+
+// addPending adds entities spawned during Update, forgetting ones already despawned
+func (w *World) addPending() {
+	for _, e := range w.pending {
+		if base := e.entityBase(); base.removed {
+			delete(w.byID, base.id)
+			base.world = nil
+		} else {
+			w.add(e)
+		}
+	}
+	clear(w.pending)
+	w.pending = w.pending[:0]
+}
+
+// add puts a spawned entity into the update order and spatial hash
+func (w *World) add(e Entity) {
+	w.entities = append(w.entities, e)
+	if w.contacts != nil {
+		w.contacts.Insert(e.ID(), entityRect(e))
+	}
+}
+
+// dropDespawned takes despawned entities out of the world. Entities dropped by the previous
+// call are forgotten; this call's stay resolvable by ID until the next update has reported
+// the end of their contacts.
+func (w *World) dropDespawned() {
+	for _, id := range w.departed {
+		delete(w.byID, id)
+	}
+	w.departed = w.departed[:0]
+
+	kept := w.entities[:0]
+	for _, e := range w.entities {
+		base := e.entityBase()
+		if !base.removed {
+			kept = append(kept, e)
+			continue
+		}
+		if w.contacts != nil {
+			w.contacts.Remove(base.id)
+		}
+		base.world = nil
+		w.departed = append(w.departed, base.id)
+	}
+	clear(w.entities[len(kept):])
+	w.entities = kept
+}
+
+// dispatchContact tells the live entities of a contact about each other
+func (w *World) dispatchContact(a, b collision.ID, entered bool) {
+	entityA, okA := w.byID[a]
+	entityB, okB := w.byID[b]
+	if !okA || !okB {
+		return
+	}
+	notify := func(e, other Entity) {
+		listener, ok := e.(ContactListener)
+		if !ok || e.entityBase().removed {
+			return
+		}
+		if entered {
+			listener.OnContactEnter(other)
+		} else {
+			listener.OnContactExit(other)
+		}
+	}
+	notify(entityA, entityB)
+	notify(entityB, entityA)
+}
+
+// entityRect returns an entity's bounds as a rectangle
+func entityRect(e Entity) collision.Rect {
+	x, y, width, height := e.GetBounds()
+	return collision.Rect{X: x, Y: y, Width: width, Height: height}
+}
@@ -0,0 +1,291 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"ebiten-platformer/collision"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testEntity is a box that records what the world does to it
+type testEntity struct {
+	EntityBase
+	name          string
+	x, y          float64
+	log           *[]string           // Shared record of updates, draws and contacts
+	onUpdate      func(e *testEntity) // Optional behaviour run during Update
+	onContact     func(e *testEntity, other Entity)
+	touching      []string
+	contactsEnded []string
+}
+
+func newTestEntity(name string, x, y float64, log *[]string, tags ...string) *testEntity {
+	return &testEntity{EntityBase: NewEntityBase(tags...), name: name, x: x, y: y, log: log}
+}
+
+func (e *testEntity) Update(deltaTime float64) {
+	*e.log = append(*e.log, "update "+e.name)
+	if e.onUpdate != nil {
+		e.onUpdate(e)
+	}
+}
+
+func (e *testEntity) Draw(screen *ebiten.Image) {
+	*e.log = append(*e.log, "draw "+e.name)
+}
+
+func (e *testEntity) GetBounds() (float64, float64, float64, float64) {
+	return e.x, e.y, 16, 16
+}
+
+func (e *testEntity) OnContactEnter(other Entity) {
+	e.touching = append(e.touching, entityName(other))
+	if e.onContact != nil {
+		e.onContact(e, other)
+	}
+}
+
+func (e *testEntity) OnContactExit(other Entity) {
+	e.contactsEnded = append(e.contactsEnded, entityName(other))
+}
+
+// entityName returns a test entity's name, or the type of any other entity
+func entityName(e Entity) string {
+	if named, ok := e.(*testEntity); ok {
+		return named.name
+	}
+	return fmt.Sprintf("%T", e)
+}
+
+func TestWorld_SpawnAndGet(t *testing.T) {
+	var log []string
+	world := NewWorld(nil)
+	a := newTestEntity("a", 0, 0, &log)
+	b := newTestEntity("b", 0, 0, &log)
+
+	idA, idB := world.Spawn(a), world.Spawn(b)
+	if idA == 0 || idA == idB || a.ID() != idA {
+		t.Fatalf("Expected distinct non-zero IDs, got %d and %d", idA, idB)
+	}
+	if world.Spawn(a) != idA || world.Len() != 2 {
+		t.Errorf("Spawning an entity twice should keep its ID, got %d entities", world.Len())
+	}
+	if got, ok := world.Get(idB); !ok || got != b {
+		t.Errorf("Expected to find b by ID")
+	}
+	if !a.IsAlive() || a.World() != world {
+		t.Error("Expected a spawned entity to be alive in the world")
+	}
+}
+
+func TestWorld_SpawnAndDespawnDuringUpdate(t *testing.T) {
+	var log []string
+	world := NewWorld(nil)
+	spawner := newTestEntity("spawner", 0, 0, &log)
+	victim := newTestEntity("victim", 0, 0, &log)
+	child := newTestEntity("child", 0, 0, &log)
+	spawner.onUpdate = func(e *testEntity) {
+		if !child.IsAlive() {
+			world.Spawn(child)
+			world.Despawn(victim)
+		}
+	}
+	world.Spawn(spawner)
+	world.Spawn(victim)
+
+	world.Update(1.0 / 60.0)
+	if want := []string{"update spawner"}; !slices.Equal(log, want) {
+		t.Errorf("Expected only the spawner to update on the first frame, got %v", log)
+	}
+	if world.Len() != 2 || !child.IsAlive() || victim.IsAlive() {
+		t.Errorf("Expected the child to join and the victim to go, got %d entities", world.Len())
+	}
+	if _, ok := world.Get(victim.ID()); ok {
+		t.Error("Expected the victim not to be found after despawning")
+	}
+
+	log = nil
+	world.Update(1.0 / 60.0)
+	if want := []string{"update spawner", "update child"}; !slices.Equal(log, want) {
+		t.Errorf("Expected the child to update on the next frame, got %v", log)
+	}
+}
+
+func TestWorld_DespawnSelf(t *testing.T) {
+	var log []string
+	world := NewWorld(nil)
+	e := newTestEntity("e", 0, 0, &log)
+	e.onUpdate = func(e *testEntity) { world.Despawn(e) }
+	world.Spawn(e)
+
+	world.Update(1.0 / 60.0)
+	world.Update(1.0 / 60.0)
+	if len(log) != 1 || world.Len() != 0 || e.World() != nil {
+		t.Errorf("Expected the entity to update once then leave, got %v with %d entities", log, world.Len())
+	}
+}
+
+func TestWorld_DrawOrder(t *testing.T) {
+	var log []string
+	world := NewWorld(nil)
+	entities := []struct {
+		name  string
+		layer int
+		z     float64
+	}{
+		{"effect", LayerEffects, 0},
+		{"front pickup", LayerDefault, 1},
+		{"pickup", LayerDefault, 0},
+		{"scenery", LayerBackground, 5},
+		{"second pickup", LayerDefault, 0},
+	}
+	for _, spec := range entities {
+		e := newTestEntity(spec.name, 0, 0, &log)
+		e.Layer, e.Z = spec.layer, spec.z
+		world.Spawn(e)
+	}
+
+	world.Draw(nil)
+	want := []string{"draw scenery", "draw pickup", "draw second pickup", "draw front pickup", "draw effect"}
+	if !slices.Equal(log, want) {
+		t.Errorf("Expected draw order %v, got %v", want, log)
+	}
+}
+
+func TestWorld_Tags(t *testing.T) {
+	var log []string
+	world := NewWorld(nil)
+	first := newTestEntity("first", 0, 0, &log, "heart")
+	world.Spawn(newTestEntity("cat", 0, 0, &log, "npc"))
+	world.Spawn(first)
+	second := newTestEntity("second", 0, 0, &log)
+	second.AddTag("heart")
+	world.Spawn(second)
+
+	if got := world.FirstWithTag("heart"); got != first {
+		t.Errorf("Expected the first heart, got %v", got)
+	}
+	if got := world.WithTag("heart", nil); len(got) != 2 || got[1] != second {
+		t.Errorf("Expected both hearts in spawn order, got %v", got)
+	}
+
+	world.Despawn(first)
+	if got := world.FirstWithTag("heart"); got != second {
+		t.Errorf("Expected despawned entities to be skipped, got %v", got)
+	}
+	if world.FirstWithTag("drone") != nil {
+		t.Error("Expected no entity for an unused tag")
+	}
+}
+
+func TestWorld_Contacts(t *testing.T) {
+	var log []string
+	world := NewWorld(collision.NewSpatialHash(32))
+	player := newTestEntity("player", 0, 0, &log)
+	pickup := newTestEntity("pickup", 100, 0, &log)
+	world.Spawn(player)
+	world.Spawn(pickup)
+
+	world.Update(1.0 / 60.0)
+	if len(player.touching) != 0 {
+		t.Fatalf("Expected no contacts while apart, got %v", player.touching)
+	}
+
+	// The pickup despawns itself when touched; the player still hears the contact end
+	pickup.onContact = func(e *testEntity, other Entity) { world.Despawn(e) }
+	player.x = 90
+	world.Update(1.0 / 60.0)
+	if !slices.Equal(player.touching, []string{"pickup"}) || !slices.Equal(pickup.touching, []string{"player"}) {
+		t.Errorf("Expected both entities to be told about the contact, got %v and %v", player.touching, pickup.touching)
+	}
+	if pickup.IsAlive() {
+		t.Error("Expected the pickup to be gone")
+	}
+
+	world.Update(1.0 / 60.0)
+	if !slices.Equal(player.contactsEnded, []string{"pickup"}) {
+		t.Errorf("Expected the player's contact with the despawned pickup to end, got %v", player.contactsEnded)
+	}
+	if len(pickup.contactsEnded) != 0 {
+		t.Errorf("Despawned entities shouldn't hear about contacts, got %v", pickup.contactsEnded)
+	}
+}
+
+func TestWorld_QueryRegion(t *testing.T) {
+	for _, hash := range []*collision.SpatialHash{nil, collision.NewSpatialHash(32)} {
+		var log []string
+		world := NewWorld(hash)
+		near := newTestEntity("near", 10, 10, &log)
+		world.Spawn(near)
+		world.Spawn(newTestEntity("far", 500, 10, &log))
+		world.Update(1.0 / 60.0)
+
+		got := world.QueryRegion(collision.Rect{X: 0, Y: 0, Width: 64, Height: 64}, nil)
+		if len(got) != 1 || got[0] != near {
+			t.Errorf("With spatial hash %v: expected only the near entity, got %v", hash != nil, got)
+		}
+	}
+}
+
+func TestWorld_Clear(t *testing.T) {
+	var log []string
+	world := NewWorld(collision.NewSpatialHash(32))
+	a := newTestEntity("a", 0, 0, &log)
+	world.Spawn(a)
+	world.Spawn(newTestEntity("b", 0, 0, &log))
+	world.Update(1.0 / 60.0)
+
+	world.Clear()
+	if world.Len() != 0 || a.IsAlive() {
+		t.Errorf("Expected an empty world, got %d entities", world.Len())
+	}
+	if got := world.QueryRegion(collision.Rect{X: 0, Y: 0, Width: 64, Height: 64}, nil); len(got) != 0 {
+		t.Errorf("Expected cleared entities to leave the spatial hash, got %v", got)
+	}
+
+	// Cleared entities can be spawned again with a fresh ID
+	oldID := a.ID()
+	if world.Spawn(a) == oldID || world.Len() != 1 {
+		t.Error("Expected a respawned entity to get a new ID")
+	}
+}
+
+func TestPlayerIsEntity(t *testing.T) {
+	var _ Entity = (*Player)(nil)
+
+	player := NewPlayer(0, 0, CreateTestSpriteSheet())
+	world := NewWorld(nil)
+	world.Spawn(player)
+	if world.FirstWithTag(TagPlayer) != player || player.Layer != LayerPlayer {
+		t.Error("Expected the player to be found by its tag on the player layer")
+	}
+
+	world.Update(1.0 / 60.0)
+	if player.GetVelocityY() == 0 {
+		t.Error("Expected the world to update the player")
+	}
+}
+
+func TestEntityBase_FindPlayer(t *testing.T) {
+	var log []string
+	entity := newTestEntity("cat", 0, 0, &log)
+	if entity.findPlayer() != nil {
+		t.Fatal("Expected no player outside a world")
+	}
+
+	world := NewWorld(nil)
+	world.Spawn(entity)
+	if entity.findPlayer() != nil {
+		t.Fatal("Expected no player in a world without one")
+	}
+
+	player := NewPlayer(0, 0, CreateTestSpriteSheet())
+	world.Spawn(player)
+	world.Update(1.0 / 60.0)
+	if entity.findPlayer() != player {
+		t.Error("Expected to find the player spawned into the world")
+	}
+}
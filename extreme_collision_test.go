@@ -13,8 +13,6 @@ func TestExtremeSpeedCollision(t *testing.T) {
 	testLevel := level.NewLevel(10, 10, 32, "Extreme Speed Test")
 	testLevel.SetTile(2, 5, level.TileSolid) // Platform at tile (2,5) = world position (64, 160) to (96, 192)
 	
-	// Create level adapter
-	levelAdapter := level.NewCollisionAdapter(testLevel)
 	
 	// Create a test sprite sheet to avoid the nil pointer issue
 	testSpriteSheet := entities.CreateTestSpriteSheet()
@@ -34,7 +32,7 @@ func TestExtremeSpeedCollision(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create a fresh player for each test
 			player := entities.NewPlayer(64, tc.startY, testSpriteSheet)
-			player.SetLevel(levelAdapter)
+			player.SetLevel(testLevel)
 			
 			// Set the falling velocity
 			player.VelocityY = tc.velocityY
@@ -85,8 +83,7 @@ func TestFallingFromHeight(t *testing.T) {
 	testLevel := level.NewLevel(20, 20, 32, "Height Test")
 	testLevel.SetTile(5, 15, level.TileSolid) // Platform at tile (5,15) = world position (160, 480) to (192, 512)
 	
-	// Create level adapter and test sprite sheet
-	levelAdapter := level.NewCollisionAdapter(testLevel)
+	// Create test sprite sheet
 	testSpriteSheet := entities.CreateTestSpriteSheet()
 	
 	// Test falling from various extreme heights
@@ -108,7 +105,7 @@ func TestFallingFromHeight(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create a fresh player for each test
 			player := entities.NewPlayer(160, tc.startY, testSpriteSheet)
-			player.SetLevel(levelAdapter)
+			player.SetLevel(testLevel)
 			
 			// Let gravity take effect
 			landed := false
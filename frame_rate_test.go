@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"ebiten-platformer/entities"
+	"ebiten-platformer/level"
+)
+
+// updateRates are the update frequencies movement must be independent of
+var updateRates = []float64{30, 60, 144}
+
+// movementSample is the player's state at a point in a scripted run
+type movementSample struct {
+	x, y, velocityX float64
+	onGround        bool
+}
+
+// runScript settles a player on a flat floor of the given tile type, then updates it at a
+// fixed rate. input returns the direction held at a time; jumpAt lists times to jump.
+// The state is sampled at each of the given times (multiples of 1/6s, which every
+// rate reaches exactly).
+func runScript(hz float64, floor level.TileType, input func(t float64) int, jumpAt []float64, sampleAt []float64) []movementSample {
+	testLevel := level.NewLevel(80, 12, 32, "Frame Rate Test")
+	for x := 0; x < testLevel.Width; x++ {
+		testLevel.SetTile(x, 10, floor)
+	}
+
+	player := entities.NewPlayer(64, 288, entities.CreateTestSpriteSheet())
+	player.SetLevel(testLevel)
+	dt := 1.0 / hz
+	for i := 0; i < int(hz/2); i++ {
+		player.Update(dt)
+	}
+	startX, startY := player.GetPosition()
+
+	var samples []movementSample
+	totalFrames := int(math.Round(sampleAt[len(sampleAt)-1] * hz))
+	for frame := 0; frame < totalFrames; frame++ {
+		t := float64(frame) / hz
+		for _, jumpTime := range jumpAt {
+			if int(math.Round(jumpTime*hz)) == frame {
+				player.Jump()
+			}
+		}
+		switch input(t) {
+		case -1:
+			player.MoveLeft()
+		case 1:
+			player.MoveRight()
+		}
+		player.Update(dt)
+
+		for _, sampleTime := range sampleAt {
+			if int(math.Round(sampleTime*hz)) == frame+1 {
+				x, y := player.GetPosition()
+				samples = append(samples, movementSample{x - startX, y - startY, player.VelocityX, player.OnGround})
+			}
+		}
+	}
+	return samples
+}
+
+// TestFrameRateIndependentMovement checks the same inputs give the same motion at every update rate
+func TestFrameRateIndependentMovement(t *testing.T) {
+	holdRight := func(t float64) int { return 1 }
+
+	testCases := []struct {
+		name     string
+		floor    level.TileType
+		input    func(t float64) int
+		jumpAt   []float64
+		sampleAt []float64
+	}{
+		{"Accelerate from rest", level.TileSolid, holdRight, nil, []float64{1.0 / 6, 1.0 / 3, 1}},
+		{"Run then release", level.TileSolid, func(t float64) int {
+			if t < 0.5 {
+				return 1
+			}
+			return 0
+		}, nil, []float64{0.5, 2.0 / 3, 1}},
+		{"Turn around", level.TileSolid, func(t float64) int {
+			if t < 0.5 {
+				return 1
+			}
+			return -1
+		}, nil, []float64{0.5, 2.0 / 3, 1}},
+		{"Running jump", level.TileSolid, holdRight, []float64{0.5}, []float64{2.0 / 3, 5.0 / 6, 1, 1.5}},
+		{"Jump then release in the air", level.TileSolid, func(t float64) int {
+			if t < 0.5 {
+				return 1
+			}
+			return 0
+		}, []float64{0.5}, []float64{2.0 / 3, 5.0 / 6}},
+		{"Ice run then release", level.TileIce, func(t float64) int {
+			if t < 1 {
+				return 1
+			}
+			return 0
+		}, nil, []float64{0.5, 1, 2}},
+	}
+
+	const positionTolerance = 0.5 // Pixels
+	const velocityTolerance = 1.0 // Pixels per second
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reference := runScript(60, tc.floor, tc.input, tc.jumpAt, tc.sampleAt)
+			if len(reference) != len(tc.sampleAt) {
+				t.Fatalf("Expected %d samples, got %d", len(tc.sampleAt), len(reference))
+			}
+
+			for _, hz := range updateRates {
+				samples := runScript(hz, tc.floor, tc.input, tc.jumpAt, tc.sampleAt)
+				for i, sample := range samples {
+					ref := reference[i]
+					if math.Abs(sample.x-ref.x) > positionTolerance || math.Abs(sample.y-ref.y) > positionTolerance ||
+						math.Abs(sample.velocityX-ref.velocityX) > velocityTolerance || sample.onGround != ref.onGround {
+						t.Errorf("%s at %.0f Hz, t=%.3fs: got (%.2f, %.2f) vx=%.1f ground=%v, 60 Hz got (%.2f, %.2f) vx=%.1f ground=%v",
+							tc.name, hz, tc.sampleAt[i], sample.x, sample.y, sample.velocityX, sample.onGround,
+							ref.x, ref.y, ref.velocityX, ref.onGround)
+					}
+				}
+			}
+
+			last := reference[len(reference)-1]
+			fmt.Printf("%s: moved %.2f px, vx=%.1f at every rate\n", tc.name, last.x, last.velocityX)
+		})
+	}
+}
+
+// TestAccelerationMatchesRates checks the motion follows the configured px/s² rates
+func TestAccelerationMatchesRates(t *testing.T) {
+	player := entities.NewPlayer(0, 0, entities.CreateTestSpriteSheet())
+	speed, acceleration, deceleration := player.Speed, player.GroundAcceleration, player.GroundDeceleration
+
+	// Ramp to full speed, then hold it for the rest of the second
+	rampTime := speed / acceleration
+	expectedRun := speed*rampTime/2 + speed*(1-rampTime)
+
+	for _, hz := range updateRates {
+		samples := runScript(hz, level.TileSolid, func(t float64) int {
+			if t < 1 {
+				return 1
+			}
+			return 0
+		}, nil, []float64{1, 2})
+
+		if math.Abs(samples[0].x-expectedRun) > 0.5 || math.Abs(samples[0].velocityX-speed) > 0.01 {
+			t.Errorf("%.0f Hz: Expected %.2f px at %.0f px/s after a second, got %.2f px at %.1f px/s", hz, expectedRun, speed, samples[0].x, samples[0].velocityX)
+		}
+
+		// Stopping from full speed takes v²/2a
+		if stop := samples[1].x - samples[0].x; math.Abs(stop-speed*speed/(2*deceleration)) > 0.5 {
+			t.Errorf("%.0f Hz: Expected to stop within %.2f px, took %.2f px", hz, speed*speed/(2*deceleration), stop)
+		}
+	}
+}
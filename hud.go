@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	"ebiten-platformer/entities"
+)
+
+// HUD placement, from the top-right corner of the screen
+const (
+	hudMargin     = 6
+	hudTextWidth  = 42 // Room for "99/99"
+	hudCharWidth  = 6  // Width of a debug font character
+	hudLineHeight = 16
+)
+
+// drawHUD shows the player's progress in the current level over the game
+func (g *RoboGame) drawHUD(screen *ebiten.Image) {
+	if g.levelProgress == nil {
+		return
+	}
+
+	x := screen.Bounds().Dx() - hudMargin - hudTextWidth - entities.HeartSize
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), hudMargin)
+	screen.DrawImage(entities.HeartSprite(), op)
+
+	hearts := fmt.Sprintf("%d/%d", g.levelProgress.HeartsCollectedCount(), g.levelProgress.HeartsTotal)
+	ebitenutil.DebugPrintAt(screen, hearts, x+entities.HeartSize+4, hudMargin)
+
+	// The score sits under the hearts, right-aligned
+	score := fmt.Sprintf("Score %d", g.levelProgress.Score)
+	scoreX := screen.Bounds().Dx() - hudMargin - len(score)*hudCharWidth
+	ebitenutil.DebugPrintAt(screen, score, scoreX, hudMargin+hudLineHeight+2)
+}
@@ -0,0 +1,270 @@
+package level
+
+import (
+	"image"
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// AutotileMode selects how neighbours are turned into a tile variant
+type AutotileMode int
+
+const (
+	// Autotile4Bit looks at the four edge neighbours (N, E, S, W), giving 16 variants.
+	// This is the Wang edge set: variant index = BaseIndex + mask.
+	Autotile4Bit AutotileMode = iota
+
+	// Autotile8Bit also looks at the diagonal neighbours, giving the 47-tile blob set.
+	// A corner only counts when both edges next to it are connected.
+	Autotile8Bit
+)
+
+// Neighbour bits used in autotile masks
+const (
+	AutotileNorth     = 1 << iota // 4-bit: N
+	AutotileEast                  // 4-bit: E
+	AutotileSouth                 // 4-bit: S
+	AutotileWest                  // 4-bit: W
+	AutotileNorthEast             // 8-bit only
+	AutotileSouthEast             // 8-bit only
+	AutotileSouthWest             // 8-bit only
+	AutotileNorthWest             // 8-bit only
+)
+
+// AutotileRule describes how one tile type picks its edge and corner sprites
+type AutotileRule struct {
+	Type         TileType     // Tile type the rule applies to
+	Mode         AutotileMode // 4-bit edge or 8-bit blob
+	BaseIndex    int          // Tileset index of the first variant
+	ConnectsTo   []TileType   // Neighbour types that join up with this one (defaults to Type)
+	EdgesConnect bool         // Treat neighbours outside the level as connected
+}
+
+// connects reports whether a neighbour tile joins up with tiles of this rule
+func (r AutotileRule) connects(neighbour *Tile) bool {
+	if len(r.ConnectsTo) == 0 {
+		return neighbour.Type == r.Type
+	}
+	for _, tileType := range r.ConnectsTo {
+		if neighbour.Type == tileType {
+			return true
+		}
+	}
+	return false
+}
+
+// blobVariants maps each reduced 8-bit mask to its position in the 47-tile blob set
+var blobVariants = buildBlobVariants()
+
+// buildBlobVariants enumerates the 47 distinct reduced 8-bit masks in ascending order
+func buildBlobVariants() map[int]int {
+	seen := make(map[int]bool)
+	for mask := 0; mask < 256; mask++ {
+		seen[reduceBlobMask(mask)] = true
+	}
+
+	masks := make([]int, 0, len(seen))
+	for mask := range seen {
+		masks = append(masks, mask)
+	}
+	sort.Ints(masks)
+
+	variants := make(map[int]int, len(masks))
+	for i, mask := range masks {
+		variants[mask] = i
+	}
+	return variants
+}
+
+// reduceBlobMask clears corner bits whose adjacent edges are not both connected
+func reduceBlobMask(mask int) int {
+	corners := []struct{ corner, edgeA, edgeB int }{
+		{AutotileNorthEast, AutotileNorth, AutotileEast},
+		{AutotileSouthEast, AutotileSouth, AutotileEast},
+		{AutotileSouthWest, AutotileSouth, AutotileWest},
+		{AutotileNorthWest, AutotileNorth, AutotileWest},
+	}
+	for _, c := range corners {
+		if mask&c.edgeA == 0 || mask&c.edgeB == 0 {
+			mask &^= c.corner
+		}
+	}
+	return mask
+}
+
+// BlobVariant returns the 0-46 blob set position for an 8-bit neighbour mask
+func BlobVariant(mask int) int {
+	return blobVariants[reduceBlobMask(mask)]
+}
+
+// Autotiler keeps each tile's TileIndex in step with its neighbours.
+// It listens to Level.SetTile, so only the changed tile and its eight neighbours
+// are recomputed when the map is edited at runtime.
+type Autotiler struct {
+	level *Level
+	rules map[TileType]AutotileRule
+}
+
+// NewAutotiler creates an autotiler for a level and subscribes it to tile changes.
+// Call Apply once after the level has been built.
+func NewAutotiler(level *Level, rules ...AutotileRule) *Autotiler {
+	autotiler := &Autotiler{
+		level: level,
+		rules: make(map[TileType]AutotileRule),
+	}
+	for _, rule := range rules {
+		autotiler.rules[rule.Type] = rule
+	}
+
+	level.AddTileChangeListener(func(x, y int) {
+		autotiler.UpdateAround(x, y)
+	})
+
+	return autotiler
+}
+
+// Apply recomputes the tile index of every tile in the level
+func (a *Autotiler) Apply() {
+	for y := 0; y < a.level.Height; y++ {
+		for x := 0; x < a.level.Width; x++ {
+			a.updateTile(x, y)
+		}
+	}
+}
+
+// UpdateAround recomputes a tile and its eight neighbours
+func (a *Autotiler) UpdateAround(x, y int) {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			a.updateTile(x+dx, y+dy)
+		}
+	}
+}
+
+// Mask returns the neighbour mask for a tile under its rule, or -1 if it has no rule.
+// 8-bit masks are returned reduced, so unused corners are already cleared.
+func (a *Autotiler) Mask(x, y int) int {
+	if !a.level.IsValidCoord(x, y) {
+		return -1
+	}
+	rule, exists := a.rules[a.level.Tiles[y][x].Type]
+	if !exists {
+		return -1
+	}
+	return a.mask(rule, x, y)
+}
+
+// updateTile sets the tile index for a single tile from its neighbours
+func (a *Autotiler) updateTile(x, y int) {
+	if !a.level.IsValidCoord(x, y) {
+		return
+	}
+
+	tile := a.level.Tiles[y][x]
+	rule, exists := a.rules[tile.Type]
+	if !exists {
+		return
+	}
+
+	mask := a.mask(rule, x, y)
+	if rule.Mode == Autotile8Bit {
+		tile.TileIndex = rule.BaseIndex + blobVariants[mask]
+	} else {
+		tile.TileIndex = rule.BaseIndex + mask
+	}
+}
+
+// mask builds the neighbour mask for a tile
+func (a *Autotiler) mask(rule AutotileRule, x, y int) int {
+	neighbours := []struct{ dx, dy, bit int }{
+		{0, -1, AutotileNorth},
+		{1, 0, AutotileEast},
+		{0, 1, AutotileSouth},
+		{-1, 0, AutotileWest},
+	}
+	if rule.Mode == Autotile8Bit {
+		neighbours = append(neighbours,
+			struct{ dx, dy, bit int }{1, -1, AutotileNorthEast},
+			struct{ dx, dy, bit int }{1, 1, AutotileSouthEast},
+			struct{ dx, dy, bit int }{-1, 1, AutotileSouthWest},
+			struct{ dx, dy, bit int }{-1, -1, AutotileNorthWest},
+		)
+	}
+
+	mask := 0
+	for _, n := range neighbours {
+		nx, ny := x+n.dx, y+n.dy
+		if !a.level.IsValidCoord(nx, ny) {
+			if rule.EdgesConnect {
+				mask |= n.bit
+			}
+			continue
+		}
+		if rule.connects(a.level.Tiles[ny][nx]) {
+			mask |= n.bit
+		}
+	}
+
+	if rule.Mode == Autotile8Bit {
+		return reduceBlobMask(mask)
+	}
+	return mask
+}
+
+// placeholderAutotileTypes are the tile types given edge variants by NewPlaceholderAutotileset
+var placeholderAutotileTypes = []TileType{TileSolid, TileClimbable, TileOneWay}
+
+// NewPlaceholderAutotileset builds a flat colour atlas with 4-bit edge variants, and the
+// rules that use it, so autotiling is visible before real tile art exists.
+// The first rows hold the NewColourTileset colours at the same indices; each autotiled
+// type then gets a row of 16 variants with a darker band on every side that doesn't
+// connect to a neighbour.
+func NewPlaceholderAutotileset(tileSize int) (*Tileset, []AutotileRule) {
+	const columns = 16
+	flatRows := max((colourTileCount()+columns-1)/columns, 1)
+	rows := flatRows + len(placeholderAutotileTypes)
+	atlas := ebiten.NewImage(columns*tileSize, rows*tileSize)
+
+	// First rows: flat colours indexed by tile type
+	fillColourTiles(atlas, columns, tileSize)
+
+	band := max(tileSize/8, 1)
+	rules := make([]AutotileRule, 0, len(placeholderAutotileTypes))
+	for row, tileType := range placeholderAutotileTypes {
+		base := (flatRows + row) * columns
+		fill := tileType.Properties().Colour
+		edge := color.RGBA{fill.R / 2, fill.G / 2, fill.B / 2, 255}
+
+		for mask := 0; mask < 16; mask++ {
+			x := mask * tileSize
+			y := (flatRows + row) * tileSize
+			atlas.SubImage(image.Rect(x, y, x+tileSize, y+tileSize)).(*ebiten.Image).Fill(fill)
+
+			exposed := []struct {
+				bit  int
+				rect image.Rectangle
+			}{
+				{AutotileNorth, image.Rect(x, y, x+tileSize, y+band)},
+				{AutotileEast, image.Rect(x+tileSize-band, y, x+tileSize, y+tileSize)},
+				{AutotileSouth, image.Rect(x, y+tileSize-band, x+tileSize, y+tileSize)},
+				{AutotileWest, image.Rect(x, y, x+band, y+tileSize)},
+			}
+			for _, side := range exposed {
+				if mask&side.bit == 0 {
+					atlas.SubImage(side.rect).(*ebiten.Image).Fill(edge)
+				}
+			}
+		}
+
+		rules = append(rules, AutotileRule{
+			Type:         tileType,
+			Mode:         Autotile4Bit,
+			BaseIndex:    base,
+			EdgesConnect: tileType != TileOneWay,
+		})
+	}
+
+	return NewTileset(atlas, tileSize), rules
+}
@@ -0,0 +1,240 @@
+package level
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newAutotileLevel builds a level from rows of characters: '#' solid, 'H' climbable,
+// '=' one-way, anything else empty
+func newAutotileLevel(rows ...string) *Level {
+	level := NewLevel(len(rows[0]), len(rows), 32, "Autotile")
+	for y, row := range rows {
+		for x, c := range row {
+			switch c {
+			case '#':
+				level.SetTile(x, y, TileSolid)
+			case 'H':
+				level.SetTile(x, y, TileClimbable)
+			case '=':
+				level.SetTile(x, y, TileOneWay)
+			}
+		}
+	}
+	return level
+}
+
+func TestAutotiler_4BitMasks(t *testing.T) {
+	level := newAutotileLevel(
+		".....",
+		".###.",
+		".###.",
+		".....",
+	)
+	autotiler := NewAutotiler(level, AutotileRule{Type: TileSolid, Mode: Autotile4Bit, BaseIndex: 16})
+	autotiler.Apply()
+
+	tests := []struct {
+		name string
+		x, y int
+		mask int
+	}{
+		{"top left corner", 1, 1, AutotileEast | AutotileSouth},
+		{"top edge", 2, 1, AutotileEast | AutotileSouth | AutotileWest},
+		{"top right corner", 3, 1, AutotileSouth | AutotileWest},
+		{"bottom edge", 2, 2, AutotileNorth | AutotileEast | AutotileWest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if mask := autotiler.Mask(tt.x, tt.y); mask != tt.mask {
+				t.Errorf("Expected mask %04b, got %04b", tt.mask, mask)
+			}
+			if index := level.GetTile(tt.x, tt.y).TileIndex; index != 16+tt.mask {
+				t.Errorf("Expected tile index %d, got %d", 16+tt.mask, index)
+			}
+		})
+	}
+
+	// Tiles without a rule keep the type default
+	if index := level.GetTile(0, 0).TileIndex; index != -1 {
+		t.Errorf("Expected empty tile to keep index -1, got %d", index)
+	}
+	if mask := autotiler.Mask(0, 0); mask != -1 {
+		t.Errorf("Expected no mask for a tile without a rule, got %d", mask)
+	}
+}
+
+func TestAutotiler_EdgesConnect(t *testing.T) {
+	level := newAutotileLevel(
+		"##",
+		"##",
+	)
+
+	open := NewAutotiler(level, AutotileRule{Type: TileSolid})
+	if mask := open.Mask(0, 0); mask != AutotileEast|AutotileSouth {
+		t.Errorf("Expected level edges to be exposed, got %04b", mask)
+	}
+
+	closed := NewAutotiler(level, AutotileRule{Type: TileSolid, EdgesConnect: true})
+	if mask := closed.Mask(0, 0); mask != 0b1111 {
+		t.Errorf("Expected level edges to connect, got %04b", mask)
+	}
+}
+
+func TestAutotiler_ConnectsTo(t *testing.T) {
+	level := newAutotileLevel("#H#")
+
+	autotiler := NewAutotiler(level,
+		AutotileRule{Type: TileSolid, ConnectsTo: []TileType{TileSolid, TileClimbable}},
+		AutotileRule{Type: TileClimbable},
+	)
+
+	if mask := autotiler.Mask(0, 0); mask != AutotileEast {
+		t.Errorf("Solid should join the climbable tile, got %04b", mask)
+	}
+	if mask := autotiler.Mask(1, 0); mask != 0 {
+		t.Errorf("Climbable should only join other climbable tiles, got %04b", mask)
+	}
+}
+
+func TestAutotiler_BlobCorners(t *testing.T) {
+	level := newAutotileLevel(
+		"###",
+		"###",
+		"##.",
+	)
+	autotiler := NewAutotiler(level, AutotileRule{Type: TileSolid, Mode: Autotile8Bit})
+
+	// Centre tile: all edges plus every corner except the missing south-east one
+	expected := 0xff &^ AutotileSouthEast
+	if mask := autotiler.Mask(1, 1); mask != expected {
+		t.Errorf("Expected centre mask %08b, got %08b", expected, mask)
+	}
+
+	// Top-left tile: corner NE/NW/SW are outside the level, only SE counts
+	expected = AutotileEast | AutotileSouth | AutotileSouthEast
+	if mask := autotiler.Mask(0, 0); mask != expected {
+		t.Errorf("Expected top-left mask %08b, got %08b", expected, mask)
+	}
+
+	// A corner without both adjacent edges is ignored
+	level = newAutotileLevel(
+		"#.",
+		".#",
+	)
+	autotiler = NewAutotiler(level, AutotileRule{Type: TileSolid, Mode: Autotile8Bit})
+	if mask := autotiler.Mask(0, 0); mask != 0 {
+		t.Errorf("Diagonal neighbour alone should not count, got %08b", mask)
+	}
+}
+
+func TestBlobVariant_Has47Variants(t *testing.T) {
+	seen := make(map[int]bool)
+	for mask := 0; mask < 256; mask++ {
+		variant := BlobVariant(mask)
+		if variant < 0 || variant >= 47 {
+			t.Fatalf("Mask %08b gave variant %d outside 0-46", mask, variant)
+		}
+		seen[variant] = true
+	}
+	if len(seen) != 47 {
+		t.Errorf("Expected 47 blob variants, got %d", len(seen))
+	}
+
+	if BlobVariant(0) != 0 {
+		t.Error("Isolated tile should be the first variant")
+	}
+	if BlobVariant(0xff) != 46 {
+		t.Error("Fully surrounded tile should be the last variant")
+	}
+}
+
+func TestAutotiler_IncrementalUpdate(t *testing.T) {
+	level := newAutotileLevel(
+		"....",
+		"###.",
+		"....",
+	)
+	autotiler := NewAutotiler(level, AutotileRule{Type: TileSolid})
+	autotiler.Apply()
+
+	if index := level.GetTile(2, 1).TileIndex; index != AutotileWest {
+		t.Fatalf("Expected right end cap before edit, got %d", index)
+	}
+
+	// Extending the row turns the old end cap into a middle piece
+	level.SetTile(3, 1, TileSolid)
+	if index := level.GetTile(2, 1).TileIndex; index != AutotileEast|AutotileWest {
+		t.Errorf("Expected neighbour to update to a middle piece, got %04b", index)
+	}
+	if index := level.GetTile(3, 1).TileIndex; index != AutotileWest {
+		t.Errorf("Expected new tile to be autotiled, got %d", index)
+	}
+
+	// Removing a tile updates the neighbours on both sides
+	level.SetTile(1, 1, TileEmpty)
+	if index := level.GetTile(0, 1).TileIndex; index != 0 {
+		t.Errorf("Expected isolated tile after removal, got %04b", index)
+	}
+	if index := level.GetTile(2, 1).TileIndex; index != AutotileEast {
+		t.Errorf("Expected left end cap after removal, got %04b", index)
+	}
+}
+
+func TestAutotiler_RendererRebuildsNeighbourChunks(t *testing.T) {
+	level := newFilledLevel(32, 16)
+	NewAutotiler(level, AutotileRule{Type: TileSolid}).Apply()
+
+	renderer := NewTileRenderer(level, nil)
+	renderer.SetChunkCaching(true, 16)
+	screen := ebiten.NewImage(1024, 512) // Both chunks visible
+	renderer.Draw(screen, 0, 0)
+
+	// Tile on the left chunk's edge changes its neighbour in the right chunk
+	level.SetTile(15, 5, TileEmpty)
+	renderer.Draw(screen, 0, 0)
+
+	if rebuilt := renderer.Stats().ChunksRebuilt; rebuilt != 2 {
+		t.Errorf("Expected both chunks rebuilt after an edge change, got %d", rebuilt)
+	}
+}
+
+func TestPlaceholderAutotileset(t *testing.T) {
+	tileset, rules := NewPlaceholderAutotileset(32)
+
+	// Flat colours fill as many rows as there are tile types, then one row per rule
+	flatRows := (colourTileCount() + 15) / 16
+	if tileset.TileCount() != 16*(flatRows+3) {
+		t.Errorf("Expected %d tiles, got %d", 16*(flatRows+3), tileset.TileCount())
+	}
+	if len(rules) != 3 {
+		t.Fatalf("Expected rules for solid, climbable and one-way, got %d", len(rules))
+	}
+
+	for _, rule := range rules {
+		if rule.BaseIndex%16 != 0 || rule.BaseIndex == 0 {
+			t.Errorf("Rule for %v should start a fresh row after the flat colours, got %d", rule.Type, rule.BaseIndex)
+		}
+		if tileset.Tile(rule.BaseIndex+15) == nil {
+			t.Errorf("Rule for %v is missing variants", rule.Type)
+		}
+	}
+
+	// Flat colour row still works for tile types without a rule
+	if tileset.Tile(int(TileSpike)) == nil {
+		t.Error("Expected flat colour tile for spikes")
+	}
+}
+
+func TestTileRenderer_FallsBackForMissingVariant(t *testing.T) {
+	level := newAutotileLevel("##")
+	NewAutotiler(level, AutotileRule{Type: TileSolid, BaseIndex: 64}).Apply()
+
+	// Flat colour tileset has no autotile variants, only a tile per type
+	renderer := NewTileRenderer(level, nil)
+	if renderer.tileImage(level.GetTile(0, 0)) != renderer.Tileset().Tile(int(TileSolid)) {
+		t.Error("Expected the type default when the tileset lacks the variant")
+	}
+}
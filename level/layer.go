@@ -0,0 +1,245 @@
+package level
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// CollisionLayerName is the name of the layer every level creates for its Tiles grid
+const CollisionLayerName = "collision"
+
+// LayerKind identifies what a layer draws
+type LayerKind int
+
+const (
+	LayerImage     LayerKind = iota // A single image, optionally repeated, with parallax scrolling
+	LayerTiles                      // A decorative tile grid that never collides
+	LayerCollision                  // The level's own Tiles grid
+)
+
+// LayerDepth places a layer behind or in front of the player
+type LayerDepth int
+
+const (
+	LayerBehind LayerDepth = iota // Drawn before entities
+	LayerFront                    // Drawn after entities
+)
+
+// RepeatMode controls how an image layer tiles across the screen
+type RepeatMode int
+
+const (
+	RepeatNone RepeatMode = iota // Drawn once
+	RepeatX                      // Repeated horizontally
+	RepeatY                      // Repeated vertically
+	RepeatBoth                   // Repeated in both directions
+)
+
+// Layer is a named, ordered part of a level's visuals
+type Layer struct {
+	Name    string
+	Kind    LayerKind
+	Depth   LayerDepth
+	Visible bool
+
+	ScrollX float64 // Horizontal parallax factor: 0 is fixed to the screen, 1 moves with the world
+	ScrollY float64 // Vertical parallax factor
+
+	// Image layers
+	Image   *ebiten.Image
+	OffsetX float64 // Screen position of the image when the camera is at the origin
+	OffsetY float64
+	Repeat  RepeatMode
+
+	// Tile layers; for the collision layer this is the level itself
+	Grid *Level
+}
+
+// NewImageLayer creates a parallax image layer drawn behind the player
+func NewImageLayer(name string, img *ebiten.Image, scrollX, scrollY float64, repeat RepeatMode) *Layer {
+	return &Layer{
+		Name:    name,
+		Kind:    LayerImage,
+		Depth:   LayerBehind,
+		Visible: true,
+		ScrollX: scrollX,
+		ScrollY: scrollY,
+		Image:   img,
+		Repeat:  repeat,
+	}
+}
+
+// NewTileLayer creates an empty decorative tile layer. Its tiles are only drawn;
+// collision always uses the level's own Tiles.
+func NewTileLayer(name string, width, height, tileSize int, depth LayerDepth) *Layer {
+	return &Layer{
+		Name:    name,
+		Kind:    LayerTiles,
+		Depth:   depth,
+		Visible: true,
+		ScrollX: 1,
+		ScrollY: 1,
+		Grid:    NewLevel(width, height, tileSize, name),
+	}
+}
+
+// SetTile sets a tile on a tile layer. It does nothing for image layers.
+func (layer *Layer) SetTile(x, y int, tileType TileType) {
+	if layer.Grid != nil {
+		layer.Grid.SetTile(x, y, tileType)
+	}
+}
+
+// AddLayer adds a layer to the level. Layers behind the player are inserted before the
+// collision layer and layers in front are appended, each in the order they are added.
+func (l *Level) AddLayer(layer *Layer) {
+	if layer.Depth == LayerFront {
+		l.Layers = append(l.Layers, layer)
+		return
+	}
+
+	index := len(l.Layers)
+	for i, existing := range l.Layers {
+		if existing.Kind == LayerCollision || existing.Depth == LayerFront {
+			index = i
+			break
+		}
+	}
+	l.Layers = append(l.Layers, nil)
+	copy(l.Layers[index+1:], l.Layers[index:])
+	l.Layers[index] = layer
+}
+
+// AddTileLayer creates a decorative tile layer the size of the level, using the level's tileset
+func (l *Level) AddTileLayer(name string, depth LayerDepth) *Layer {
+	layer := NewTileLayer(name, l.Width, l.Height, l.TileSize, depth)
+	layer.Grid.Tileset = l.Tileset
+	l.AddLayer(layer)
+	return layer
+}
+
+// Layer returns the layer with the given name, or nil if there is none
+func (l *Level) Layer(name string) *Layer {
+	for _, layer := range l.Layers {
+		if layer.Name == name {
+			return layer
+		}
+	}
+	return nil
+}
+
+// LayerStats reports what the most recent LayeredRenderer draw call did
+type LayerStats struct {
+	Layers     []string // Names of the layers drawn, in order
+	ImageDraws int      // Copies of layer images drawn, counting repeats
+}
+
+// LayeredRenderer draws a level's layers in order with a camera offset
+type LayeredRenderer struct {
+	level *Level
+	stats LayerStats
+}
+
+// NewLayeredRenderer creates a layered renderer for a level
+func NewLayeredRenderer(level *Level) *LayeredRenderer {
+	return &LayeredRenderer{level: level}
+}
+
+// Stats returns what the most recent draw call did
+func (r *LayeredRenderer) Stats() LayerStats {
+	return r.stats
+}
+
+// Draw renders every visible layer, behind and in front
+func (r *LayeredRenderer) Draw(screen *ebiten.Image, cameraX, cameraY float64) {
+	r.stats = LayerStats{}
+	r.drawLayers(screen, cameraX, cameraY, LayerBehind)
+	r.drawLayers(screen, cameraX, cameraY, LayerFront)
+}
+
+// DrawBehind renders the legacy Background image and every layer behind the player
+func (r *LayeredRenderer) DrawBehind(screen *ebiten.Image, cameraX, cameraY float64) {
+	r.stats = LayerStats{}
+	r.drawLayers(screen, cameraX, cameraY, LayerBehind)
+}
+
+// DrawFront renders every layer in front of the player
+func (r *LayeredRenderer) DrawFront(screen *ebiten.Image, cameraX, cameraY float64) {
+	r.stats = LayerStats{}
+	r.drawLayers(screen, cameraX, cameraY, LayerFront)
+}
+
+// drawLayers renders the visible layers at one depth in order
+func (r *LayeredRenderer) drawLayers(screen *ebiten.Image, cameraX, cameraY float64, depth LayerDepth) {
+	// The single static Background predates layers and stays furthest back
+	if depth == LayerBehind && r.level.Background != nil {
+		screen.DrawImage(r.level.Background, &ebiten.DrawImageOptions{})
+	}
+
+	for _, layer := range r.level.Layers {
+		if !layer.Visible || layer.Depth != depth {
+			continue
+		}
+
+		switch layer.Kind {
+		case LayerImage:
+			r.drawImageLayer(screen, layer, cameraX, cameraY)
+		case LayerTiles:
+			layer.Grid.Renderer().Draw(screen, cameraX*layer.ScrollX, cameraY*layer.ScrollY)
+		case LayerCollision:
+			r.level.Renderer().Draw(screen, cameraX, cameraY)
+		}
+		r.stats.Layers = append(r.stats.Layers, layer.Name)
+	}
+}
+
+// drawImageLayer draws a parallax image, repeating it to cover the screen if asked
+func (r *LayeredRenderer) drawImageLayer(screen *ebiten.Image, layer *Layer, cameraX, cameraY float64) {
+	if layer.Image == nil {
+		return
+	}
+
+	bounds := layer.Image.Bounds()
+	imageWidth, imageHeight := float64(bounds.Dx()), float64(bounds.Dy())
+	if imageWidth <= 0 || imageHeight <= 0 {
+		return
+	}
+	screenBounds := screen.Bounds()
+	screenWidth, screenHeight := float64(screenBounds.Dx()), float64(screenBounds.Dy())
+
+	x := layer.OffsetX - cameraX*layer.ScrollX
+	y := layer.OffsetY - cameraY*layer.ScrollY
+
+	startX, endX := x, x
+	if layer.Repeat == RepeatX || layer.Repeat == RepeatBoth {
+		startX, endX = repeatStart(x, imageWidth), screenWidth-1
+	}
+	startY, endY := y, y
+	if layer.Repeat == RepeatY || layer.Repeat == RepeatBoth {
+		startY, endY = repeatStart(y, imageHeight), screenHeight-1
+	}
+
+	for drawY := startY; drawY <= endY; drawY += imageHeight {
+		for drawX := startX; drawX <= endX; drawX += imageWidth {
+			// Skip copies entirely outside the screen
+			if drawX+imageWidth <= 0 || drawX >= screenWidth || drawY+imageHeight <= 0 || drawY >= screenHeight {
+				continue
+			}
+
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(drawX, drawY)
+			screen.DrawImage(layer.Image, op)
+			r.stats.ImageDraws++
+		}
+	}
+}
+
+// repeatStart returns the first position at or left of 0 where a repeated image starts
+func repeatStart(offset, size float64) float64 {
+	start := math.Mod(offset, size)
+	if start > 0 {
+		start -= size
+	}
+	return start
+}
@@ -0,0 +1,141 @@
+package level
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestNewLevel_HasCollisionLayer(t *testing.T) {
+	level := NewLevel(10, 10, 32, "Layers")
+
+	layer := level.Layer(CollisionLayerName)
+	if layer == nil {
+		t.Fatal("Expected every level to have a collision layer")
+	}
+	if layer.Kind != LayerCollision || layer.Grid != level {
+		t.Error("Collision layer should draw the level's own tiles")
+	}
+
+	// Setting a tile through the layer changes the collision grid
+	layer.SetTile(2, 3, TileSolid)
+	if !level.GetTile(2, 3).IsSolid() {
+		t.Error("Expected collision layer SetTile to change the level tiles")
+	}
+}
+
+func TestLevel_AddLayerOrder(t *testing.T) {
+	level := NewLevel(10, 10, 32, "Layers")
+	sky := ebiten.NewImage(64, 64)
+
+	level.AddLayer(NewImageLayer("sky", sky, 0, 0, RepeatBoth))
+	level.AddLayer(NewImageLayer("hills", sky, 0.5, 0, RepeatX))
+	level.AddTileLayer("foliage", LayerFront)
+	level.AddTileLayer("pipes", LayerBehind)
+
+	var names []string
+	for _, layer := range level.Layers {
+		names = append(names, layer.Name)
+	}
+
+	expected := []string{"sky", "hills", "pipes", CollisionLayerName, "foliage"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected layer order %v, got %v", expected, names)
+	}
+
+	if level.Layer("missing") != nil {
+		t.Error("Expected nil for an unknown layer name")
+	}
+}
+
+func TestLayeredRenderer_DrawsInOrder(t *testing.T) {
+	level := NewLevel(10, 10, 32, "Layers")
+	level.AddLayer(NewImageLayer("sky", ebiten.NewImage(64, 64), 0, 0, RepeatNone))
+	level.AddTileLayer("foliage", LayerFront)
+	hidden := level.AddTileLayer("hidden", LayerBehind)
+	hidden.Visible = false
+
+	screen := ebiten.NewImage(320, 240)
+	renderer := level.LayeredRenderer()
+
+	level.DrawBehind(screen, 0, 0)
+	if layers := renderer.Stats().Layers; !reflect.DeepEqual(layers, []string{"sky", CollisionLayerName}) {
+		t.Errorf("Unexpected layers behind the player: %v", layers)
+	}
+
+	level.DrawFront(screen, 0, 0)
+	if layers := renderer.Stats().Layers; !reflect.DeepEqual(layers, []string{"foliage"}) {
+		t.Errorf("Unexpected layers in front of the player: %v", layers)
+	}
+
+	level.DrawWithCamera(screen, 0, 0)
+	if layers := renderer.Stats().Layers; !reflect.DeepEqual(layers, []string{"sky", CollisionLayerName, "foliage"}) {
+		t.Errorf("Unexpected full layer order: %v", layers)
+	}
+}
+
+func TestLayeredRenderer_ImageRepeat(t *testing.T) {
+	screen := ebiten.NewImage(320, 240)
+
+	tests := []struct {
+		name    string
+		repeat  RepeatMode
+		scrollX float64
+		cameraX float64
+		draws   int
+	}{
+		{"no repeat", RepeatNone, 0, 0, 1},
+		{"no repeat scrolled off screen", RepeatNone, 1, 200, 0},
+		{"repeat x aligned", RepeatX, 0, 0, 3},           // 320 / 128 = 2.5 -> 3 copies
+		{"repeat x with parallax", RepeatX, 0.5, 100, 3}, // Offset -50 still needs 3 copies
+		{"repeat x crossing edge", RepeatX, 1, 64, 3},    // Starts at -64: covers -64..320
+		{"repeat both", RepeatBoth, 0, 0, 3 * 2},         // 240 / 128 -> 2 rows
+		{"repeat y", RepeatY, 0, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := NewLevel(1, 1, 32, "Parallax")
+			level.AddLayer(NewImageLayer("bg", ebiten.NewImage(128, 128), tt.scrollX, 0, tt.repeat))
+
+			renderer := level.LayeredRenderer()
+			renderer.Draw(screen, tt.cameraX, 0)
+
+			if draws := renderer.Stats().ImageDraws; draws != tt.draws {
+				t.Errorf("Expected %d image draws, got %d", tt.draws, draws)
+			}
+		})
+	}
+}
+
+func TestRepeatStart(t *testing.T) {
+	tests := []struct {
+		offset, size, expected float64
+	}{
+		{0, 100, 0},
+		{-30, 100, -30},
+		{30, 100, -70},
+		{-250, 100, -50},
+		{250, 100, -50},
+	}
+
+	for _, tt := range tests {
+		if start := repeatStart(tt.offset, tt.size); start != tt.expected {
+			t.Errorf("repeatStart(%v, %v) = %v, expected %v", tt.offset, tt.size, start, tt.expected)
+		}
+	}
+}
+
+func TestTileLayer_DoesNotCollide(t *testing.T) {
+	level := NewLevel(10, 10, 32, "Decor")
+	decor := level.AddTileLayer("decor", LayerBehind)
+	decor.SetTile(1, 1, TileSolid)
+
+	if level.GetTile(1, 1).Type != TileEmpty {
+		t.Error("Decorative tiles must not change the collision grid")
+	}
+	if result := level.CheckCollision(32, 32, 16, 16); result.Collided {
+		t.Error("Decorative tiles must not collide")
+	}
+}
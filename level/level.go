@@ -1,18 +1,19 @@
 package level
 
 import (
-	"image/color"
 	"math"
 
+	"ebiten-platformer/collision"
+
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // Constants for collision detection tuning
 const (
 	// GroundTolerance defines how close an entity must be to a tile surface
-	// to be considered "on ground". This accounts for floating-point precision
-	// and provides stable ground detection.
-	GroundTolerance = 2.0
+	// to be considered "on ground". It is collision.GroundTolerance, so tiles
+	// and other solids agree.
+	GroundTolerance = collision.GroundTolerance
 )
 
 // Level represents a game level with tile-based collision
@@ -22,9 +23,21 @@ type Level struct {
 	TileSize   int             // Size of each tile in pixels
 	Tiles      [][]*Tile       // 2D array of tiles [y][x]
 	Background *ebiten.Image   // Background image (optional)
+	Tileset    *Tileset        // Tile atlas (optional, flat colours are used if nil)
 	Name       string          // Level name
+	Layers     []*Layer        // Draw order; always contains the collision layer
+	Objects    []*Object       // Player start, collectibles, enemies and other placements
+
+	renderer      *TileRenderer        // Lazily created by Renderer()
+	layered       *LayeredRenderer     // Lazily created by LayeredRenderer()
+	navigator     *Navigator           // Lazily created by Navigator()
+	tileListeners []TileChangeListener // Notified by SetTile
+	replaced      map[int]*Tile        // Tiles as the level was built, by y*Width+x, where ReplaceTile changed them
 }
 
+// TileChangeListener is notified when SetTile changes the tile at (x, y)
+type TileChangeListener func(x, y int)
+
 // NewLevel creates a new empty level
 func NewLevel(width, height, tileSize int, name string) *Level {
 	// Initialize 2D tile array
@@ -36,19 +49,47 @@ func NewLevel(width, height, tileSize int, name string) *Level {
 		}
 	}
 
-	return &Level{
+	level := &Level{
 		Width:    width,
 		Height:   height,
 		TileSize: tileSize,
 		Tiles:    tiles,
 		Name:     name,
 	}
+	level.Layers = []*Layer{{
+		Name:    CollisionLayerName,
+		Kind:    LayerCollision,
+		Depth:   LayerBehind,
+		Visible: true,
+		ScrollX: 1,
+		ScrollY: 1,
+		Grid:    level,
+	}}
+
+	return level
 }
 
-// SetTile sets a tile at the given grid coordinates
+// SetTile sets a tile at the given grid coordinates. The new tile is the one the level
+// is built with, so a tile ReplaceTile changed is no longer restored.
 func (l *Level) SetTile(x, y int, tileType TileType) {
 	if l.IsValidCoord(x, y) {
+		delete(l.replaced, y*l.Width+x)
 		l.Tiles[y][x] = NewTile(tileType, x, y)
+		l.notifyTileChanged(x, y)
+	}
+}
+
+// AddTileChangeListener registers a callback for tile changes made through SetTile.
+// Caches derived from the tile grid (render chunks, autotiling, navigation) use this
+// to update incrementally instead of rebuilding every frame.
+func (l *Level) AddTileChangeListener(listener TileChangeListener) {
+	l.tileListeners = append(l.tileListeners, listener)
+}
+
+// notifyTileChanged tells every listener that a tile changed
+func (l *Level) notifyTileChanged(x, y int) {
+	for _, listener := range l.tileListeners {
+		listener(x, y)
 	}
 }
 
@@ -77,23 +118,24 @@ func (l *Level) GetWorldBounds() (width, height float64) {
 	return float64(l.Width * l.TileSize), float64(l.Height * l.TileSize)
 }
 
-// CollisionResult represents the result of a collision check
-type CollisionResult struct {
-	Collided         bool
-	CollisionX       bool  // True if collision occurred on X axis
-	CollisionY       bool  // True if collision occurred on Y axis
-	PenetrationX     float64 // How much the entity penetrated on X axis
-	PenetrationY     float64 // How much the entity penetrated on Y axis
-	OnGround         bool  // True if the entity is standing on solid ground
-	TouchingWall     bool  // True if the entity is touching a wall
-	ClimbableSurface bool // True if touching a climbable surface
-	DangerousTile    bool // True if touching a dangerous tile
-	OneWayPlatform   bool // True if touching a one-way platform from above
+// NewSpatialHash creates an entity-vs-entity broadphase whose cells are the level's tiles
+func (l *Level) NewSpatialHash() *collision.SpatialHash {
+	return collision.NewSpatialHash(float64(l.TileSize))
 }
 
-// CheckCollision checks collision between a rectangular entity and the level tiles
-func (l *Level) CheckCollision(entityX, entityY, entityWidth, entityHeight float64) *CollisionResult {
-	result := &CollisionResult{}
+// CheckCollision checks collision between a rectangular entity and the level tiles. It
+// allocates a new result; movers checking every frame should reuse one with CheckCollisionInto.
+func (l *Level) CheckCollision(entityX, entityY, entityWidth, entityHeight float64) *collision.Result {
+	result := &collision.Result{}
+	l.CheckCollisionInto(entityX, entityY, entityWidth, entityHeight, result)
+	return result
+}
+
+// CheckCollisionInto checks collision between a rectangular entity and the level tiles,
+// filling result after resetting it. Every tile the entity overlaps or stands on is added to
+// its Contacts with the direction that tile pushes the entity.
+func (l *Level) CheckCollisionInto(entityX, entityY, entityWidth, entityHeight float64, result *collision.Result) {
+	result.Reset()
 
 	// Calculate which tiles the entity overlaps
 	leftTile := int(math.Floor(entityX / float64(l.TileSize)))
@@ -104,40 +146,87 @@ func (l *Level) CheckCollision(entityX, entityY, entityWidth, entityHeight float
 	// For ground detection, also check the tile directly below the entity
 	belowTile := int(math.Floor((entityY + entityHeight) / float64(l.TileSize)))
 
+	// Slopes collide through their surface height under the entity's centre
+	entityBottom := entityY + entityHeight
+	stepLimit := math.Inf(1)
+	if slopeX, slopeY, surfaceY, gradient, found := l.slopeSurface(entityX+entityWidth/2, entityBottom); found {
+		// While on or just above a slope, solid tiles it runs into are steps rather than
+		// walls. This includes the frame before snapping down onto a descending slope.
+		stepLimit = entityBottom - (entityWidth/2*math.Abs(gradient) + GroundTolerance)
+		
+		if entityBottom >= surfaceY {
+			result.Collided = true
+			result.OnGround = true
+			result.OnSlope = true
+			result.SurfaceY = surfaceY
+			result.SlopeGradient = gradient
+			result.PenetrationY = entityBottom - surfaceY
+			length := math.Hypot(gradient, 1)
+			result.AddContact(slopeX, slopeY, gradient/length, -1/length)
+		}
+	}
+
 	// Check all overlapping tiles
 	for tileY := topTile; tileY <= bottomTile; tileY++ {
+		oneWayWidth, oneWayTop := 0.0, 0.0 // One-way platform tops crossing the box in this row
 		for tileX := leftTile; tileX <= rightTile; tileX++ {
 			tile := l.GetTile(tileX, tileY)
 			
-			if tile.Type == TileEmpty {
+			if tile.Type == TileEmpty || tile.IsSlope() {
 				continue
 			}
 
 			// Get tile bounds
-			tileBounds := l.getTileBounds(tileX, tileY)
+			tileBounds := l.getTileShapeBounds(tile, tileX, tileY)
+			if tile.IsSolid() && tileBounds.Y >= stepLimit {
+				continue
+			}
+			
+			// Measure one-way tops crossing the box so movers can tell which side they came from
+			if tile.IsOneWay() && tileBounds.Y >= entityY && tileBounds.Y < entityBottom {
+				oneWayWidth += math.Min(entityX+entityWidth, tileBounds.X+tileBounds.Width) - math.Max(entityX, tileBounds.X)
+				oneWayTop = tileBounds.Y
+			}
 			
 			// Check if entity actually overlaps with this tile
 			if l.rectanglesOverlap(entityX, entityY, entityWidth, entityHeight,
 				tileBounds.X, tileBounds.Y, tileBounds.Width, tileBounds.Height) {
 				
-				l.processCollision(result, tile, entityX, entityY, entityWidth, entityHeight, tileBounds)
+				l.processCollision(result, tile, tileX, tileY, entityX, entityY, entityWidth, entityHeight, tileBounds)
 			}
 		}
+		
+		// Like ground contact, a one-way top only counts when it could support the entity.
+		// Rows are scanned top down, so the first one found is the highest.
+		if !result.OneWaySurface && oneWayWidth >= entityWidth*0.5 {
+			result.OneWaySurface = true
+			result.OneWaySurfaceY = oneWayTop
+		}
 	}
 	
-	// Additionally check for ground contact with tiles directly below the entity
-	if belowTile > bottomTile { // Only if we're not already checking this tile row
-		solidGroundWidth := 0.0 // Track how much solid ground is under the player
-		
+	// Additionally check for ground contact with tiles directly below the entity.
+	// Half tiles have their top inside a row, so the overlapped bottom row is checked for them too.
+	groundRows := [2]int{bottomTile, belowTile}
+	rowCount := 1
+	if belowTile > bottomTile {
+		rowCount = 2
+	}
+	solidGroundWidth := 0.0 // Track how much solid ground is under the player
+	for _, groundRow := range groundRows[:rowCount] {
 		for tileX := leftTile; tileX <= rightTile; tileX++ {
-			tile := l.GetTile(tileX, belowTile)
+			tile := l.GetTile(tileX, groundRow)
 			
-			if tile.Type == TileEmpty {
+			if tile.Type == TileEmpty || tile.IsSlope() {
+				continue
+			}
+			
+			// Full tiles in overlapped rows were handled above
+			if groundRow <= bottomTile && !tile.IsHalf() {
 				continue
 			}
 
 			// Get tile bounds
-			tileBounds := l.getTileBounds(tileX, belowTile)
+			tileBounds := l.getTileShapeBounds(tile, tileX, groundRow)
 			
 			// Check for ground contact (entity bottom touching tile top)
 			entityBottom := entityY + entityHeight
@@ -153,6 +242,9 @@ func (l *Level) CheckCollision(entityX, entityY, entityWidth, entityHeight float
 				
 				if overlapWidth > 0 && (tile.IsSolid() || tile.IsOneWay()) {
 					solidGroundWidth += overlapWidth
+					result.AddContact(tileX, groundRow, 0, -1)
+				} else {
+					result.AddContact(tileX, groundRow, 0, 0)
 				}
 				
 				// Process ground contact for other properties
@@ -176,20 +268,23 @@ func (l *Level) CheckCollision(entityX, entityY, entityWidth, entityHeight float
 			result.OnGround = true
 		}
 	}
-
-	return result
+	
+	if result.OnGround {
+		result.Material = l.groundMaterial(entityX, entityWidth, entityBottom)
+	}
 }
 
 // processCollision handles collision logic for a single tile
-func (l *Level) processCollision(result *CollisionResult, tile *Tile, entityX, entityY, entityWidth, entityHeight float64, tileBounds struct{ X, Y, Width, Height float64 }) {
+func (l *Level) processCollision(result *collision.Result, tile *Tile, tileX, tileY int, entityX, entityY, entityWidth, entityHeight float64, tileBounds struct{ X, Y, Width, Height float64 }) {
 	result.Collided = true
 	
 	// Calculate overlap amounts for all cases
 	overlapX := l.getOverlapX(entityX, entityWidth, tileBounds.X, tileBounds.Width)
 	overlapY := l.getOverlapY(entityY, entityHeight, tileBounds.Y, tileBounds.Height)
 	
-	// Set flags based on tile type and position
-	if tile.IsSolid() {
+	// Set flags based on tile type and position. One-way platforms never block from the
+	// side or from below, so they only take part in the ground check further down.
+	if tile.IsSolid() && !tile.IsOneWay() {
 		// Check if entity is on top of the tile (ground check)
 		entityBottom := entityY + entityHeight
 		tileTop := tileBounds.Y
@@ -199,6 +294,13 @@ func (l *Level) processCollision(result *CollisionResult, tile *Tile, entityX, e
 		// 2. A significant portion of the entity is over the solid tile
 		isOnGround := entityBottom >= tileTop && entityBottom <= tileTop + GroundTolerance
 		
+		if isOnGround {
+			result.AddContact(tileX, tileY, 0, -1)
+		} else {
+			normalX, normalY := l.pushOut(entityX, entityY, entityWidth, entityHeight, tileBounds, overlapX, overlapY)
+			result.AddContact(tileX, tileY, normalX, normalY)
+		}
+		
 		if isOnGround {
 			// Calculate how much of the entity overlaps with this tile horizontally
 			overlapLeft := math.Max(entityX, tileBounds.X)
@@ -256,15 +358,20 @@ func (l *Level) processCollision(result *CollisionResult, tile *Tile, entityX, e
 		entityBottom := entityY + entityHeight
 		tileTop := tileBounds.Y
 		
-		// Only collide if entity is coming from above and touching the surface
+		// Only support the entity when its feet are on the surface. This is never a
+		// CollisionY: an entity jumping up through the platform must not be stopped by it.
 		if entityBottom >= tileTop && entityBottom <= tileTop + GroundTolerance {
 			result.OnGround = true
 			result.OneWayPlatform = true
-			result.CollisionY = true
 			result.PenetrationY = entityBottom - tileTop
+			result.AddContact(tileX, tileY, 0, -1)
 		}
 	}
 	
+	// Tiles that don't block, and one-way platforms the entity is passing through, are
+	// touched without pushing it anywhere
+	result.AddContact(tileX, tileY, 0, 0)
+	
 	// Check for climbable surfaces
 	if tile.IsClimbable() {
 		result.ClimbableSurface = true
@@ -286,6 +393,59 @@ func (l *Level) getTileBounds(tileX, tileY int) struct{ X, Y, Width, Height floa
 	}
 }
 
+// getTileShapeBounds returns a tile's collision box, which is shorter than the cell for half tiles
+func (l *Level) getTileShapeBounds(tile *Tile, tileX, tileY int) struct{ X, Y, Width, Height float64 } {
+	bounds := l.getTileBounds(tileX, tileY)
+	if tile.IsHalf() {
+		height := tile.SurfaceHeight(0, bounds.Height)
+		bounds.Y += bounds.Height - height
+		bounds.Height = height
+	}
+	return bounds
+}
+
+// pushOut returns the normal of the tile side an overlapping entity is least embedded in
+func (l *Level) pushOut(entityX, entityY, entityWidth, entityHeight float64, tileBounds struct{ X, Y, Width, Height float64 }, overlapX, overlapY float64) (normalX, normalY float64) {
+	if overlapX < overlapY {
+		if entityX+entityWidth/2 < tileBounds.X+tileBounds.Width/2 {
+			return -1, 0
+		}
+		return 1, 0
+	}
+	if entityY+entityHeight/2 < tileBounds.Y+tileBounds.Height/2 {
+		return 0, -1
+	}
+	return 0, 1
+}
+
+// slopeSurface finds the highest slope surface in the column under worldX that an entity
+// with its feet at bottom is sunk into by up to one tile, or hovering above by up to half a
+// tile, and the grid coordinates of its tile
+func (l *Level) slopeSurface(worldX, bottom float64) (slopeX, slopeY int, surfaceY, gradient float64, found bool) {
+	tileSize := float64(l.TileSize)
+	tileX := int(math.Floor(worldX / tileSize))
+	localX := worldX - float64(tileX)*tileSize
+
+	minRow := int(math.Floor((bottom - tileSize) / tileSize))
+	maxRow := int(math.Floor((bottom + tileSize/2) / tileSize))
+	for tileY := minRow; tileY <= maxRow; tileY++ {
+		tile := l.GetTile(tileX, tileY)
+		if !tile.IsSlope() {
+			continue
+		}
+
+		y := float64(tileY+1)*tileSize - tile.SurfaceHeight(localX, tileSize)
+		penetration := bottom - y
+		if penetration < -tileSize/2 || penetration > tileSize {
+			continue
+		}
+		if !found || y < surfaceY {
+			slopeY, surfaceY, gradient, found = tileY, y, tile.SlopeGradient(), true
+		}
+	}
+	return tileX, slopeY, surfaceY, gradient, found
+}
+
 // Helper function to check if two rectangles overlap
 func (l *Level) rectanglesOverlap(x1, y1, w1, h1, x2, y2, w2, h2 float64) bool {
 	return x1 < x2+w2 && x1+w1 > x2 && y1 < y2+h2 && y1+h1 > y2
@@ -301,73 +461,40 @@ func (l *Level) getOverlapY(y1, h1, y2, h2 float64) float64 {
 	return math.Min(y1+h1, y2+h2) - math.Max(y1, y2)
 }
 
-// Draw renders the level (basic tile visualization)
+// Draw renders the level with the camera at the world origin
 func (l *Level) Draw(screen *ebiten.Image) {
-	// Draw background if available
-	if l.Background != nil {
-		screen.DrawImage(l.Background, &ebiten.DrawImageOptions{})
-	}
-	
-	// Draw tiles (simple colored rectangles for now)
-	for y := 0; y < l.Height; y++ {
-		for x := 0; x < l.Width; x++ {
-			tile := l.Tiles[y][x]
-			if tile.Type != TileEmpty {
-				l.drawTile(screen, tile, x, y)
-			}
-		}
-	}
+	l.DrawWithCamera(screen, 0, 0)
 }
 
-// drawTile draws a single tile with a color based on its type
-func (l *Level) drawTile(screen *ebiten.Image, tile *Tile, x, y int) {
-	tileImg := ebiten.NewImage(l.TileSize, l.TileSize)
-	
-	// Color based on tile type
-	switch tile.Type {
-	case TileSolid:
-		tileImg.Fill(color.RGBA{128, 128, 128, 255}) // Gray
-	case TileClimbable:
-		tileImg.Fill(color.RGBA{139, 69, 19, 255})   // Brown (like metal/wood)
-	case TileSpike:
-		tileImg.Fill(color.RGBA{255, 0, 0, 255})     // Red
-	case TileOneWay:
-		tileImg.Fill(color.RGBA{0, 255, 0, 255})     // Green
-	}
-	
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(float64(x*l.TileSize), float64(y*l.TileSize))
-	screen.DrawImage(tileImg, op)
+// DrawWithCamera renders every layer as seen from a camera whose top-left corner
+// is at (cameraX, cameraY) in world space
+func (l *Level) DrawWithCamera(screen *ebiten.Image, cameraX, cameraY float64) {
+	l.LayeredRenderer().Draw(screen, cameraX, cameraY)
 }
 
-// PlayerCollisionResult represents collision data in a format compatible with the player
-type PlayerCollisionResult struct {
-	Collided         bool
-	CollisionX       bool
-	CollisionY       bool
-	PenetrationX     float64
-	PenetrationY     float64
-	OnGround         bool
-	TouchingWall     bool
-	ClimbableSurface bool
-	DangerousTile    bool
-	OneWayPlatform   bool
+// DrawBehind renders the layers that belong behind the player
+func (l *Level) DrawBehind(screen *ebiten.Image, cameraX, cameraY float64) {
+	l.LayeredRenderer().DrawBehind(screen, cameraX, cameraY)
 }
 
-// CheckPlayerCollision checks collision and returns a player-compatible result
-func (l *Level) CheckPlayerCollision(entityX, entityY, entityWidth, entityHeight float64) *PlayerCollisionResult {
-	result := l.CheckCollision(entityX, entityY, entityWidth, entityHeight)
-	
-	return &PlayerCollisionResult{
-		Collided:         result.Collided,
-		CollisionX:       result.CollisionX,
-		CollisionY:       result.CollisionY,
-		PenetrationX:     result.PenetrationX,
-		PenetrationY:     result.PenetrationY,
-		OnGround:         result.OnGround,
-		TouchingWall:     result.TouchingWall,
-		ClimbableSurface: result.ClimbableSurface,
-		DangerousTile:    result.DangerousTile,
-		OneWayPlatform:   result.OneWayPlatform,
+// DrawFront renders the layers that belong in front of the player
+func (l *Level) DrawFront(screen *ebiten.Image, cameraX, cameraY float64) {
+	l.LayeredRenderer().DrawFront(screen, cameraX, cameraY)
+}
+
+// LayeredRenderer returns the level's layered renderer, creating it on first use
+func (l *Level) LayeredRenderer() *LayeredRenderer {
+	if l.layered == nil {
+		l.layered = NewLayeredRenderer(l)
+	}
+	return l.layered
+}
+
+// Renderer returns the level's tile renderer, creating it on first use
+func (l *Level) Renderer() *TileRenderer {
+	if l.renderer == nil {
+		l.renderer = NewTileRenderer(l, l.Tileset)
+		l.renderer.SetChunkCaching(true, DefaultChunkSize)
 	}
+	return l.renderer
 }
@@ -1,7 +1,10 @@
 package level
 
 import (
+	"math"
 	"testing"
+
+	"ebiten-platformer/collision"
 )
 
 func TestNewLevel(t *testing.T) {
@@ -132,6 +135,57 @@ func TestOneWayPlatform(t *testing.T) {
 	}
 }
 
+func TestOneWayPlatformFromBelow(t *testing.T) {
+	level := NewLevel(5, 5, 32, "Test")
+	level.SetTile(1, 2, TileOneWay) // Top at Y=64
+	
+	tests := []struct {
+		name     string
+		y        float64
+		onGround bool
+	}{
+		{"head inside the platform", 70, false},
+		{"feet just inside the top while rising", 64 - 32 + 1, true},
+		{"body across the platform", 50, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := level.CheckCollision(32, tt.y, 32, 32)
+			if result.CollisionX || result.CollisionY || result.TouchingWall {
+				t.Error("One-way platforms should never block from the side or below")
+			}
+			if result.OnGround != tt.onGround {
+				t.Errorf("Expected on ground %v, got %v", tt.onGround, result.OnGround)
+			}
+		})
+	}
+}
+
+func TestOneWaySurface(t *testing.T) {
+	level := NewLevel(5, 8, 32, "Test")
+	level.SetTile(1, 2, TileOneWay) // Top at Y=64
+	level.SetTile(1, 5, TileOneWay) // Top at Y=160
+	
+	// A tall box crossing both tops reports the higher one
+	result := level.CheckCollision(32, 40, 32, 150)
+	if !result.OneWaySurface || result.OneWaySurfaceY != 64 {
+		t.Errorf("Expected the highest one-way top at 64, got %v at %.1f", result.OneWaySurface, result.OneWaySurfaceY)
+	}
+	
+	// A box starting below the upper top only crosses the lower one
+	result = level.CheckCollision(32, 70, 32, 100)
+	if !result.OneWaySurface || result.OneWaySurfaceY != 160 {
+		t.Errorf("Expected the lower one-way top at 160, got %v at %.1f", result.OneWaySurface, result.OneWaySurfaceY)
+	}
+	
+	// Barely overlapping the platform can't support the entity
+	result = level.CheckCollision(32+24, 40, 32, 150)
+	if result.OneWaySurface {
+		t.Error("Expected no one-way surface when less than half the box is over the platform")
+	}
+}
+
 func TestClimbableSurface(t *testing.T) {
 	level := NewLevel(5, 5, 32, "Test")
 	level.SetTile(1, 1, TileClimbable)
@@ -187,3 +241,82 @@ func TestTileProperties(t *testing.T) {
 		t.Error("One-way tile properties incorrect")
 	}
 }
+
+func TestCollisionContacts(t *testing.T) {
+	level := newSweepTestLevel()
+	slopeNormal := -1 / math.Sqrt2
+
+	tests := []struct {
+		name     string
+		x, y     float64
+		contacts []collision.Contact
+	}{
+		{"standing on the floor", 64, 224, []collision.Contact{{TileX: 2, TileY: 8, NormalY: -1}}},
+		{"standing in a spike", 16, 224, []collision.Contact{
+			{TileX: 1, TileY: 7},
+			{TileX: 0, TileY: 8, NormalY: -1},
+			{TileX: 1, TileY: 8, NormalY: -1},
+		}},
+		{"pushed into a wall", 165, 200, []collision.Contact{
+			{TileX: 6, TileY: 6, NormalX: -1},
+			{TileX: 6, TileY: 7, NormalX: -1},
+		}},
+		{"head in the ceiling", 64, 90, []collision.Contact{{TileX: 2, TileY: 2, NormalY: 1}}},
+		{"standing on a slope", 128, 208, []collision.Contact{{TileX: 4, TileY: 7, NormalX: slopeNormal, NormalY: slopeNormal}}},
+		{"in the air", 64, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := level.CheckCollision(tt.x, tt.y, 32, 32)
+			if len(result.Contacts) != len(tt.contacts) {
+				t.Fatalf("Expected %d contacts, got %+v", len(tt.contacts), result.Contacts)
+			}
+			for _, want := range tt.contacts {
+				if !result.Touches(want.TileX, want.TileY) {
+					t.Errorf("Expected to touch tile (%d, %d), got %+v", want.TileX, want.TileY, result.Contacts)
+					continue
+				}
+				for _, got := range result.Contacts {
+					if got.TileX == want.TileX && got.TileY == want.TileY &&
+						(math.Abs(got.NormalX-want.NormalX) > 1e-9 || math.Abs(got.NormalY-want.NormalY) > 1e-9) {
+						t.Errorf("Tile (%d, %d): expected normal (%v, %v), got (%v, %v)",
+							want.TileX, want.TileY, want.NormalX, want.NormalY, got.NormalX, got.NormalY)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCollisionIntoReusesResult(t *testing.T) {
+	level := newSweepTestLevel()
+	var result collision.Result
+
+	level.CheckCollisionInto(16, 224, 32, 32, &result)
+	if !result.DangerousTile || len(result.Contacts) != 3 {
+		t.Fatalf("Expected a spike and two floor tiles, got %+v", result)
+	}
+
+	// A second check starts from a clean result
+	level.CheckCollisionInto(64, 0, 32, 32, &result)
+	if result.Collided || result.DangerousTile || len(result.Contacts) != 0 || result.Material != MaterialDefault {
+		t.Errorf("Expected an empty result in the air, got %+v", result)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		level.CheckCollisionInto(16, 224, 32, 32, &result)
+		level.CheckCollisionInto(128, 208, 32, 32, &result)
+		level.CheckCollisionInto(165, 200, 32, 32, &result)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected reused results not to allocate, got %v allocations per run", allocs)
+	}
+}
+
+func TestNewSpatialHash(t *testing.T) {
+	level := NewLevel(10, 10, 16, "Test")
+	if size := level.NewSpatialHash().CellSize(); size != 16 {
+		t.Errorf("Expected cells the size of a tile, got %v", size)
+	}
+}
@@ -0,0 +1,83 @@
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultLevelTileSize is the tile size of level data that doesn't give one
+const DefaultLevelTileSize = 32
+
+// LevelData is a whole level as written in level data
+type LevelData struct {
+	Name      string               `json:"name"`
+	TileSize  int                  `json:"tile_size"`  // Pixels; zero is DefaultLevelTileSize
+	TileTypes []TileTypeDefinition `json:"tile_types"` // Registered before the tiles are read, so the legend can use them
+	Legend    map[string]string    `json:"legend"`     // Tile type name for each character used in Tiles
+	Tiles     []string             `json:"tiles"`      // One string per row; ' ' and '.' are empty unless the legend says otherwise
+	Objects   []ObjectDefinition   `json:"objects"`
+}
+
+// LoadLevel reads a level from JSON level data. Its tile types are registered first, so
+// its tiles can use them and the level's renderer, created when it is first drawn, draws
+// them. loadSprite resolves tile type sprite paths, for example AssetManager.LoadImage.
+// Rows shorter than the longest are filled with empty tiles.
+func LoadLevel(r io.Reader, loadSprite func(path string) (*ebiten.Image, error)) (*Level, error) {
+	var data LevelData
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode level: %w", err)
+	}
+	if data.TileSize < 0 {
+		return nil, fmt.Errorf("level %q has a negative tile size", data.Name)
+	}
+	if data.TileSize == 0 {
+		data.TileSize = DefaultLevelTileSize
+	}
+	if len(data.Tiles) == 0 {
+		return nil, fmt.Errorf("level %q has no tiles", data.Name)
+	}
+
+	if _, err := registerTileTypes(data.TileTypes, loadSprite); err != nil {
+		return nil, fmt.Errorf("level %q: %w", data.Name, err)
+	}
+
+	legend := map[rune]TileType{' ': TileEmpty, '.': TileEmpty}
+	for key, name := range data.Legend {
+		symbol := []rune(key)
+		if len(symbol) != 1 {
+			return nil, fmt.Errorf("level %q has legend key %q, which isn't one character", data.Name, key)
+		}
+		tileType, exists := LookupTileType(name)
+		if !exists {
+			return nil, fmt.Errorf("level %q has legend %q for unknown tile type %q", data.Name, key, name)
+		}
+		legend[symbol[0]] = tileType
+	}
+
+	width := 0
+	for _, row := range data.Tiles {
+		width = max(width, len([]rune(row)))
+	}
+	lvl := NewLevel(width, len(data.Tiles), data.TileSize, data.Name)
+	for y, row := range data.Tiles {
+		for x, symbol := range []rune(row) {
+			tileType, exists := legend[symbol]
+			if !exists {
+				return nil, fmt.Errorf("level %q has %q at (%d, %d), which isn't in its legend", data.Name, symbol, x, y)
+			}
+			lvl.SetTile(x, y, tileType)
+		}
+	}
+
+	for _, def := range data.Objects {
+		object, err := def.Object()
+		if err != nil {
+			return nil, err
+		}
+		lvl.AddObject(object)
+	}
+	return lvl, nil
+}
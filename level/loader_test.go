@@ -0,0 +1,89 @@
+package level
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadLevel(t *testing.T) {
+	const data = `{
+		"name": "Loaded",
+		"tile_size": 16,
+		"tile_types": [{"name": "test_loaded_crate", "solid": true, "colour": "#aa7733"}],
+		"legend": {"#": "solid", "c": "test_loaded_crate", "^": "spike"},
+		"tiles": [
+			"#....#",
+			"# c^",
+			"######"
+		],
+		"objects": [{"type": "player_start", "x": 16, "y": 16}]
+	}`
+
+	lvl, err := LoadLevel(strings.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("LoadLevel failed: %v", err)
+	}
+	if lvl.Name != "Loaded" || lvl.Width != 6 || lvl.Height != 3 || lvl.TileSize != 16 {
+		t.Fatalf("Expected the 6x3 level \"Loaded\" of 16px tiles, got %q %dx%d of %dpx", lvl.Name, lvl.Width, lvl.Height, lvl.TileSize)
+	}
+
+	crate, exists := LookupTileType("test_loaded_crate")
+	if !exists {
+		t.Fatal("Expected the level's tile type to be registered")
+	}
+	tests := []struct {
+		x, y int
+		want TileType
+	}{
+		{0, 0, TileSolid},
+		{1, 0, TileEmpty},
+		{1, 1, TileEmpty},
+		{2, 1, crate},
+		{3, 1, TileSpike},
+		{5, 1, TileEmpty}, // Short rows are filled with empty tiles
+		{5, 2, TileSolid},
+	}
+	for _, tt := range tests {
+		if got := lvl.GetTile(tt.x, tt.y).Type; got != tt.want {
+			t.Errorf("Expected %v at (%d, %d), got %v", tt.want, tt.x, tt.y, got)
+		}
+	}
+	if !lvl.GetTile(2, 1).IsSolid() {
+		t.Error("Expected the loaded type's tiles to be solid")
+	}
+	if _, exists := lvl.PlayerStart(); !exists {
+		t.Error("Expected the level's objects to be placed")
+	}
+
+	// The renderer is created afterwards, so it has a colour for the new type
+	renderer := lvl.Renderer()
+	if index, exists := renderer.TypeIndices[crate]; !exists || renderer.Tileset().Tile(index) == nil {
+		t.Error("Expected the renderer to draw the level's tile type")
+	}
+}
+
+func TestLoadLevel_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"invalid JSON", `{"tiles": }`, "failed to decode"},
+		{"no tiles", `{"name": "Empty"}`, `level "Empty" has no tiles`},
+		{"negative tile size", `{"name": "Small", "tile_size": -1, "tiles": ["#"]}`, "negative tile size"},
+		{"bad tile type", `{"name": "Odd", "tile_types": [{"name": "test_bad", "shape": "star"}], "tiles": ["."]}`, `unknown shape "star"`},
+		{"long legend key", `{"name": "Odd", "legend": {"##": "solid"}, "tiles": ["##"]}`, `legend key "##"`},
+		{"unknown legend type", `{"name": "Odd", "legend": {"#": "granite"}, "tiles": ["#"]}`, `unknown tile type "granite"`},
+		{"character not in legend", `{"name": "Odd", "tiles": ["..", ".x"]}`, `'x' at (1, 1)`},
+		{"bad object", `{"name": "Odd", "tiles": ["."], "objects": [{"type": "boss"}]}`, `unknown type "boss"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadLevel(strings.NewReader(tt.data), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package level
+
+import "ebiten-platformer/collision"
+
+// Built-in materials. A tile's material changes how entities move while standing on it.
+var (
+	MaterialDefault   = collision.DefaultMaterial
+	MaterialIce       = collision.Material{Name: "ice", Friction: 0.1, SpeedScale: 1, JumpScale: 1}
+	MaterialConveyor  = collision.Material{Name: "conveyor", Friction: 1, SpeedScale: 1, JumpScale: 1}
+	MaterialBouncePad = collision.Material{Name: "bounce_pad", Friction: 1, LaunchSpeed: 380, SpeedScale: 1, JumpScale: 1}
+	MaterialMud       = collision.Material{Name: "mud", Friction: 1.5, SpeedScale: 0.5, JumpScale: 0.6}
+)
+
+// DefaultConveyorSpeed is the belt speed of the built-in conveyor tiles in pixels per second
+const DefaultConveyorSpeed = 60.0
+
+// Materials are the built-in materials by name, for tile type definitions to start from
+var Materials = map[string]collision.Material{
+	MaterialDefault.Name:   MaterialDefault,
+	MaterialIce.Name:       MaterialIce,
+	MaterialConveyor.Name:  MaterialConveyor,
+	MaterialBouncePad.Name: MaterialBouncePad,
+	MaterialMud.Name:       MaterialMud,
+}
+
+// conveyor returns the conveyor material moving at a speed
+func conveyor(speed float64) collision.Material {
+	material := MaterialConveyor
+	material.ConveyorSpeed = speed
+	return material
+}
+
+// groundMaterial returns the material of the ground under an entity's feet. The tile under
+// the bottom-centre wins; the quarter points are tried when the centre is over a gap.
+func (l *Level) groundMaterial(entityX, entityWidth, entityBottom float64) collision.Material {
+	for _, fraction := range []float64{0.5, 0.25, 0.75} {
+		tile := l.GetTileAtWorldPos(entityX+entityWidth*fraction, entityBottom)
+		if tile.IsSolid() {
+			return tile.Properties().Material
+		}
+	}
+	return MaterialDefault
+}
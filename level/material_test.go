@@ -0,0 +1,76 @@
+package level
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollisionReportsGroundMaterial(t *testing.T) {
+	level := NewLevel(6, 5, 32, "Materials")
+	level.SetTile(0, 3, TileSolid)
+	level.SetTile(1, 3, TileIce)
+	level.SetTile(2, 3, TileConveyorRight)
+	level.SetTile(3, 3, TileMud)
+	level.SetTile(4, 3, TileSlopeRight45)
+
+	tests := []struct {
+		name     string
+		x, y     float64
+		material string
+	}{
+		{"solid ground", 0, 64, "default"},
+		{"ice", 32, 64, "ice"},
+		{"sunk into mud", 96, 65, "mud"},
+		{"mostly over the conveyor", 70, 64, "conveyor"},
+		{"slope", 136, 112 - 32, "default"},
+		{"in the air", 32, 20, "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := level.CheckCollision(tt.x, tt.y, 32, 32)
+			if result.Material.Name != tt.material {
+				t.Errorf("Expected material %q, got %q (on ground=%v)", tt.material, result.Material.Name, result.OnGround)
+			}
+		})
+	}
+
+	result := level.CheckCollision(64, 64, 32, 32)
+	if result.Material.ConveyorSpeed != DefaultConveyorSpeed {
+		t.Errorf("Expected the right conveyor to move at %v, got %v", DefaultConveyorSpeed, result.Material.ConveyorSpeed)
+	}
+}
+
+func TestTileTypeDefinitionMaterial(t *testing.T) {
+	data := `[
+		{"name": "test_fast_ice", "solid": true, "material": "ice", "speed_scale": 1.5},
+		{"name": "test_trampoline", "solid": true, "bounciness": 0.8}
+	]`
+
+	types, err := LoadTileTypes(strings.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("Failed to load tile types: %v", err)
+	}
+
+	fastIce := types[0].Properties().Material
+	if fastIce.Name != "ice" || fastIce.Friction != MaterialIce.Friction || fastIce.SpeedScale != 1.5 {
+		t.Errorf("Expected ice with a 1.5 speed scale, got %+v", fastIce)
+	}
+
+	trampoline := types[1].Properties().Material
+	if trampoline.Bounciness != 0.8 || trampoline.Friction != 1 || trampoline.JumpScale != 1 {
+		t.Errorf("Expected default ground with 0.8 bounciness, got %+v", trampoline)
+	}
+
+	if _, err := LoadTileTypes(strings.NewReader(`[{"name": "test_lava", "material": "lava"}]`), nil); err == nil {
+		t.Error("Expected an error for an unknown material")
+	}
+
+	registered, err := RegisterTileType(TileProperties{Name: "test_plain"})
+	if err != nil {
+		t.Fatalf("Failed to register tile type: %v", err)
+	}
+	if registered.Properties().Material != MaterialDefault {
+		t.Error("Expected a zero material to be registered as the default material")
+	}
+}
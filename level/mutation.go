@@ -0,0 +1,50 @@
+package level
+
+// ReplaceTile changes a tile while the level is being played, such as a block crumbling
+// away. Unlike SetTile, the level remembers the tile as it was built, so RestoreTile and
+// RestoreTiles can put it back. Listeners are told about the change as for SetTile.
+func (l *Level) ReplaceTile(x, y int, tileType TileType) {
+	if !l.IsValidCoord(x, y) {
+		return
+	}
+	index := y*l.Width + x
+	if _, exists := l.replaced[index]; !exists {
+		if l.replaced == nil {
+			l.replaced = make(map[int]*Tile)
+		}
+		l.replaced[index] = l.Tiles[y][x]
+	}
+	l.Tiles[y][x] = NewTile(tileType, x, y)
+	l.notifyTileChanged(x, y)
+}
+
+// RestoreTile puts back the tile at (x, y) as the level was built, keeping its tileset
+// index. It does nothing if ReplaceTile hasn't changed the tile.
+func (l *Level) RestoreTile(x, y int) {
+	if !l.IsValidCoord(x, y) {
+		return
+	}
+	index := y*l.Width + x
+	original, exists := l.replaced[index]
+	if !exists {
+		return
+	}
+	delete(l.replaced, index)
+	l.Tiles[y][x] = original
+	l.notifyTileChanged(x, y)
+}
+
+// RestoreTiles puts back every tile ReplaceTile has changed, such as when the player
+// respawns
+func (l *Level) RestoreTiles() {
+	for index := range l.replaced {
+		l.RestoreTile(index%l.Width, index/l.Width)
+	}
+}
+
+// IsReplaced returns whether ReplaceTile has changed the tile at (x, y) since it was
+// last restored
+func (l *Level) IsReplaced(x, y int) bool {
+	_, exists := l.replaced[y*l.Width+x]
+	return exists && l.IsValidCoord(x, y)
+}
@@ -0,0 +1,76 @@
+package level
+
+import "testing"
+
+func TestLevel_ReplaceAndRestoreTiles(t *testing.T) {
+	level := NewLevel(10, 10, 32, "Mutation")
+	level.SetTile(2, 5, TileCrumbling)
+	level.SetTile(3, 5, TileSolid)
+	level.Tiles[5][2].TileIndex = 7 // As autotiling would pick
+
+	var changed []int
+	level.AddTileChangeListener(func(x, y int) {
+		changed = append(changed, y*level.Width+x)
+	})
+
+	// Replacing remembers the tile as built, however often it changes
+	level.ReplaceTile(2, 5, TileEmpty)
+	level.ReplaceTile(2, 5, TileSpike)
+	if level.GetTile(2, 5).Type != TileSpike || !level.IsReplaced(2, 5) {
+		t.Fatalf("Expected a replaced spike, got %v (replaced=%v)", level.GetTile(2, 5).Type, level.IsReplaced(2, 5))
+	}
+	if result := level.CheckCollision(64, 128, 32, 32); !result.DangerousTile {
+		t.Error("Expected collision to see the replacement")
+	}
+	if len(changed) != 2 {
+		t.Errorf("Expected listeners to hear both changes, heard %d", len(changed))
+	}
+
+	level.RestoreTile(2, 5)
+	if tile := level.GetTile(2, 5); tile.Type != TileCrumbling || tile.TileIndex != 7 || level.IsReplaced(2, 5) {
+		t.Errorf("Expected the tile as built with its tileset index, got %v index %d", tile.Type, tile.TileIndex)
+	}
+
+	// Restoring a tile that wasn't replaced, or outside the level, changes nothing
+	changed = nil
+	level.RestoreTile(3, 5)
+	level.ReplaceTile(-1, 5, TileEmpty)
+	level.RestoreTile(10, 10)
+	if len(changed) != 0 || level.IsReplaced(-1, 5) {
+		t.Errorf("Expected no changes, heard %d", len(changed))
+	}
+
+	// Restoring them all puts the level back as built
+	level.ReplaceTile(2, 5, TileEmpty)
+	level.ReplaceTile(3, 5, TileEmpty)
+	level.ReplaceTile(4, 5, TileSolid)
+	level.RestoreTiles()
+	for x, want := range map[int]TileType{2: TileCrumbling, 3: TileSolid, 4: TileEmpty} {
+		if got := level.GetTile(x, 5).Type; got != want || level.IsReplaced(x, 5) {
+			t.Errorf("Expected tile %d restored to %v, got %v", x, want, got)
+		}
+	}
+}
+
+func TestLevel_SetTileReplacesTileAsBuilt(t *testing.T) {
+	level := NewLevel(10, 10, 32, "Mutation")
+	level.SetTile(2, 5, TileCrumbling)
+	level.ReplaceTile(2, 5, TileEmpty)
+
+	// Setting a replaced tile rebuilds the level there, so there's nothing to restore
+	level.SetTile(2, 5, TileSolid)
+	if level.IsReplaced(2, 5) {
+		t.Error("Expected SetTile to forget the replaced tile")
+	}
+	level.RestoreTiles()
+	if got := level.GetTile(2, 5).Type; got != TileSolid {
+		t.Errorf("Expected restoring to keep the tile SetTile placed, got %v", got)
+	}
+
+	// And it is the tile later replacements restore
+	level.ReplaceTile(2, 5, TileSpike)
+	level.RestoreTile(2, 5)
+	if got := level.GetTile(2, 5).Type; got != TileSolid {
+		t.Errorf("Expected the tile SetTile placed to be restored, got %v", got)
+	}
+}
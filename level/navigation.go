@@ -0,0 +1,239 @@
+package level
+
+import "math"
+
+// NavMode is how an agent gets around, for pathfinding
+type NavMode int
+
+const (
+	NavFlying     NavMode = iota // Moves freely in 8 directions through tiles that aren't solid
+	NavPlatformer                // Stands on the ground, and walks, falls and jumps between footholds
+)
+
+// NavProfile describes an agent for pathfinding. Agents with the same profile share a
+// navigation graph, so use the same values for agents that move alike.
+type NavProfile struct {
+	Mode          NavMode
+	Width, Height int     // Tiles the agent covers, from its top-left tile; 0 counts as 1
+	JumpHeight    float64 // Platformer: highest the agent can jump (px); 0 never jumps
+	Speed         float64 // Platformer: horizontal speed while jumping and falling (px/s)
+	Gravity       float64 // Platformer: downward acceleration while in the air (px/s²)
+}
+
+// normalised returns the profile with sizes of at least one tile and nothing a flying
+// agent ignores, so that equivalent profiles share a graph
+func (p NavProfile) normalised() NavProfile {
+	p.Width, p.Height = max(p.Width, 1), max(p.Height, 1)
+	if p.Mode == NavFlying {
+		p.JumpHeight, p.Speed, p.Gravity = 0, 0, 0
+	}
+	return p
+}
+
+// Move is how an agent gets from one step of a path to the next
+type Move int
+
+const (
+	MoveStart Move = iota // The first step of a path, where the agent already is
+	MoveFly               // Flying to a neighbouring tile
+	MoveWalk              // Walking along the ground, including up and down slopes
+	MoveFall              // Walking off a ledge and dropping
+	MoveJump              // Jumping, to JumpHeight above the take-off point
+)
+
+// String returns a readable name for the move
+func (m Move) String() string {
+	switch m {
+	case MoveStart:
+		return "Start"
+	case MoveFly:
+		return "Fly"
+	case MoveWalk:
+		return "Walk"
+	case MoveFall:
+		return "Fall"
+	case MoveJump:
+		return "Jump"
+	default:
+		return "Unknown"
+	}
+}
+
+// navClass is what a tile means to pathfinding. Changing a tile's type only affects
+// navigation graphs if its class changes.
+type navClass uint8
+
+const (
+	navOpen      navClass = iota // Can be moved through
+	navSolid                     // Blocks movement and can be stood on
+	navOneWay                    // Can be jumped up through and stood on
+	navSlope                     // Solid, and can be walked up and down diagonally
+	navDangerous                 // Harms anything that touches it, so is avoided
+)
+
+// classify returns what a tile means to pathfinding
+func classify(tile *Tile) navClass {
+	switch {
+	case tile.IsDangerous():
+		return navDangerous
+	case !tile.IsSolid():
+		return navOpen
+	case tile.IsOneWay():
+		return navOneWay
+	case tile.IsSlope():
+		return navSlope
+	default:
+		return navSolid
+	}
+}
+
+// navEdge is a move from one navigation node to another
+type navEdge struct {
+	to         int     // Node index: y*width + x of the agent's top-left tile
+	move       Move    // How the agent makes the move
+	cost       float64 // At least the distance between the nodes in tiles, so A* can use it
+	jumpHeight float64 // How high a jump goes (px)
+}
+
+// navGraph holds the moves out of every tile for one profile. Tiles the agent can't be in
+// have no edges.
+type navGraph struct {
+	profile NavProfile
+	edges   [][]navEdge
+}
+
+// Navigator finds paths through a level. Navigation graphs are built the first time a
+// profile is used and kept until Level.SetTile changes a tile's class, such as a solid
+// tile becoming empty. Changes made by writing to Level.Tiles directly aren't seen.
+type Navigator struct {
+	level   *Level
+	classes []navClass // Class of every tile when the graphs were built, nil before then
+	graphs  map[NavProfile]*navGraph
+}
+
+// NewNavigator creates a navigator for a level. Levels create their own with Navigator.
+func NewNavigator(level *Level) *Navigator {
+	n := &Navigator{level: level, graphs: make(map[NavProfile]*navGraph)}
+	level.AddTileChangeListener(func(x, y int) {
+		if n.classes == nil {
+			return
+		}
+		index := y*level.Width + x
+		if class := classify(level.Tiles[y][x]); class != n.classes[index] {
+			n.classes[index] = class
+			clear(n.graphs)
+		}
+	})
+	return n
+}
+
+// Navigator returns the level's navigator, creating it on first use
+func (l *Level) Navigator() *Navigator {
+	if l.navigator == nil {
+		l.navigator = NewNavigator(l)
+	}
+	return l.navigator
+}
+
+// graph returns the navigation graph for a profile, building it if needed
+func (n *Navigator) graph(profile NavProfile) *navGraph {
+	profile = profile.normalised()
+	if graph, ok := n.graphs[profile]; ok {
+		return graph
+	}
+
+	if n.classes == nil {
+		n.classes = make([]navClass, n.level.Width*n.level.Height)
+		for y, row := range n.level.Tiles {
+			for x, tile := range row {
+				n.classes[y*n.level.Width+x] = classify(tile)
+			}
+		}
+	}
+
+	graph := &navGraph{profile: profile, edges: make([][]navEdge, len(n.classes))}
+	for y := 0; y < n.level.Height; y++ {
+		for x := 0; x < n.level.Width; x++ {
+			if profile.Mode == NavFlying {
+				graph.edges[y*n.level.Width+x] = n.flyingEdges(profile, x, y)
+			} else {
+				graph.edges[y*n.level.Width+x] = n.platformerEdges(profile, x, y)
+			}
+		}
+	}
+	n.graphs[profile] = graph
+	return graph
+}
+
+// class returns the class of a tile, treating tiles outside the level as solid
+func (n *Navigator) class(x, y int) navClass {
+	if !n.level.IsValidCoord(x, y) {
+		return navSolid
+	}
+	return n.classes[y*n.level.Width+x]
+}
+
+// fits returns whether an agent of a profile's size with its top-left tile at (x, y)
+// covers only open tiles
+func (n *Navigator) fits(profile NavProfile, x, y int) bool {
+	for ty := y; ty < y+profile.Height; ty++ {
+		for tx := x; tx < x+profile.Width; tx++ {
+			if n.class(tx, ty) != navOpen {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// flyingEdges returns the moves to the 8 tiles around (x, y). Diagonal moves need both
+// tiles beside the corner to be clear, so agents don't cut corners through walls.
+func (n *Navigator) flyingEdges(profile NavProfile, x, y int) []navEdge {
+	if !n.fits(profile, x, y) {
+		return nil
+	}
+	var edges []navEdge
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if (dx == 0 && dy == 0) || !n.fits(profile, x+dx, y+dy) {
+				continue
+			}
+			if dx != 0 && dy != 0 && (!n.fits(profile, x+dx, y) || !n.fits(profile, x, y+dy)) {
+				continue
+			}
+			edges = append(edges, navEdge{
+				to:   (y+dy)*n.level.Width + x + dx,
+				move: MoveFly,
+				cost: math.Hypot(float64(dx), float64(dy)),
+			})
+		}
+	}
+	return edges
+}
+
+// standable returns whether an agent can stand with its top-left tile at (x, y): it fits
+// there and something under its feet holds it up
+func (n *Navigator) standable(profile NavProfile, x, y int) bool {
+	if !n.fits(profile, x, y) {
+		return false
+	}
+	for tx := x; tx < x+profile.Width; tx++ {
+		switch n.class(tx, y+profile.Height) {
+		case navSolid, navOneWay, navSlope:
+			if n.level.IsValidCoord(tx, y+profile.Height) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// onSlope returns whether any of the ground under an agent standing at (x, y) is a slope
+func (n *Navigator) onSlope(profile NavProfile, x, y int) bool {
+	for tx := x; tx < x+profile.Width; tx++ {
+		if n.class(tx, y+profile.Height) == navSlope {
+			return true
+		}
+	}
+	return false
+}
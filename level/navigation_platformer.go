@@ -0,0 +1,212 @@
+package level
+
+import "math"
+
+// Platformer navigation tuning
+const (
+	// jumpCost is added to every jump, so agents walk where they can
+	jumpCost = 1.0
+
+	// minJumpClearance is how far, in tiles, a short jump rises above its landing point.
+	// Agents try a short jump before a full one, to fit under low ceilings.
+	minJumpClearance = 0.5
+
+	// arcSamplesPerTile is how many points per tile travelled are checked along a jump or fall
+	arcSamplesPerTile = 4
+)
+
+// platformerEdges returns the walks, falls and jumps an agent standing at (x, y) can make
+func (n *Navigator) platformerEdges(profile NavProfile, x, y int) []navEdge {
+	if !n.standable(profile, x, y) {
+		return nil
+	}
+
+	var edges []navEdge
+	edge := func(toX, toY int, move Move, cost, jumpHeight float64) {
+		edges = append(edges, navEdge{to: toY*n.level.Width + toX, move: move, cost: cost, jumpHeight: jumpHeight})
+	}
+
+	// Walk to either side, diagonally on slopes, or step off a ledge and drop straight down
+	for _, dx := range [...]int{-1, 1} {
+		toX := x + dx
+		switch {
+		case n.standable(profile, toX, y):
+			edge(toX, y, MoveWalk, 1, 0)
+		case n.slopeWalk(profile, x, y, toX, y-1):
+			edge(toX, y-1, MoveWalk, math.Sqrt2, 0)
+		case n.slopeWalk(profile, x, y, toX, y+1):
+			edge(toX, y+1, MoveWalk, math.Sqrt2, 0)
+		case n.fits(profile, toX, y):
+			for toY := y + 1; n.fits(profile, toX, toY); toY++ {
+				if n.standable(profile, toX, toY) {
+					edge(toX, toY, MoveFall, float64(1+toY-y), 0)
+					break
+				}
+			}
+		}
+	}
+
+	if profile.JumpHeight <= 0 || profile.Gravity <= 0 || profile.Speed < 0 {
+		return edges
+	}
+
+	// Jump, or walk off and fall with some sideways drift, to footholds in reach. Work in
+	// tiles and seconds from here on.
+	tileSize := float64(n.level.TileSize)
+	gravity, speed := profile.Gravity/tileSize, profile.Speed/tileSize
+	jumpHeight := profile.JumpHeight / tileSize
+	launch := math.Sqrt(2 * gravity * jumpHeight)
+
+	for dy := -int(jumpHeight); y+dy < n.level.Height; dy++ {
+		// The longest flight to this row is a full jump, which bounds the reach
+		longest := (launch + math.Sqrt(launch*launch+2*gravity*float64(dy))) / gravity
+		reach := int(speed * longest)
+		for dx := -reach; dx <= reach; dx++ {
+			if dx == 0 && dy >= 0 {
+				continue
+			}
+			if !n.standable(profile, x+dx, y+dy) || (dy == 0 && n.walkable(profile, x, x+dx, y)) {
+				continue
+			}
+			distance := math.Hypot(float64(dx), float64(dy))
+			if dy > 0 && (dx < -1 || dx > 1) && n.canFall(profile, x, y, dx, dy, gravity, speed) {
+				step := float64(dx / abs(dx))
+				edge(x+dx, y+dy, MoveFall, 1+math.Hypot(float64(dx)-step, float64(dy)), 0)
+			} else if height, ok := n.canJump(profile, x, y, dx, dy, gravity, speed, jumpHeight); ok {
+				edge(x+dx, y+dy, MoveJump, distance+jumpCost, height*tileSize)
+			}
+		}
+	}
+	return edges
+}
+
+// slopeWalk returns whether an agent can walk diagonally from (x, y) to (toX, toY), up or
+// down a slope it is standing on or stepping onto
+func (n *Navigator) slopeWalk(profile NavProfile, x, y, toX, toY int) bool {
+	return n.standable(profile, toX, toY) && (n.onSlope(profile, x, y) || n.onSlope(profile, toX, toY))
+}
+
+// walkable returns whether an agent can walk along row y from column x to column toX,
+// in which case it needn't jump there
+func (n *Navigator) walkable(profile NavProfile, x, toX, y int) bool {
+	step := 1
+	if toX < x {
+		step = -1
+	}
+	for ; x != toX; x += step {
+		if !n.standable(profile, x+step, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// canJump returns whether an agent standing at (x, y) can jump to stand at (x+dx, y+dy),
+// and how high, in tiles, the jump goes. It tries a short jump and then a full one, each
+// steering sideways evenly, straight away or at the last moment.
+func (n *Navigator) canJump(profile NavProfile, x, y, dx, dy int, gravity, speed, jumpHeight float64) (float64, bool) {
+	rise := float64(-dy) // How much higher the landing is than the take-off
+	for _, height := range [...]float64{max(rise, 0) + minJumpClearance, jumpHeight} {
+		if height > jumpHeight || height < rise {
+			continue
+		}
+		launch := math.Sqrt(2 * gravity * height)
+		duration := (launch + math.Sqrt(max(launch*launch-2*gravity*rise, 0))) / gravity
+		footX := float64(x) + float64(profile.Width)/2
+		footY := float64(y + profile.Height)
+		if n.canFly(profile, footX, footY, float64(dx), launch, gravity, duration, speed) {
+			return height, true
+		}
+	}
+	return 0, false
+}
+
+// canFall returns whether an agent standing at (x, y) can walk off the ledge beside it and
+// drift down to stand at (x+dx, y+dy)
+func (n *Navigator) canFall(profile NavProfile, x, y, dx, dy int, gravity, speed float64) bool {
+	step := dx / abs(dx)
+	if !n.fits(profile, x+step, y) || n.standable(profile, x+step, y) {
+		return false
+	}
+	duration := math.Sqrt(2 * float64(dy) / gravity)
+	footX := float64(x+step) + float64(profile.Width)/2
+	footY := float64(y + profile.Height)
+	return n.canFly(profile, footX, footY, float64(dx-step), 0, gravity, duration, speed)
+}
+
+// canFly returns whether an agent whose feet leave (footX, footY) with an upward speed of
+// launch can cover dx tiles sideways at up to speed in the given time without touching
+// anything on the way. It tries steering evenly throughout, as early as possible and as
+// late as possible, so that jumps can clear ledges beside the take-off and the landing.
+func (n *Navigator) canFly(profile NavProfile, footX, footY, dx, launch, gravity, duration, speed float64) bool {
+	steering := math.Abs(dx) / speed // Time spent moving sideways at full speed
+	if dx == 0 {
+		steering = 0
+	} else if !(steering <= duration) {
+		return false
+	}
+
+	schedules := [...][2]float64{{0, duration}, {0, steering}, {duration - steering, duration}}
+	for i, schedule := range schedules {
+		if dx == 0 && i > 0 {
+			break
+		}
+		if n.flightClear(profile, footX, footY, dx, launch, gravity, duration, schedule[0], schedule[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// flightClear checks points along one flight, moving sideways only between steerStart and
+// steerEnd. Rising agents pass up through one-way platforms; falling ones would land on them.
+func (n *Navigator) flightClear(profile NavProfile, footX, footY, dx, launch, gravity, duration, steerStart, steerEnd float64) bool {
+	// Distance travelled: sideways, up to the apex and down to the landing
+	apex := launch * launch / (2 * gravity)
+	landing := launch*duration - gravity*duration*duration/2
+	travel := math.Abs(dx) + 2*apex - landing
+	samples := int(math.Ceil(travel*arcSamplesPerTile)) + 1
+
+	width, height := float64(profile.Width), float64(profile.Height)
+	for i := 1; i < samples; i++ {
+		t := duration * float64(i) / float64(samples)
+		steered := 1.0
+		if steerEnd > steerStart {
+			steered = min(max((t-steerStart)/(steerEnd-steerStart), 0), 1)
+		}
+		left := footX + dx*steered - width/2
+		top := footY - (launch*t - gravity*t*t/2) - height
+		if !n.boxClear(left, top, width, height, launch-gravity*t > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// boxClear returns whether a box in tile units only overlaps open tiles, or one-way
+// platforms while rising
+func (n *Navigator) boxClear(left, top, width, height float64, rising bool) bool {
+	const inset = 1e-6 // Boxes touching a tile's edge don't overlap it
+	for y := int(math.Floor(top + inset)); y <= int(math.Floor(top+height-inset)); y++ {
+		for x := int(math.Floor(left + inset)); x <= int(math.Floor(left+width-inset)); x++ {
+			switch n.class(x, y) {
+			case navOpen:
+			case navOneWay:
+				if !rising {
+					return false
+				}
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// abs returns the absolute value of an integer
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
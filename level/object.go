@@ -0,0 +1,290 @@
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ObjectType is what an object placed in a level is for
+type ObjectType int
+
+const (
+	ObjectPlayerStart ObjectType = iota // Where the player appears when the level loads
+	ObjectCheckpoint                    // Where the player reappears after touching it
+	ObjectCollectible                   // Something to pick up, such as an energy heart
+	ObjectEnemy                         // A hazard that moves by itself, such as a drone
+	ObjectTrigger                       // An invisible area that reacts to the player
+	ObjectExit                          // Completes the level when the player reaches it
+	ObjectNPC                           // A character the player can interact with, such as a cat
+	ObjectPlatform                      // A solid that moves along a path and carries the player
+	ObjectHazard                        // A trap that doesn't move by itself, such as falling debris
+	ObjectSwitch                        // Sends a signal while pressed or pulled, such as a lever
+	ObjectDoor                          // Tiles that open and close on a signal, such as an energy barrier
+	ObjectLogic                         // Combines or times signals, such as an AND gate
+)
+
+// objectTypeNames are the names used for object types in level data, indexed by type
+var objectTypeNames = []string{
+	ObjectPlayerStart: "player_start",
+	ObjectCheckpoint:  "checkpoint",
+	ObjectCollectible: "collectible",
+	ObjectEnemy:       "enemy",
+	ObjectTrigger:     "trigger",
+	ObjectExit:        "exit",
+	ObjectNPC:         "npc",
+	ObjectPlatform:    "platform",
+	ObjectHazard:      "hazard",
+	ObjectSwitch:      "switch",
+	ObjectDoor:        "door",
+	ObjectLogic:       "logic",
+}
+
+// String returns the object type's name in level data
+func (t ObjectType) String() string {
+	if t < 0 || int(t) >= len(objectTypeNames) {
+		return "unknown"
+	}
+	return objectTypeNames[t]
+}
+
+// LookupObjectType returns the object type with a name used in level data
+func LookupObjectType(name string) (ObjectType, bool) {
+	for objectType, typeName := range objectTypeNames {
+		if typeName == name {
+			return ObjectType(objectType), true
+		}
+	}
+	return 0, false
+}
+
+// Object is something placed in a level that isn't a tile. The game turns objects into
+// entities when the level loads.
+type Object struct {
+	Name          string            // Optional; lets other objects and the game refer to this one
+	Type          ObjectType        // What the object is for
+	Kind          string            // Which entity to spawn for the type, such as "heart" or "drone"
+	X, Y          float64           // Top-left corner in world pixels
+	Width, Height float64           // Size of areas such as triggers and exits; 0 for points
+	Properties    map[string]string // Type- and kind-specific settings
+}
+
+// GetBounds returns the object's area in world pixels
+func (o *Object) GetBounds() (x, y, width, height float64) {
+	return o.X, o.Y, o.Width, o.Height
+}
+
+// Key identifies the object within its level: its name, or its type and position if it
+// has none. Progress such as collected hearts is saved by key.
+func (o *Object) Key() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	return fmt.Sprintf("%s@%g,%g", o.Type, o.X, o.Y)
+}
+
+// Property returns the raw value of a property
+func (o *Object) Property(key string) (string, bool) {
+	value, exists := o.Properties[key]
+	return value, exists
+}
+
+// StringProperty returns a property, or fallback if the object doesn't have it
+func (o *Object) StringProperty(key, fallback string) string {
+	if value, exists := o.Properties[key]; exists {
+		return value
+	}
+	return fallback
+}
+
+// FloatProperty returns a numeric property, or fallback if the object doesn't have it
+func (o *Object) FloatProperty(key string, fallback float64) (float64, error) {
+	value, exists := o.Properties[key]
+	if !exists {
+		return fallback, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fallback, fmt.Errorf("property %q of %s is not a number: %q", key, o.describe(), value)
+	}
+	return number, nil
+}
+
+// IntProperty returns a whole-number property, or fallback if the object doesn't have it
+func (o *Object) IntProperty(key string, fallback int) (int, error) {
+	value, exists := o.Properties[key]
+	if !exists {
+		return fallback, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback, fmt.Errorf("property %q of %s is not a whole number: %q", key, o.describe(), value)
+	}
+	return number, nil
+}
+
+// BoolProperty returns a true/false property, or fallback if the object doesn't have it
+func (o *Object) BoolProperty(key string, fallback bool) (bool, error) {
+	value, exists := o.Properties[key]
+	if !exists {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback, fmt.Errorf("property %q of %s is not true or false: %q", key, o.describe(), value)
+	}
+	return b, nil
+}
+
+// Point is a position in world pixels
+type Point struct {
+	X, Y float64
+}
+
+// PointsProperty returns a list of points written as "x,y x,y ...", such as a patrol path,
+// or nil if the object doesn't have it
+func (o *Object) PointsProperty(key string) ([]Point, error) {
+	value, exists := o.Properties[key]
+	if !exists {
+		return nil, nil
+	}
+
+	var points []Point
+	for _, pair := range strings.Fields(value) {
+		x, y, found := strings.Cut(pair, ",")
+		px, errX := strconv.ParseFloat(x, 64)
+		py, errY := strconv.ParseFloat(y, 64)
+		if !found || errX != nil || errY != nil {
+			return nil, fmt.Errorf("property %q of %s is not a list of x,y points: %q", key, o.describe(), value)
+		}
+		points = append(points, Point{X: px, Y: py})
+	}
+	return points, nil
+}
+
+// NamesProperty returns a list of names written as "name name ...", such as the signals a
+// door is wired to, or nil if the object doesn't have it
+func (o *Object) NamesProperty(key string) []string {
+	value, exists := o.Properties[key]
+	if !exists {
+		return nil
+	}
+	return strings.Fields(value)
+}
+
+// describe names the object for error messages
+func (o *Object) describe() string {
+	if o.Name != "" {
+		return fmt.Sprintf("%s %q", o.Type, o.Name)
+	}
+	return fmt.Sprintf("%s at (%g, %g)", o.Type, o.X, o.Y)
+}
+
+// AddObject places a copy of an object in the level and returns it
+func (l *Level) AddObject(object Object) *Object {
+	placed := &object
+	l.Objects = append(l.Objects, placed)
+	return placed
+}
+
+// ObjectsOfType returns the level's objects of one type in placement order
+func (l *Level) ObjectsOfType(objectType ObjectType) []*Object {
+	var objects []*Object
+	for _, object := range l.Objects {
+		if object.Type == objectType {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// FindObject returns the first object with a name
+func (l *Level) FindObject(name string) (*Object, bool) {
+	for _, object := range l.Objects {
+		if object.Name == name {
+			return object, true
+		}
+	}
+	return nil, false
+}
+
+// PlayerStart returns where the player appears when the level loads: the first player
+// start object
+func (l *Level) PlayerStart() (*Object, bool) {
+	for _, object := range l.Objects {
+		if object.Type == ObjectPlayerStart {
+			return object, true
+		}
+	}
+	return nil, false
+}
+
+// ObjectDefinition is an object as written in level data. Property values may be strings,
+// numbers or booleans.
+type ObjectDefinition struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"` // An object type name such as "player_start"
+	Kind       string         `json:"kind"`
+	X          float64        `json:"x"`
+	Y          float64        `json:"y"`
+	Width      float64        `json:"width"`
+	Height     float64        `json:"height"`
+	Properties map[string]any `json:"properties"`
+}
+
+// Object converts a definition into an object
+func (def ObjectDefinition) Object() (Object, error) {
+	objectType, exists := LookupObjectType(def.Type)
+	if !exists {
+		return Object{}, fmt.Errorf("object %q has unknown type %q", def.Name, def.Type)
+	}
+
+	object := Object{
+		Name:   def.Name,
+		Type:   objectType,
+		Kind:   def.Kind,
+		X:      def.X,
+		Y:      def.Y,
+		Width:  def.Width,
+		Height: def.Height,
+	}
+	if len(def.Properties) > 0 {
+		object.Properties = make(map[string]string, len(def.Properties))
+	}
+	for key, value := range def.Properties {
+		switch value := value.(type) {
+		case string:
+			object.Properties[key] = value
+		case float64:
+			object.Properties[key] = strconv.FormatFloat(value, 'g', -1, 64)
+		case bool:
+			object.Properties[key] = strconv.FormatBool(value)
+		default:
+			return object, fmt.Errorf("property %q of %s has unsupported value %v", key, object.describe(), value)
+		}
+	}
+	return object, nil
+}
+
+// LoadObjects reads a JSON array of object definitions and places them in the level
+func (l *Level) LoadObjects(r io.Reader) error {
+	var defs []ObjectDefinition
+	if err := json.NewDecoder(r).Decode(&defs); err != nil {
+		return fmt.Errorf("failed to decode level objects: %w", err)
+	}
+
+	objects := make([]Object, 0, len(defs))
+	for _, def := range defs {
+		object, err := def.Object()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+	for _, object := range objects {
+		l.AddObject(object)
+	}
+	return nil
+}
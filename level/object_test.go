@@ -0,0 +1,233 @@
+package level
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestObjectTypeNames(t *testing.T) {
+	for _, objectType := range []ObjectType{ObjectPlayerStart, ObjectCheckpoint, ObjectCollectible, ObjectEnemy, ObjectTrigger, ObjectExit, ObjectNPC, ObjectPlatform, ObjectHazard, ObjectSwitch, ObjectDoor, ObjectLogic} {
+		found, exists := LookupObjectType(objectType.String())
+		if !exists || found != objectType {
+			t.Errorf("Expected %q to look up as itself, got %v (exists=%v)", objectType, found, exists)
+		}
+	}
+	if _, exists := LookupObjectType("boss"); exists {
+		t.Error("Expected an unknown name not to look up")
+	}
+	if ObjectType(99).String() != "unknown" {
+		t.Errorf("Expected an out-of-range type to be unknown, got %q", ObjectType(99))
+	}
+}
+
+func TestLevelObjects(t *testing.T) {
+	level := NewLevel(10, 10, 32, "Objects")
+	if _, exists := level.PlayerStart(); exists {
+		t.Error("Expected a new level to have no player start")
+	}
+
+	heart := Object{Name: "first heart", Type: ObjectCollectible, Kind: "heart", X: 64, Y: 96}
+	placed := level.AddObject(heart)
+	level.AddObject(Object{Type: ObjectPlayerStart, X: 32, Y: 32})
+	level.AddObject(Object{Type: ObjectCollectible, Kind: "heart", X: 128, Y: 96})
+	level.AddObject(Object{Type: ObjectPlayerStart, X: 200, Y: 32})
+
+	placed.X = 70
+	if heart.X != 64 {
+		t.Error("Expected the level to keep its own copy of an added object")
+	}
+
+	if start, exists := level.PlayerStart(); !exists || start.X != 32 {
+		t.Errorf("Expected the first player start, got %+v", start)
+	}
+	if hearts := level.ObjectsOfType(ObjectCollectible); len(hearts) != 2 || hearts[0] != placed {
+		t.Errorf("Expected two collectibles in placement order, got %d", len(hearts))
+	}
+	if found, exists := level.FindObject("first heart"); !exists || found != placed {
+		t.Error("Expected to find the heart by name")
+	}
+	if _, exists := level.FindObject("missing"); exists {
+		t.Error("Expected no object for an unused name")
+	}
+}
+
+func TestObjectProperties(t *testing.T) {
+	object := &Object{
+		Name: "drone",
+		Type: ObjectEnemy,
+		Properties: map[string]string{
+			"speed":  "42.5",
+			"health": "3",
+			"armed":  "true",
+			"path":   "left-right",
+			"broken": "fast",
+		},
+	}
+
+	if value, exists := object.Property("path"); !exists || value != "left-right" {
+		t.Errorf("Expected the raw path property, got %q", value)
+	}
+	if object.StringProperty("colour", "blue") != "blue" {
+		t.Error("Expected the fallback for a missing string property")
+	}
+
+	tests := []struct {
+		name    string
+		get     func() (any, error)
+		want    any
+		wantErr bool
+	}{
+		{"float", func() (any, error) { return object.FloatProperty("speed", 1) }, 42.5, false},
+		{"missing float", func() (any, error) { return object.FloatProperty("range", 80) }, 80.0, false},
+		{"bad float", func() (any, error) { return object.FloatProperty("broken", 1) }, 1.0, true},
+		{"int", func() (any, error) { return object.IntProperty("health", 1) }, 3, false},
+		{"fractional int", func() (any, error) { return object.IntProperty("speed", 1) }, 1, true},
+		{"bool", func() (any, error) { return object.BoolProperty("armed", false) }, true, false},
+		{"missing bool", func() (any, error) { return object.BoolProperty("asleep", true) }, true, false},
+		{"bad bool", func() (any, error) { return object.BoolProperty("broken", false) }, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if err != nil && !strings.Contains(err.Error(), `enemy "drone"`) {
+				t.Errorf("Expected the error to name the object, got %v", err)
+			}
+		})
+	}
+}
+
+func TestObjectPointsProperty(t *testing.T) {
+	object := &Object{
+		Name: "drone",
+		Type: ObjectEnemy,
+		Properties: map[string]string{
+			"waypoints": " 64,128  96.5,-32\t200,0 ",
+			"single":    "10,20",
+			"broken":    "64,128 96",
+			"letters":   "a,b",
+		},
+	}
+
+	tests := []struct {
+		key     string
+		want    []Point
+		wantErr bool
+	}{
+		{"waypoints", []Point{{64, 128}, {96.5, -32}, {200, 0}}, false},
+		{"single", []Point{{10, 20}}, false},
+		{"missing", nil, false},
+		{"broken", nil, true},
+		{"letters", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := object.PointsProperty(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if err != nil && !strings.Contains(err.Error(), `enemy "drone"`) {
+				t.Errorf("Expected the error to name the object, got %v", err)
+			}
+		})
+	}
+}
+
+func TestObjectNamesProperty(t *testing.T) {
+	object := &Object{
+		Name:       "gate",
+		Type:       ObjectLogic,
+		Properties: map[string]string{"inputs": " plate-a\tlever-b  ", "blank": ""},
+	}
+
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"inputs", []string{"plate-a", "lever-b"}},
+		{"blank", nil},
+		{"missing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := object.NamesProperty(tt.key); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadObjects(t *testing.T) {
+	const data = `[
+		{"type": "player_start", "x": 64, "y": 480},
+		{"name": "heart-1", "type": "collectible", "kind": "heart", "x": 320, "y": 200,
+		 "properties": {"bob_height": 4, "hidden": false, "colour": "#ff66aa"}},
+		{"name": "goal", "type": "exit", "x": 900, "y": 416, "width": 32, "height": 64}
+	]`
+
+	level := NewLevel(30, 20, 32, "Loaded")
+	if err := level.LoadObjects(strings.NewReader(data)); err != nil {
+		t.Fatalf("LoadObjects failed: %v", err)
+	}
+	if len(level.Objects) != 3 {
+		t.Fatalf("Expected 3 objects, got %d", len(level.Objects))
+	}
+
+	heart, _ := level.FindObject("heart-1")
+	if heart.Type != ObjectCollectible || heart.Kind != "heart" || heart.X != 320 {
+		t.Errorf("Unexpected heart %+v", heart)
+	}
+	if bob, err := heart.FloatProperty("bob_height", 0); err != nil || bob != 4 {
+		t.Errorf("Expected a numeric property, got %v (%v)", bob, err)
+	}
+	if hidden, err := heart.BoolProperty("hidden", true); err != nil || hidden {
+		t.Errorf("Expected a boolean property, got %v (%v)", hidden, err)
+	}
+	if heart.StringProperty("colour", "") != "#ff66aa" {
+		t.Errorf("Expected a string property, got %q", heart.StringProperty("colour", ""))
+	}
+
+	goal, _ := level.FindObject("goal")
+	if _, _, width, height := goal.GetBounds(); width != 32 || height != 64 {
+		t.Errorf("Expected the exit to be 32x64, got %vx%v", width, height)
+	}
+}
+
+func TestLoadObjectsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"invalid JSON", `[{"type": }]`, "failed to decode"},
+		{"unknown type", `[{"name": "bob", "type": "boss"}]`, `unknown type "boss"`},
+		{"nested property", `[{"type": "enemy", "x": 5, "y": 6, "properties": {"path": [1, 2]}}]`, `enemy at (5, 6)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := NewLevel(10, 10, 32, "Broken")
+			level.AddObject(Object{Type: ObjectPlayerStart})
+
+			err := level.LoadObjects(strings.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+			if len(level.Objects) != 1 {
+				t.Errorf("Expected a failed load to leave the level unchanged, got %d objects", len(level.Objects))
+			}
+		})
+	}
+}
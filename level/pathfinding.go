@@ -0,0 +1,114 @@
+package level
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+)
+
+// PathStep is one step along a path: where the agent's top-left tile is after a move
+type PathStep struct {
+	X, Y       int     // Grid coordinates of the agent's top-left tile
+	Move       Move    // How the agent got here from the previous step
+	JumpHeight float64 // For jumps, how high above the take-off point to jump (px)
+}
+
+// FindPath finds the shortest path for an agent from the tile at (fromX, fromY) to the tile
+// at (toX, toY), both the agent's top-left tile, using A* over the profile's navigation
+// graph. The path starts where the agent is and ends at the destination. Platformer agents
+// start and finish on the ground, so positions in the air are moved down onto whatever is
+// below them. It returns nil if the agent can't get there.
+func (n *Navigator) FindPath(profile NavProfile, fromX, fromY, toX, toY int) []PathStep {
+	graph := n.graph(profile)
+	start, ok := n.node(graph.profile, fromX, fromY)
+	if !ok {
+		return nil
+	}
+	goal, ok := n.node(graph.profile, toX, toY)
+	if !ok {
+		return nil
+	}
+
+	width := n.level.Width
+	goalX, goalY := float64(goal%width), float64(goal/width)
+	estimate := func(node int) float64 {
+		return math.Hypot(float64(node%width)-goalX, float64(node/width)-goalY)
+	}
+
+	costs := make([]float64, len(graph.edges))
+	for i := range costs {
+		costs[i] = math.Inf(1)
+	}
+	previous := make([]int, len(graph.edges))
+	arrivals := make([]navEdge, len(graph.edges))
+
+	costs[start] = 0
+	open := &pathQueue{{node: start, priority: estimate(start)}}
+	for open.Len() > 0 {
+		current := heap.Pop(open).(pathEntry)
+		if current.node == goal {
+			break
+		}
+		if current.priority > costs[current.node]+estimate(current.node) {
+			continue // A cheaper way here was already expanded
+		}
+		for _, edge := range graph.edges[current.node] {
+			cost := costs[current.node] + edge.cost
+			if cost >= costs[edge.to] {
+				continue
+			}
+			costs[edge.to] = cost
+			previous[edge.to] = current.node
+			arrivals[edge.to] = edge
+			heap.Push(open, pathEntry{node: edge.to, priority: cost + estimate(edge.to)})
+		}
+	}
+	if math.IsInf(costs[goal], 1) {
+		return nil
+	}
+
+	var path []PathStep
+	for node := goal; node != start; node = previous[node] {
+		arrival := arrivals[node]
+		path = append(path, PathStep{X: node % width, Y: node / width, Move: arrival.move, JumpHeight: arrival.jumpHeight})
+	}
+	path = append(path, PathStep{X: start % width, Y: start / width, Move: MoveStart})
+	slices.Reverse(path)
+	return path
+}
+
+// node returns the navigation node for an agent at (x, y). Flying agents must fit there;
+// platformer agents drop to the ground below, through tiles they fit in.
+func (n *Navigator) node(profile NavProfile, x, y int) (int, bool) {
+	if profile.Mode == NavFlying {
+		return y*n.level.Width + x, n.fits(profile, x, y)
+	}
+	for ; n.fits(profile, x, y); y++ {
+		if n.standable(profile, x, y) {
+			return y*n.level.Width + x, true
+		}
+	}
+	return 0, false
+}
+
+// pathEntry is a node waiting to be expanded, with its cost so far plus its estimated
+// cost to the goal
+type pathEntry struct {
+	node     int
+	priority float64
+}
+
+// pathQueue is a priority queue of nodes for A*, cheapest first
+type pathQueue []pathEntry
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x any)        { *q = append(*q, x.(pathEntry)) }
+
+func (q *pathQueue) Pop() any {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,290 @@
+package level
+
+import (
+	"slices"
+	"testing"
+)
+
+// newFlyingLevel creates a 10x8 level split by a wall in column 4 that leaves a two tile
+// gap at the bottom, with two blocks meeting corner to corner at (6, 2) and (7, 3)
+func newFlyingLevel() *Level {
+	level := NewLevel(10, 8, 32, "Flying")
+	for y := 0; y <= 5; y++ {
+		level.SetTile(4, y, TileSolid)
+	}
+	level.SetTile(6, 2, TileSolid)
+	level.SetTile(7, 3, TileSolid)
+	return level
+}
+
+// newPlatformerLevel creates a 20x12 level with ground along row 11 and:
+//
+//	columns 8-9: a gap in the ground, down out of the level
+//	columns 2-4: a one-way platform in row 9
+//	columns 12-13: a block two tiles high
+//	column 17: a pillar five tiles high
+func newPlatformerLevel() *Level {
+	level := NewLevel(20, 12, 32, "Platformer")
+	for x := 0; x < level.Width; x++ {
+		if x != 8 && x != 9 {
+			level.SetTile(x, 11, TileSolid)
+		}
+	}
+	for x := 2; x <= 4; x++ {
+		level.SetTile(x, 9, TileOneWay)
+	}
+	for y := 9; y <= 10; y++ {
+		level.SetTile(12, y, TileSolid)
+		level.SetTile(13, y, TileSolid)
+	}
+	for y := 6; y <= 10; y++ {
+		level.SetTile(17, y, TileSolid)
+	}
+	return level
+}
+
+// jumper jumps 2.5 tiles high and runs at about 4 tiles a second
+var jumper = NavProfile{Mode: NavPlatformer, JumpHeight: 80, Speed: 120, Gravity: 500}
+
+func TestFindPath_Flying(t *testing.T) {
+	level := newFlyingLevel()
+	drone := NavProfile{Mode: NavFlying}
+
+	tests := []struct {
+		name                   string
+		fromX, fromY, toX, toY int
+		profile                NavProfile
+		want                   int // Steps in the path, 0 for none
+	}{
+		{"diagonally across open space", 5, 7, 9, 4, drone, 5},
+		{"round the bottom of the wall", 1, 1, 7, 1, drone, 15},
+		{"not between corners touching", 6, 3, 7, 2, drone, 7},
+		{"to where it is", 2, 2, 2, 2, drone, 1},
+		{"into the wall", 1, 1, 4, 2, drone, 0},
+		{"from outside the level", -1, 1, 2, 2, drone, 0},
+		{"too big for the gap", 1, 1, 7, 1, NavProfile{Mode: NavFlying, Width: 3, Height: 3}, 0},
+		{"big enough to fill the gap", 0, 0, 8, 0, NavProfile{Mode: NavFlying, Width: 2, Height: 2}, 17},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := level.Navigator().FindPath(tt.profile, tt.fromX, tt.fromY, tt.toX, tt.toY)
+			if len(path) != tt.want {
+				t.Fatalf("Expected %d steps, got %v", tt.want, path)
+			}
+			if tt.want == 0 {
+				return
+			}
+			if first, last := path[0], path[len(path)-1]; first != (PathStep{X: tt.fromX, Y: tt.fromY}) ||
+				last.X != tt.toX || last.Y != tt.toY {
+				t.Errorf("Expected a path from (%d, %d) to (%d, %d), got %v", tt.fromX, tt.fromY, tt.toX, tt.toY, path)
+			}
+
+			nav := level.Navigator()
+			profile := tt.profile.normalised()
+			for i, step := range path[1:] {
+				from := path[i]
+				dx, dy := step.X-from.X, step.Y-from.Y
+				if step.Move != MoveFly || abs(dx) > 1 || abs(dy) > 1 {
+					t.Fatalf("Expected flights to neighbouring tiles, got %v", path)
+				}
+				if !nav.fits(profile, step.X, step.Y) {
+					t.Fatalf("Expected the path through open tiles, got %v", path)
+				}
+				if dx != 0 && dy != 0 && (!nav.fits(profile, from.X+dx, from.Y) || !nav.fits(profile, from.X, from.Y+dy)) {
+					t.Fatalf("Expected the path not to cut corners, got %v", path)
+				}
+			}
+		})
+	}
+}
+
+func TestFindPath_Platformer(t *testing.T) {
+	level := newPlatformerLevel()
+	walker := NavProfile{Mode: NavPlatformer}
+
+	tests := []struct {
+		name                   string
+		fromX, fromY, toX, toY int
+		profile                NavProfile
+		wantEnd                [2]int // Where the path ends
+		wantMoves              []Move // The moves made, ignoring repeats, or nil for no path
+	}{
+		{"walking along the ground", 0, 10, 5, 10, jumper, [2]int{5, 10}, []Move{MoveStart, MoveWalk}},
+		{"jumping onto a block", 10, 10, 12, 8, jumper, [2]int{12, 8}, []Move{MoveStart, MoveJump}},
+		{"falling off a block", 12, 8, 15, 10, jumper, [2]int{15, 10}, []Move{MoveStart, MoveWalk, MoveFall}},
+		{"jumping a gap", 5, 10, 11, 10, jumper, [2]int{11, 10}, []Move{MoveStart, MoveWalk, MoveJump}},
+		{"jumping up through a one-way platform", 3, 10, 3, 8, jumper, [2]int{3, 8}, []Move{MoveStart, MoveJump}},
+		{"landing from the air", 1, 3, 0, 10, jumper, [2]int{0, 10}, []Move{MoveStart, MoveWalk}},
+		{"up a pillar too high to jump", 16, 10, 17, 5, jumper, [2]int{}, nil},
+		{"into the gap", 5, 10, 8, 5, jumper, [2]int{}, nil},
+		{"across a gap without jumping", 5, 10, 11, 10, walker, [2]int{}, nil},
+		{"off a block without jumping", 13, 8, 15, 10, walker, [2]int{15, 10}, []Move{MoveStart, MoveFall, MoveWalk}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := level.Navigator().FindPath(tt.profile, tt.fromX, tt.fromY, tt.toX, tt.toY)
+			if tt.wantMoves == nil {
+				if path != nil {
+					t.Fatalf("Expected no path, got %v", path)
+				}
+				return
+			}
+			if len(path) == 0 {
+				t.Fatal("Expected a path, got none")
+			}
+			if last := path[len(path)-1]; last.X != tt.wantEnd[0] || last.Y != tt.wantEnd[1] {
+				t.Errorf("Expected the path to end at %v, got %v", tt.wantEnd, path)
+			}
+
+			var moves []Move
+			for _, step := range path {
+				if !level.Navigator().standable(tt.profile.normalised(), step.X, step.Y) {
+					t.Fatalf("Expected every step on the ground, got %v", path)
+				}
+				if step.Move == MoveJump && (step.JumpHeight <= 0 || step.JumpHeight > tt.profile.JumpHeight) {
+					t.Errorf("Expected jumps no higher than %v, got %v", tt.profile.JumpHeight, step.JumpHeight)
+				}
+				if len(moves) == 0 || moves[len(moves)-1] != step.Move {
+					moves = append(moves, step.Move)
+				}
+			}
+			if !slices.Equal(moves, tt.wantMoves) {
+				t.Errorf("Expected moves %v, got %v", tt.wantMoves, path)
+			}
+		})
+	}
+}
+
+func TestFindPath_Slopes(t *testing.T) {
+	// Ground along row 4 with a 45° slope up to a plateau in row 3
+	level := NewLevel(8, 5, 32, "Slopes")
+	for x := 0; x < level.Width; x++ {
+		level.SetTile(x, 4, TileSolid)
+	}
+	level.SetTile(3, 3, TileSlopeRight45)
+	for x := 4; x < level.Width; x++ {
+		level.SetTile(x, 3, TileSolid)
+	}
+
+	walker := NavProfile{Mode: NavPlatformer}
+	path := level.Navigator().FindPath(walker, 0, 3, 6, 2)
+	want := []PathStep{{0, 3, MoveStart, 0}, {1, 3, MoveWalk, 0}, {2, 3, MoveWalk, 0}, {3, 2, MoveWalk, 0},
+		{4, 2, MoveWalk, 0}, {5, 2, MoveWalk, 0}, {6, 2, MoveWalk, 0}}
+	if !slices.Equal(path, want) {
+		t.Errorf("Expected to walk up the slope, got %v", path)
+	}
+
+	path = level.Navigator().FindPath(walker, 6, 2, 0, 3)
+	if len(path) != len(want) || path[3] != (PathStep{3, 2, MoveWalk, 0}) || path[4] != (PathStep{2, 3, MoveWalk, 0}) {
+		t.Errorf("Expected to walk down the slope, got %v", path)
+	}
+}
+
+func TestFindPath_LowCeiling(t *testing.T) {
+	// A step up in a corridor three tiles high: a full jump hits the ceiling, a short one fits
+	level := NewLevel(8, 6, 32, "Low Ceiling")
+	for x := 0; x < level.Width; x++ {
+		level.SetTile(x, 1, TileSolid)
+		level.SetTile(x, 5, TileSolid)
+	}
+	for x := 4; x < level.Width; x++ {
+		level.SetTile(x, 4, TileSolid)
+	}
+
+	path := level.Navigator().FindPath(jumper, 2, 4, 5, 3)
+	i := slices.IndexFunc(path, func(step PathStep) bool { return step.Move == MoveJump })
+	if i < 0 {
+		t.Fatalf("Expected a jump up the step, got %v", path)
+	}
+	if want := (1 + minJumpClearance) * 32; path[i].JumpHeight != want {
+		t.Errorf("Expected a short jump of %vpx under the ceiling, got %v", want, path[i].JumpHeight)
+	}
+
+	// Lowering the ceiling leaves no room to jump up the step at all
+	for x := 0; x < level.Width; x++ {
+		level.SetTile(x, 2, TileSolid)
+	}
+	if path := level.Navigator().FindPath(jumper, 2, 4, 5, 3); path != nil {
+		t.Errorf("Expected no jump in a corridor two tiles high, got %v", path)
+	}
+}
+
+func TestNavigator_InvalidatesOnTileChanges(t *testing.T) {
+	level := newFlyingLevel()
+	nav := level.Navigator()
+	drone := NavProfile{Mode: NavFlying}
+
+	if nav.FindPath(drone, 1, 1, 7, 1) == nil {
+		t.Fatal("Expected a path round the wall")
+	}
+	if nav.FindPath(NavProfile{Mode: NavFlying, Width: 1, Height: 1, Speed: 50}, 1, 1, 7, 1) == nil || len(nav.graphs) != 1 {
+		t.Errorf("Expected equivalent profiles to share a graph, got %d graphs", len(nav.graphs))
+	}
+
+	// A change to a tile of the same class keeps the graph
+	level.SetTile(4, 0, TileIce)
+	if len(nav.graphs) != 1 {
+		t.Error("Expected the graph kept when a solid tile stays solid")
+	}
+
+	// Closing the gap under the wall cuts the level in two
+	level.SetTile(4, 6, TileSolid)
+	level.SetTile(4, 7, TileSolid)
+	if len(nav.graphs) != 0 {
+		t.Error("Expected graphs dropped when a tile becomes solid")
+	}
+	if path := nav.FindPath(drone, 1, 1, 7, 1); path != nil {
+		t.Errorf("Expected no path through the closed gap, got %v", path)
+	}
+
+	// Opening a hole in the wall makes a shorter way through
+	level.SetTile(4, 1, TileEmpty)
+	if path := nav.FindPath(drone, 1, 1, 7, 1); len(path) != 7 {
+		t.Errorf("Expected a straight path through the hole, got %v", path)
+	}
+}
+
+// BenchmarkFindPath_Flying flies across the test level, over and round its platforms
+func BenchmarkFindPath_Flying(b *testing.B) {
+	level := CreateTestLevel()
+	drone := NavProfile{Mode: NavFlying}
+	if level.Navigator().FindPath(drone, 1, 16, 28, 2) == nil {
+		b.Fatal("Expected a path")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		level.Navigator().FindPath(drone, 1, 16, 28, 2)
+	}
+}
+
+// BenchmarkFindPath_Platformer climbs onto the test level's left platform and jumps the
+// spikes on the right
+func BenchmarkFindPath_Platformer(b *testing.B) {
+	level := CreateTestLevel()
+	climber := NavProfile{Mode: NavPlatformer, JumpHeight: 112, Speed: 120, Gravity: 500}
+	if level.Navigator().FindPath(climber, 1, 17, 10, 14) == nil || level.Navigator().FindPath(climber, 13, 17, 28, 17) == nil {
+		b.Fatal("Expected paths")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		level.Navigator().FindPath(climber, 1, 17, 10, 14)
+		level.Navigator().FindPath(climber, 13, 17, 28, 17)
+	}
+}
+
+// BenchmarkNavigator_BuildGraph builds the platformer graph of the test level from scratch,
+// as after a tile change
+func BenchmarkNavigator_BuildGraph(b *testing.B) {
+	level := CreateTestLevel()
+	climber := NavProfile{Mode: NavPlatformer, JumpHeight: 112, Speed: 120, Gravity: 500}
+	nav := level.Navigator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		clear(nav.graphs)
+		nav.graph(climber)
+	}
+}
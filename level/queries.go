@@ -0,0 +1,47 @@
+package level
+
+import "math"
+
+// LineOfSight returns whether nothing in the level blocks the straight line from (x0, y0)
+// to (x1, y1). Opaque tiles block sight where the line meets their shape; one-way
+// platforms, spikes and tiles outside the level don't.
+func (l *Level) LineOfSight(x0, y0, x1, y1 float64) bool {
+	return !l.SegmentCast(x0, y0, x1, y1, OpaqueTiles).Hit
+}
+
+// PlatformSpan finds the ground under a point and returns the edges of the platform it is
+// part of, in world pixels. The platform is the run of tiles that can be stood on either
+// side of the one under the point, stopping at gaps and at walls rising above its surface.
+// It returns false if there is no ground under the point.
+func (l *Level) PlatformSpan(worldX, worldY float64) (left, right, top float64, found bool) {
+	tileSize := float64(l.TileSize)
+	column := int(math.Floor(worldX / tileSize))
+	if column < 0 || column >= l.Width {
+		return 0, 0, 0, false
+	}
+
+	row := max(int(math.Floor(worldY/tileSize)), 0)
+	for ; row < l.Height; row++ {
+		if l.Tiles[row][column].IsSolid() {
+			break
+		}
+	}
+	if row == l.Height {
+		return 0, 0, 0, false
+	}
+
+	standable := func(x int) bool {
+		if x < 0 || x >= l.Width || !l.Tiles[row][x].IsSolid() {
+			return false
+		}
+		return row == 0 || !l.Tiles[row-1][x].IsSolid()
+	}
+	first, last := column, column
+	for standable(first - 1) {
+		first--
+	}
+	for standable(last + 1) {
+		last++
+	}
+	return float64(first) * tileSize, float64(last+1) * tileSize, float64(row) * tileSize, true
+}
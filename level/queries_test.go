@@ -0,0 +1,83 @@
+package level
+
+import "testing"
+
+// newQueryLevel creates a 10x10 level with a wall, a one-way ledge and a floor:
+//
+//	row 2: one-way tiles in columns 6-8
+//	rows 3-6: a solid wall in column 4
+//	row 7: solid floor in columns 1-8, under the wall and a step up in column 8
+func newQueryLevel() *Level {
+	level := NewLevel(10, 10, 32, "Queries")
+	for x := 6; x <= 8; x++ {
+		level.SetTile(x, 2, TileOneWay)
+	}
+	for y := 3; y <= 6; y++ {
+		level.SetTile(4, y, TileSolid)
+	}
+	for x := 1; x <= 8; x++ {
+		level.SetTile(x, 7, TileSolid)
+	}
+	level.SetTile(8, 6, TileSolid)
+	return level
+}
+
+func TestLineOfSight(t *testing.T) {
+	level := newQueryLevel()
+
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 float64
+		want           bool
+	}{
+		{"open air", 16, 16, 300, 40, true},
+		{"through the wall", 80, 150, 250, 150, false},
+		{"over the wall", 80, 80, 250, 80, true},
+		{"through a one-way platform", 220, 40, 220, 150, true},
+		{"into the floor", 80, 200, 80, 240, false},
+		{"diagonally past the wall's top", 100, 90, 200, 40, true},
+		{"diagonally into the wall", 100, 40, 200, 200, false},
+		{"within one tile", 10, 10, 20, 20, true},
+		{"from outside the level", -100, 100, 100, 100, true},
+		{"to a point inside the wall", 80, 150, 140, 150, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := level.LineOfSight(tt.x0, tt.y0, tt.x1, tt.y1); got != tt.want {
+				t.Errorf("Expected line of sight %v, got %v", tt.want, got)
+			}
+			if got := level.LineOfSight(tt.x1, tt.y1, tt.x0, tt.y0); got != tt.want {
+				t.Errorf("Expected line of sight %v in reverse, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPlatformSpan(t *testing.T) {
+	level := newQueryLevel()
+
+	tests := []struct {
+		name             string
+		x, y             float64
+		found            bool
+		left, right, top float64
+	}{
+		{"floor left of the wall", 50, 100, true, 32, 128, 224},
+		{"floor right of the wall, up to the step", 200, 100, true, 160, 256, 224},
+		{"one-way ledge", 220, 20, true, 192, 288, 64},
+		{"top of the wall", 140, 20, true, 128, 160, 96},
+		{"no ground below", 300, 20, false, 0, 0, 0},
+		{"outside the level", -50, 20, false, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right, top, found := level.PlatformSpan(tt.x, tt.y)
+			if found != tt.found || left != tt.left || right != tt.right || top != tt.top {
+				t.Errorf("Expected (%v, %v, %v, %v), got (%v, %v, %v, %v)",
+					tt.left, tt.right, tt.top, tt.found, left, right, top, found)
+			}
+		})
+	}
+}
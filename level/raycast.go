@@ -0,0 +1,189 @@
+package level
+
+import (
+	"math"
+	"slices"
+)
+
+// TileFilter picks the tiles a ray stops at
+type TileFilter func(tile *Tile) bool
+
+// SolidTiles stops rays at tiles that block movement, one-way platforms included
+func SolidTiles(tile *Tile) bool {
+	return tile.IsSolid()
+}
+
+// OpaqueTiles stops rays at tiles that block sight: solid tiles other than one-way platforms
+func OpaqueTiles(tile *Tile) bool {
+	return tile.IsSolid() && !tile.IsOneWay()
+}
+
+// DangerousTiles stops rays at tiles that damage the player, such as spikes
+func DangerousTiles(tile *Tile) bool {
+	return tile.IsDangerous()
+}
+
+// ClimbableTiles stops rays at tiles that can be climbed
+func ClimbableTiles(tile *Tile) bool {
+	return tile.IsClimbable()
+}
+
+// TilesOfType stops rays at tiles of any of the given types
+func TilesOfType(types ...TileType) TileFilter {
+	return func(tile *Tile) bool {
+		return slices.Contains(types, tile.Type)
+	}
+}
+
+// RayHit describes where a ray or segment cast through a level first hit a tile
+type RayHit struct {
+	Hit              bool    // True if the ray hit a tile the filter picked
+	Tile             *Tile   // The tile that was hit, or nil
+	TileX, TileY     int     // Grid coordinates of the tile that was hit
+	X, Y             float64 // Where the ray hit, or where it stopped: its end or where it left the level
+	NormalX, NormalY float64 // Normal of the surface hit, pointing out of it; zero if the ray started inside
+	Distance         float64 // Distance from the ray's origin to (X, Y)
+}
+
+// Raycast casts a ray from (originX, originY) in a direction, which needn't be normalised,
+// and returns the first tile the filter picks within maxDistance; a nil filter picks solid
+// tiles. Solid tiles are hit where the ray meets their shape, so rays pass over half tiles
+// and hit slopes along their surface. Other tiles are hit where the ray enters them.
+//
+// The ray walks the tiles it passes through in order (a DDA grid traversal), so its cost
+// grows with the distance covered in tiles rather than the level's size. Tiles outside the
+// level are never hit, and a ray stops once it leaves the level for good, so maxDistance
+// may be infinite.
+func (l *Level) Raycast(originX, originY, directionX, directionY, maxDistance float64, filter TileFilter) RayHit {
+	if length := math.Hypot(directionX, directionY); length > 0 {
+		directionX, directionY = directionX/length, directionY/length
+	} else {
+		maxDistance = 0
+	}
+	if filter == nil {
+		filter = SolidTiles
+	}
+
+	tileSize := float64(l.TileSize)
+	tileX, tileY := int(math.Floor(originX/tileSize)), int(math.Floor(originY/tileSize))
+
+	// Distances along the ray at which it next crosses a column and a row boundary, and the
+	// distance it takes to cross a whole tile on each axis
+	stepX, nextX, deltaX := rayStep(originX, directionX, tileX, tileSize)
+	stepY, nextY, deltaY := rayStep(originY, directionY, tileY, tileSize)
+
+	// The distance at which the ray entered the current tile, and the normal of the side
+	// it came in through
+	enter, normalX, normalY := 0.0, 0.0, 0.0
+	for {
+		exit := min(nextX, nextY, maxDistance)
+		if l.IsValidCoord(tileX, tileY) {
+			tile := l.Tiles[tileY][tileX]
+			if filter(tile) {
+				hit := RayHit{Hit: true, Tile: tile, TileX: tileX, TileY: tileY, NormalX: normalX, NormalY: normalY}
+				if l.hitTileShape(&hit, originX, originY, directionX, directionY, enter, exit) {
+					return hit
+				}
+			}
+		} else if leavingGrid(tileX, stepX, l.Width) || leavingGrid(tileY, stepY, l.Height) {
+			break
+		}
+
+		// A tile the ray ends exactly on the edge of is still checked
+		if min(nextX, nextY) > maxDistance {
+			enter = maxDistance
+			break
+		}
+		if nextX < nextY {
+			tileX += stepX
+			enter = nextX
+			nextX += deltaX
+			normalX, normalY = float64(-stepX), 0
+		} else {
+			tileY += stepY
+			enter = nextY
+			nextY += deltaY
+			normalX, normalY = 0, float64(-stepY)
+		}
+	}
+
+	return RayHit{
+		X:        originX + directionX*enter,
+		Y:        originY + directionY*enter,
+		Distance: enter,
+	}
+}
+
+// SegmentCast returns the first tile the filter picks on the straight line from (x0, y0)
+// to (x1, y1), as Raycast does. A miss stops at (x1, y1).
+func (l *Level) SegmentCast(x0, y0, x1, y1 float64, filter TileFilter) RayHit {
+	return l.Raycast(x0, y0, x1-x0, y1-y0, math.Hypot(x1-x0, y1-y0), filter)
+}
+
+// hitTileShape finds where a ray inside a tile between the distances enter and exit first
+// meets the tile's shape. It fills in the hit's position, distance and, if the ray meets
+// the shape's surface rather than coming in through a side, normal, and returns whether
+// the ray met the shape at all.
+//
+// Every shape is the part of the tile below a straight surface line, so how far the ray
+// is below the surface changes linearly along it and the meeting point can be solved for.
+func (l *Level) hitTileShape(hit *RayHit, originX, originY, directionX, directionY, enter, exit float64) bool {
+	tileSize := float64(l.TileSize)
+	left, bottom := float64(hit.TileX)*tileSize, float64(hit.TileY+1)*tileSize
+
+	// Tiles without a solid shape, such as spikes, fill their whole tile
+	heightLeft, heightRight := tileSize, tileSize
+	if hit.Tile.Properties().Solid {
+		heightLeft, heightRight = hit.Tile.SurfaceHeight(0, tileSize), hit.Tile.SurfaceHeight(tileSize, tileSize)
+	}
+	rise := (heightRight - heightLeft) / tileSize // Upward change in surface height per pixel right
+
+	// How far below the surface the ray is where it enters the tile, and how quickly that
+	// changes per pixel along the ray
+	x, y := originX+directionX*enter, originY+directionY*enter
+	below := y - (bottom - heightLeft - rise*(x-left))
+	sinking := directionY + rise*directionX
+
+	distance := enter
+	if below < -rayEpsilon {
+		if sinking <= 0 {
+			return false
+		}
+		distance = enter - below/sinking
+		if distance > exit {
+			return false
+		}
+		normalLength := math.Hypot(rise, 1)
+		hit.NormalX, hit.NormalY = -rise/normalLength, -1/normalLength
+	}
+
+	hit.X = originX + directionX*distance
+	hit.Y = originY + directionY*distance
+	hit.Distance = distance
+	return true
+}
+
+// rayEpsilon is how far, in pixels, a ray entering a tile may be above its surface and
+// still count as hitting it where it enters, absorbing rounding at tile edges
+const rayEpsilon = 1e-9
+
+// rayStep sets up a DDA traversal along one axis of a ray with a normalised direction that
+// starts at position in tile. It returns the direction to step in, the distance along the
+// ray at which it first crosses a tile boundary and the distance it takes to cross a whole
+// tile. An axis the ray doesn't move along never crosses a boundary.
+func rayStep(position, direction float64, tile int, tileSize float64) (step int, next, delta float64) {
+	switch {
+	case direction > 0:
+		return 1, (float64(tile+1)*tileSize - position) / direction, tileSize / direction
+	case direction < 0:
+		return -1, (float64(tile)*tileSize - position) / direction, -tileSize / direction
+	default:
+		return 0, math.Inf(1), math.Inf(1)
+	}
+}
+
+// leavingGrid returns whether a ray in a row or column outside the level's size along one
+// axis is moving away from the level, or not moving towards it, so it can't come back
+func leavingGrid(tile, step, size int) bool {
+	return (tile < 0 && step <= 0) || (tile >= size && step >= 0)
+}
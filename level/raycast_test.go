@@ -0,0 +1,183 @@
+package level
+
+import (
+	"math"
+	"testing"
+)
+
+// newRaycastLevel creates a 10x10 level with one of each kind of tile a ray treats
+// differently:
+//
+//	row 4: one-way tiles in columns 1-3
+//	rows 5-8: a solid wall in column 5
+//	row 8: a half tile in column 2, a 45° slope rising right in column 7 and spikes in column 8
+//	row 9: solid floor across the level
+func newRaycastLevel() *Level {
+	level := NewLevel(10, 10, 32, "Raycast")
+	for x := 1; x <= 3; x++ {
+		level.SetTile(x, 4, TileOneWay)
+	}
+	for y := 5; y <= 8; y++ {
+		level.SetTile(5, y, TileSolid)
+	}
+	level.SetTile(2, 8, TileHalf)
+	level.SetTile(7, 8, TileSlopeRight45)
+	level.SetTile(8, 8, TileSpike)
+	for x := 0; x < 10; x++ {
+		level.SetTile(x, 9, TileSolid)
+	}
+	return level
+}
+
+func TestRaycast(t *testing.T) {
+	level := newRaycastLevel()
+	inf := math.Inf(1)
+	diagonal := 1 / math.Sqrt2
+
+	tests := []struct {
+		name        string
+		x, y        float64
+		dx, dy      float64
+		maxDistance float64
+		filter      TileFilter
+		want        RayHit
+	}{
+		{"down onto the floor", 16, 16, 0, 1, inf, nil,
+			RayHit{Hit: true, TileX: 0, TileY: 9, X: 16, Y: 288, NormalY: -1, Distance: 272}},
+		{"right into the wall", 16, 200, 1, 0, inf, nil,
+			RayHit{Hit: true, TileX: 5, TileY: 6, X: 160, Y: 200, NormalX: -1, Distance: 144}},
+		{"left into the wall", 300, 200, -1, 0, inf, nil,
+			RayHit{Hit: true, TileX: 5, TileY: 6, X: 192, Y: 200, NormalX: 1, Distance: 108}},
+		{"up into a one-way platform", 80, 200, 0, -1, inf, SolidTiles,
+			RayHit{Hit: true, TileX: 2, TileY: 4, X: 80, Y: 160, NormalY: 1, Distance: 40}},
+		{"up through a one-way platform", 80, 200, 0, -1, inf, OpaqueTiles,
+			RayHit{X: 80, Y: 0, Distance: 200}},
+		{"diagonally onto a one-way platform", 0, 10, 2, 2, inf, nil,
+			RayHit{Hit: true, TileX: 3, TileY: 4, X: 118, Y: 128, NormalY: -1, Distance: 118 * math.Sqrt2}},
+		{"over a half tile", 0, 260, 1, 0, inf, nil,
+			RayHit{Hit: true, TileX: 5, TileY: 8, X: 160, Y: 260, NormalX: -1, Distance: 160}},
+		{"onto a half tile", 80, 200, 0, 1, inf, nil,
+			RayHit{Hit: true, TileX: 2, TileY: 8, X: 80, Y: 272, NormalY: -1, Distance: 72}},
+		{"into a half tile's side", 0, 280, 1, 0, inf, nil,
+			RayHit{Hit: true, TileX: 2, TileY: 8, X: 64, Y: 280, NormalX: -1, Distance: 64}},
+		{"onto a slope", 240, 100, 0, 1, inf, nil,
+			RayHit{Hit: true, TileX: 7, TileY: 8, X: 240, Y: 272, NormalX: -diagonal, NormalY: -diagonal, Distance: 172}},
+		{"along a slope's surface", 0, 280, 1, 0, inf, TilesOfType(TileSlopeRight45),
+			RayHit{Hit: true, TileX: 7, TileY: 8, X: 232, Y: 280, NormalX: -diagonal, NormalY: -diagonal, Distance: 232}},
+		{"onto spikes", 272, 100, 0, 1, inf, DangerousTiles,
+			RayHit{Hit: true, TileX: 8, TileY: 8, X: 272, Y: 256, NormalY: -1, Distance: 156}},
+		{"through spikes", 272, 100, 0, 1, inf, SolidTiles,
+			RayHit{Hit: true, TileX: 8, TileY: 9, X: 272, Y: 288, NormalY: -1, Distance: 188}},
+		{"from inside the floor", 16, 300, 1, 0, inf, nil,
+			RayHit{Hit: true, TileX: 0, TileY: 9, X: 16, Y: 300, Distance: 0}},
+		{"stopping at its maximum distance", 16, 16, 0, 1, 100, nil,
+			RayHit{X: 16, Y: 116, Distance: 100}},
+		{"from outside the level", -100, 200, 1, 0, inf, nil,
+			RayHit{Hit: true, TileX: 5, TileY: 6, X: 160, Y: 200, NormalX: -1, Distance: 260}},
+		{"away from the level", -100, 200, -1, 0, inf, nil,
+			RayHit{X: -100, Y: 200, Distance: 0}},
+		{"with no direction inside the floor", 16, 300, 0, 0, inf, nil,
+			RayHit{Hit: true, TileX: 0, TileY: 9, X: 16, Y: 300, Distance: 0}},
+		{"with no direction in the air", 16, 16, 0, 0, inf, nil,
+			RayHit{X: 16, Y: 16, Distance: 0}},
+		{"through climbable tiles only", 16, 16, 1, 0, inf, ClimbableTiles,
+			RayHit{X: 320, Y: 16, Distance: 304}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := level.Raycast(tt.x, tt.y, tt.dx, tt.dy, tt.maxDistance, tt.filter)
+			if got.Hit != tt.want.Hit || got.TileX != tt.want.TileX || got.TileY != tt.want.TileY ||
+				!near(got.X, tt.want.X) || !near(got.Y, tt.want.Y) ||
+				!near(got.NormalX, tt.want.NormalX) || !near(got.NormalY, tt.want.NormalY) ||
+				!near(got.Distance, tt.want.Distance) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+			if tt.want.Hit && got.Tile != level.Tiles[tt.want.TileY][tt.want.TileX] {
+				t.Errorf("Expected the hit tile at (%d, %d), got %+v", tt.want.TileX, tt.want.TileY, got.Tile)
+			}
+			if !tt.want.Hit && got.Tile != nil {
+				t.Errorf("Expected no tile for a miss, got %+v", got.Tile)
+			}
+		})
+	}
+}
+
+func TestSegmentCast(t *testing.T) {
+	level := newRaycastLevel()
+
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 float64
+		want           RayHit
+	}{
+		{"short of the wall", 16, 200, 150, 200, RayHit{X: 150, Y: 200, Distance: 134}},
+		{"to the wall's face", 16, 200, 160, 200,
+			RayHit{Hit: true, TileX: 5, TileY: 6, X: 160, Y: 200, NormalX: -1, Distance: 144}},
+		{"into the wall", 300, 200, 100, 200,
+			RayHit{Hit: true, TileX: 5, TileY: 6, X: 192, Y: 200, NormalX: 1, Distance: 108}},
+		{"a single point", 16, 16, 16, 16, RayHit{X: 16, Y: 16}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := level.SegmentCast(tt.x0, tt.y0, tt.x1, tt.y1, SolidTiles)
+			if got.Hit != tt.want.Hit || got.TileX != tt.want.TileX || got.TileY != tt.want.TileY ||
+				!near(got.X, tt.want.X) || !near(got.Y, tt.want.Y) ||
+				got.NormalX != tt.want.NormalX || got.NormalY != tt.want.NormalY ||
+				!near(got.Distance, tt.want.Distance) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRaycastDoesNotAllocate(t *testing.T) {
+	level := newRaycastLevel()
+	allocs := testing.AllocsPerRun(100, func() {
+		level.Raycast(16, 16, 1, 1, math.Inf(1), nil)
+		level.Raycast(300, 200, -1, 0, 500, OpaqueTiles)
+		level.SegmentCast(0, 280, 300, 280, DangerousTiles)
+		level.LineOfSight(16, 16, 300, 250)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected raycasts not to allocate, got %v allocations per run", allocs)
+	}
+}
+
+// near returns whether two distances or coordinates are equal but for rounding
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// BenchmarkRaycast casts long rays across the test level in several directions
+func BenchmarkRaycast(b *testing.B) {
+	level := CreateTestLevel()
+	width, height := level.GetWorldBounds()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		level.Raycast(16, 16, 1, 1, math.Inf(1), nil)
+		level.Raycast(width-16, 16, -1, 0.4, math.Inf(1), nil)
+		level.Raycast(width/2, height-48, 0.2, -1, math.Inf(1), OpaqueTiles)
+		level.Raycast(16, height/2, 1, 0, math.Inf(1), DangerousTiles)
+	}
+}
+
+// BenchmarkSegmentCast casts short segments of the length an enemy might look along
+func BenchmarkSegmentCast(b *testing.B) {
+	level := CreateTestLevel()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		level.SegmentCast(100, 400, 260, 450, nil)
+		level.SegmentCast(576, 320, 450, 520, nil)
+	}
+}
+
+func BenchmarkLineOfSight(b *testing.B) {
+	level := CreateTestLevel()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		level.LineOfSight(100, 400, 260, 450)
+		level.LineOfSight(576, 320, 450, 520)
+	}
+}
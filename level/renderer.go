@@ -0,0 +1,250 @@
+package level
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultChunkSize is the width and height of a cached render chunk in tiles
+const DefaultChunkSize = 16
+
+// RenderStats reports the work done by the most recent TileRenderer.Draw call
+type RenderStats struct {
+	TilesDrawn    int // Individual tile draws (including chunk rebuilds)
+	ChunksDrawn   int // Cached chunk images drawn to the screen
+	ChunksRebuilt int // Chunks redrawn because their tiles changed
+}
+
+// chunkKey identifies a chunk by its chunk-grid coordinates
+type chunkKey struct {
+	X, Y int
+}
+
+// renderChunk is a cached render target covering a block of tiles
+type renderChunk struct {
+	image *ebiten.Image
+	dirty bool
+}
+
+// TileRenderer draws a level's tiles from a shared tileset, culling tiles outside the
+// camera view and optionally caching static tiles into chunked render targets
+type TileRenderer struct {
+	level       *Level
+	tileset     *Tileset
+	TypeIndices map[TileType]int // Tileset index drawn for each tile type when a tile has no index of its own
+
+	chunkCaching bool
+	chunkSize    int
+	chunks       map[chunkKey]*renderChunk
+
+	stats RenderStats
+}
+
+// NewTileRenderer creates a renderer for a level. If tileset is nil, a flat colour
+// tileset matching the level's tile size is generated once and reused.
+func NewTileRenderer(level *Level, tileset *Tileset) *TileRenderer {
+	if tileset == nil {
+		tileset = NewColourTileset(level.TileSize)
+	}
+
+	typeIndices := make(map[TileType]int)
+	for _, tileType := range TileTypes() {
+		if tileType.Properties().Colour.A > 0 {
+			typeIndices[tileType] = int(tileType)
+		}
+	}
+
+	renderer := &TileRenderer{
+		level:       level,
+		tileset:     tileset,
+		TypeIndices: typeIndices,
+		chunkSize:   DefaultChunkSize,
+		chunks:      make(map[chunkKey]*renderChunk),
+	}
+
+	// Only chunks around a changed tile need redrawing. Neighbours are included
+	// because autotiling can change their images too.
+	level.AddTileChangeListener(func(x, y int) {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				renderer.markDirty(x+dx, y+dy)
+			}
+		}
+	})
+
+	return renderer
+}
+
+// SetChunkCaching enables or disables caching tiles into chunked render targets.
+// Chunks are only rebuilt when Level.SetTile changes a tile inside them.
+func (r *TileRenderer) SetChunkCaching(enabled bool, chunkSize int) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize != r.chunkSize || !enabled {
+		r.disposeChunks()
+	}
+	r.chunkCaching = enabled
+	r.chunkSize = chunkSize
+}
+
+// Tileset returns the tileset the renderer draws from
+func (r *TileRenderer) Tileset() *Tileset {
+	return r.tileset
+}
+
+// Stats returns counters from the most recent Draw call
+func (r *TileRenderer) Stats() RenderStats {
+	return r.stats
+}
+
+// Draw renders the tiles visible through a camera whose top-left corner is at
+// (cameraX, cameraY) in world space. The view size is the size of the screen image.
+func (r *TileRenderer) Draw(screen *ebiten.Image, cameraX, cameraY float64) {
+	r.stats = RenderStats{}
+
+	bounds := screen.Bounds()
+	minX, minY, maxX, maxY := r.visibleTileRange(cameraX, cameraY, float64(bounds.Dx()), float64(bounds.Dy()))
+
+	if r.chunkCaching {
+		r.drawChunks(screen, cameraX, cameraY, minX, minY, maxX, maxY)
+		return
+	}
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			r.drawTile(screen, r.level.Tiles[y][x], float64(x*r.level.TileSize)-cameraX, float64(y*r.level.TileSize)-cameraY)
+		}
+	}
+}
+
+// visibleTileRange returns the inclusive tile range overlapping the view, clamped to the level
+func (r *TileRenderer) visibleTileRange(cameraX, cameraY, viewWidth, viewHeight float64) (minX, minY, maxX, maxY int) {
+	tileSize := float64(r.level.TileSize)
+	minX = int(math.Floor(cameraX / tileSize))
+	minY = int(math.Floor(cameraY / tileSize))
+	maxX = int(math.Floor((cameraX + viewWidth - 1) / tileSize))
+	maxY = int(math.Floor((cameraY + viewHeight - 1) / tileSize))
+
+	minX = max(minX, 0)
+	minY = max(minY, 0)
+	maxX = min(maxX, r.level.Width-1)
+	maxY = min(maxY, r.level.Height-1)
+	return minX, minY, maxX, maxY
+}
+
+// drawChunks draws the cached chunks overlapping the visible tile range, rebuilding dirty ones
+func (r *TileRenderer) drawChunks(screen *ebiten.Image, cameraX, cameraY float64, minX, minY, maxX, maxY int) {
+	if minX > maxX || minY > maxY {
+		return
+	}
+
+	chunkPixels := float64(r.chunkSize * r.level.TileSize)
+	for cy := minY / r.chunkSize; cy <= maxY/r.chunkSize; cy++ {
+		for cx := minX / r.chunkSize; cx <= maxX/r.chunkSize; cx++ {
+			chunk := r.chunk(cx, cy)
+			if chunk.dirty {
+				r.rebuildChunk(chunk, cx, cy)
+			}
+
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(float64(cx)*chunkPixels-cameraX, float64(cy)*chunkPixels-cameraY)
+			screen.DrawImage(chunk.image, op)
+			r.stats.ChunksDrawn++
+		}
+	}
+}
+
+// chunk returns the chunk at chunk coordinates, creating it on first use
+func (r *TileRenderer) chunk(cx, cy int) *renderChunk {
+	key := chunkKey{cx, cy}
+	if chunk, exists := r.chunks[key]; exists {
+		return chunk
+	}
+
+	// Edge chunks only cover the tiles that exist
+	width := min(r.chunkSize, r.level.Width-cx*r.chunkSize)
+	height := min(r.chunkSize, r.level.Height-cy*r.chunkSize)
+
+	chunk := &renderChunk{
+		image: ebiten.NewImage(width*r.level.TileSize, height*r.level.TileSize),
+		dirty: true,
+	}
+	r.chunks[key] = chunk
+	return chunk
+}
+
+// rebuildChunk redraws every tile of a chunk into its cached image
+func (r *TileRenderer) rebuildChunk(chunk *renderChunk, cx, cy int) {
+	chunk.image.Clear()
+
+	startX := cx * r.chunkSize
+	startY := cy * r.chunkSize
+	endX := min(startX+r.chunkSize, r.level.Width)
+	endY := min(startY+r.chunkSize, r.level.Height)
+
+	for y := startY; y < endY; y++ {
+		for x := startX; x < endX; x++ {
+			r.drawTile(chunk.image, r.level.Tiles[y][x], float64((x-startX)*r.level.TileSize), float64((y-startY)*r.level.TileSize))
+		}
+	}
+
+	chunk.dirty = false
+	r.stats.ChunksRebuilt++
+}
+
+// drawTile draws a single tile at a position on the target image
+func (r *TileRenderer) drawTile(target *ebiten.Image, tile *Tile, x, y float64) {
+	img := r.tileImage(tile)
+	if img == nil {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, y)
+	target.DrawImage(img, op)
+	r.stats.TilesDrawn++
+}
+
+// tileImage picks the image for a tile: its own sprite, then its tileset index, then its
+// type's registered sprite, then the default index for its type. An index the tileset
+// doesn't have (such as an autotile variant on a flat colour tileset) falls back to the
+// type's sprite or default. Crumbling tiles have no image here: the game draws them so
+// they can shake.
+func (r *TileRenderer) tileImage(tile *Tile) *ebiten.Image {
+	if tile == nil || tile.Type == TileEmpty || tile.Properties().Crumbles() {
+		return nil
+	}
+	if tile.Sprite != nil {
+		return tile.Sprite
+	}
+	if img := r.tileset.Tile(tile.TileIndex); img != nil {
+		return img
+	}
+	if sprite := tile.Properties().Sprite; sprite != nil {
+		return sprite
+	}
+	if index, exists := r.TypeIndices[tile.Type]; exists {
+		return r.tileset.Tile(index)
+	}
+	return nil
+}
+
+// markDirty flags the chunk containing a tile for rebuilding
+func (r *TileRenderer) markDirty(x, y int) {
+	if !r.level.IsValidCoord(x, y) {
+		return
+	}
+	if chunk, exists := r.chunks[chunkKey{x / r.chunkSize, y / r.chunkSize}]; exists {
+		chunk.dirty = true
+	}
+}
+
+// disposeChunks releases all cached chunk images
+func (r *TileRenderer) disposeChunks() {
+	for key, chunk := range r.chunks {
+		chunk.image.Deallocate()
+		delete(r.chunks, key)
+	}
+}
@@ -0,0 +1,148 @@
+package level
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newFilledLevel creates a level with every tile solid
+func newFilledLevel(width, height int) *Level {
+	level := NewLevel(width, height, 32, "Filled")
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			level.SetTile(x, y, TileSolid)
+		}
+	}
+	return level
+}
+
+func TestTileRenderer_CullsOffscreenTiles(t *testing.T) {
+	level := newFilledLevel(100, 100)
+	renderer := NewTileRenderer(level, nil)
+	screen := ebiten.NewImage(320, 240) // 10x7.5 tiles
+
+	renderer.Draw(screen, 0, 0)
+	if drawn := renderer.Stats().TilesDrawn; drawn != 10*8 {
+		t.Errorf("Expected 80 visible tiles at origin, got %d", drawn)
+	}
+
+	// Offset by half a tile: one extra column and row become partly visible
+	renderer.Draw(screen, 16, 16)
+	if drawn := renderer.Stats().TilesDrawn; drawn != 11*8 {
+		t.Errorf("Expected 88 visible tiles with half-tile offset, got %d", drawn)
+	}
+
+	// Camera entirely outside the level draws nothing
+	renderer.Draw(screen, -1000, -1000)
+	if drawn := renderer.Stats().TilesDrawn; drawn != 0 {
+		t.Errorf("Expected no tiles outside the level, got %d", drawn)
+	}
+}
+
+func TestTileRenderer_SkipsEmptyTiles(t *testing.T) {
+	level := NewLevel(10, 10, 32, "Sparse")
+	level.SetTile(1, 1, TileSolid)
+	level.SetTile(2, 1, TileSpike)
+	level.SetTile(3, 1, TileCrumbling) // Drawn by the game's crumbling blocks
+
+	renderer := NewTileRenderer(level, nil)
+	renderer.Draw(ebiten.NewImage(320, 320), 0, 0)
+
+	if drawn := renderer.Stats().TilesDrawn; drawn != 2 {
+		t.Errorf("Expected 2 tiles drawn, got %d", drawn)
+	}
+}
+
+func TestTileRenderer_ChunkCaching(t *testing.T) {
+	level := newFilledLevel(40, 20)
+	renderer := NewTileRenderer(level, nil)
+	renderer.SetChunkCaching(true, 16)
+	screen := ebiten.NewImage(640, 480) // 20x15 tiles, overlapping 2x1 chunks
+
+	renderer.Draw(screen, 0, 0)
+	stats := renderer.Stats()
+	if stats.ChunksRebuilt != 2 || stats.ChunksDrawn != 2 {
+		t.Errorf("Expected first draw to build and draw 2 chunks, got %+v", stats)
+	}
+
+	// Static tiles: nothing is rebuilt on subsequent frames
+	renderer.Draw(screen, 0, 0)
+	stats = renderer.Stats()
+	if stats.ChunksRebuilt != 0 || stats.TilesDrawn != 0 {
+		t.Errorf("Expected cached chunks to be reused, got %+v", stats)
+	}
+
+	// Changing a tile only rebuilds the chunk that contains it
+	level.SetTile(17, 3, TileEmpty)
+	renderer.Draw(screen, 0, 0)
+	stats = renderer.Stats()
+	if stats.ChunksRebuilt != 1 {
+		t.Errorf("Expected exactly one chunk rebuilt after SetTile, got %d", stats.ChunksRebuilt)
+	}
+}
+
+func TestTileRenderer_TileImageSelection(t *testing.T) {
+	level := NewLevel(4, 4, 32, "Selection")
+	atlas := ebiten.NewImage(32*8, 32)
+	renderer := NewTileRenderer(level, NewTileset(atlas, 32))
+
+	level.SetTile(0, 0, TileSolid)
+	tile := level.GetTile(0, 0)
+
+	// Default index comes from the tile type
+	if renderer.tileImage(tile) != renderer.Tileset().Tile(int(TileSolid)) {
+		t.Error("Expected type default tileset index")
+	}
+
+	// Per-tile index overrides the type default
+	tile.TileIndex = 6
+	if renderer.tileImage(tile) != renderer.Tileset().Tile(6) {
+		t.Error("Expected tile's own tileset index")
+	}
+
+	// A sprite overrides both
+	sprite := ebiten.NewImage(32, 32)
+	tile.Sprite = sprite
+	if renderer.tileImage(tile) != sprite {
+		t.Error("Expected tile sprite to take precedence")
+	}
+}
+
+func TestLevel_DrawUsesSharedRenderer(t *testing.T) {
+	level := CreateTestLevel()
+	screen := ebiten.NewImage(480, 360)
+
+	level.Draw(screen)
+	renderer := level.Renderer()
+	level.Draw(screen)
+
+	if level.Renderer() != renderer {
+		t.Error("Level should reuse its renderer between frames")
+	}
+	if renderer.Stats().ChunksRebuilt != 0 {
+		t.Error("Static level should not rebuild chunks on the second frame")
+	}
+}
+
+// BenchmarkTileRenderer_Draw compares per-tile drawing with cached chunks on a large map
+func BenchmarkTileRenderer_Draw(b *testing.B) {
+	screen := ebiten.NewImage(480, 360)
+
+	b.Run("PerTile", func(b *testing.B) {
+		renderer := NewTileRenderer(newFilledLevel(500, 200), nil)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			renderer.Draw(screen, float64(i%1000), 100)
+		}
+	})
+
+	b.Run("Chunked", func(b *testing.B) {
+		renderer := NewTileRenderer(newFilledLevel(500, 200), nil)
+		renderer.SetChunkCaching(true, DefaultChunkSize)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			renderer.Draw(screen, float64(i%1000), 100)
+		}
+	})
+}
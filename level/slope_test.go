@@ -0,0 +1,141 @@
+package level
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTileSurfaceHeight(t *testing.T) {
+	tests := []struct {
+		tileType TileType
+		left     float64 // Height at localX = 0
+		middle   float64 // Height at localX = 16
+		right    float64 // Height at localX = 32
+		gradient float64
+	}{
+		{TileSolid, 32, 32, 32, 0},
+		{TileSlopeRight45, 0, 16, 32, -1},
+		{TileSlopeLeft45, 32, 16, 0, 1},
+		{TileSlopeRight22Low, 0, 8, 16, -0.5},
+		{TileSlopeRight22High, 16, 24, 32, -0.5},
+		{TileSlopeLeft22High, 32, 24, 16, 0.5},
+		{TileSlopeLeft22Low, 16, 8, 0, 0.5},
+		{TileHalf, 16, 16, 16, 0},
+		{TileEmpty, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		tile := NewTile(tt.tileType, 0, 0)
+		heights := []float64{tile.SurfaceHeight(0, 32), tile.SurfaceHeight(16, 32), tile.SurfaceHeight(32, 32)}
+		expected := []float64{tt.left, tt.middle, tt.right}
+		for i := range heights {
+			if heights[i] != expected[i] {
+				t.Errorf("Tile type %v: expected heights %v, got %v", tt.tileType, expected, heights)
+				break
+			}
+		}
+
+		if gradient := tile.SlopeGradient(); gradient != tt.gradient {
+			t.Errorf("Tile type %v: expected gradient %v, got %v", tt.tileType, tt.gradient, gradient)
+		}
+		if tile.IsSlope() != (tt.gradient != 0) {
+			t.Errorf("Tile type %v: IsSlope should be %v", tt.tileType, tt.gradient != 0)
+		}
+	}
+}
+
+func TestSlopeCollision(t *testing.T) {
+	level := NewLevel(5, 5, 32, "Test")
+	level.SetTile(1, 3, TileSlopeRight45) // Tile spans (32,96) to (64,128)
+
+	// Entity 16 wide centred on x=48: surface height 16, so surface Y = 112
+	tests := []struct {
+		name     string
+		bottom   float64
+		onSlope  bool
+		surfaceY float64
+	}{
+		{"resting on surface", 112, true, 112},
+		{"sunk into slope", 118, true, 112},
+		{"above surface", 108, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := level.CheckCollision(40, tt.bottom-16, 16, 16)
+			if result.OnSlope != tt.onSlope || result.OnGround != tt.onSlope {
+				t.Fatalf("Expected on slope and on ground to be %v, got %v and %v", tt.onSlope, result.OnSlope, result.OnGround)
+			}
+			if tt.onSlope {
+				if result.SurfaceY != tt.surfaceY {
+					t.Errorf("Expected surface Y %.1f, got %.1f", tt.surfaceY, result.SurfaceY)
+				}
+				if result.SlopeGradient != -1 {
+					t.Errorf("Expected gradient -1, got %v", result.SlopeGradient)
+				}
+			}
+			if result.CollisionX || result.CollisionY {
+				t.Error("Slopes should never report axis collisions")
+			}
+		})
+	}
+}
+
+func TestSlopeStepIntoSolid(t *testing.T) {
+	// A ramp leading up to a solid block at the same height
+	level := NewLevel(5, 5, 32, "Test")
+	level.SetTile(1, 3, TileSlopeRight45)
+	level.SetTile(2, 3, TileSolid)
+
+	// Centre at x=60 on the ramp: surface Y = 128 - 28 = 100, box overlaps the block
+	result := level.CheckCollision(44, 100-32, 32, 32)
+	if !result.OnSlope {
+		t.Fatal("Expected to be on the slope")
+	}
+	if result.CollisionX || result.TouchingWall {
+		t.Error("The block at the top of a ramp should be a step, not a wall")
+	}
+
+	// A block higher than the step is still a wall
+	level.SetTile(2, 2, TileSolid)
+	result = level.CheckCollision(44, 100-32, 32, 32)
+	if !result.CollisionX {
+		t.Error("Expected the block above the ramp's top to be a wall")
+	}
+}
+
+func TestHalfTileCollision(t *testing.T) {
+	level := NewLevel(5, 5, 32, "Test")
+	level.SetTile(1, 3, TileHalf) // Solid from y=112 to y=128
+
+	// Standing on the half tile's top
+	result := level.CheckCollision(32, 112-32, 32, 32)
+	if !result.OnGround {
+		t.Error("Expected to stand on the half tile's top")
+	}
+
+	// Standing at the top of the cell is in the air
+	result = level.CheckCollision(32, 96-32, 32, 32)
+	if result.OnGround || result.Collided {
+		t.Error("The upper half of a half tile should be empty")
+	}
+
+	// Overlapping the solid half from the side is a wall
+	result = level.CheckCollision(32-28, 100, 32, 20)
+	if !result.CollisionX {
+		t.Error("Expected the half tile's side to block horizontally")
+	}
+}
+
+func TestColourTilesetDrawsShapes(t *testing.T) {
+	tileset := NewColourTileset(32)
+	if tileset.TileCount() < int(TileHalf)+1 {
+		t.Fatalf("Expected a tile for every type up to TileHalf, got %d", tileset.TileCount())
+	}
+	if tileset.Tile(int(TileSlopeRight45)) == nil {
+		t.Error("Expected a tile for slopes")
+	}
+	if math.IsNaN(NewTile(TileSlopeRight45, 0, 0).SurfaceHeight(-5, 32)) {
+		t.Error("SurfaceHeight should clamp out-of-range offsets")
+	}
+}
@@ -0,0 +1,192 @@
+package level
+
+import (
+	"math"
+
+	"ebiten-platformer/collision"
+)
+
+// sweep is a box moving through the level
+type sweep struct {
+	x, y, width, height float64
+	deltaX, deltaY      float64
+	landOnOneWay        bool    // One-way platforms stop the box when it falls onto them
+	stepHeight          float64 // Tiles whose top is this close above the feet are steps, not walls
+}
+
+// Sweep moves a box by (deltaX, deltaY) and returns the exact time and place it first
+// touches a tile, with the contact normal. Tiles are walked one row or column at a time in
+// the direction of movement, so the search stops at the first line that is hit.
+//
+// Contacts follow the same rules as CheckCollision:
+//   - Solid tiles block from every side. Half tiles block with their lower half.
+//   - Slopes stop a box moving down onto the surface under its centre. They never block sideways.
+//   - One-way platforms only stop a falling box, and only when landOnOneWay is set.
+//   - A box may already be up to GroundTolerance into the ground it lands on.
+//   - Tiles whose top is within GroundTolerance of the feet are ground, not walls.
+//     On a slope this extends to the step the slope runs into.
+func (l *Level) Sweep(x, y, width, height, deltaX, deltaY float64, landOnOneWay bool) collision.SweepResult {
+	result := collision.SweepResult{Time: 1, X: x + deltaX, Y: y + deltaY}
+	if deltaX == 0 && deltaY == 0 {
+		return result
+	}
+
+	s := sweep{x: x, y: y, width: width, height: height, deltaX: deltaX, deltaY: deltaY,
+		landOnOneWay: landOnOneWay, stepHeight: GroundTolerance}
+	if _, _, _, gradient, found := l.slopeSurface(x+width/2, y+height); found {
+		s.stepHeight = width/2*math.Abs(gradient) + GroundTolerance
+	}
+
+	// Tiles the swept box covers
+	tileSize := float64(l.TileSize)
+	leftTile := int(math.Floor(math.Min(x, x+deltaX) / tileSize))
+	rightTile := int(math.Floor((math.Max(x, x+deltaX) + width) / tileSize))
+	topTile := int(math.Floor(math.Min(y, y+deltaY) / tileSize))
+	bottomTile := int(math.Floor((math.Max(y, y+deltaY) + height) / tileSize))
+
+	// Walk lines across the main direction of movement, nearest first
+	vertical := math.Abs(deltaY) >= math.Abs(deltaX)
+	first, last, across0, across1 := leftTile, rightTile, topTile, bottomTile
+	delta, leading := deltaX, x
+	if deltaX > 0 {
+		leading = x + width
+	}
+	if vertical {
+		first, last, across0, across1 = topTile, bottomTile, leftTile, rightTile
+		delta, leading = deltaY, y
+		if deltaY > 0 {
+			leading = y + height
+		}
+	}
+	step := 1
+	if delta < 0 {
+		first, last, step = last, first, -1
+	}
+
+	for line := first; line != last+step; line += step {
+		// Nothing in this line can be reached before the line itself, less the ground
+		// tolerance a box may already be sunk by
+		lineEdge := float64(line) * tileSize
+		if delta < 0 {
+			lineEdge += tileSize
+		}
+		if result.Hit && (lineEdge-leading-GroundTolerance*math.Copysign(1, delta))/delta > result.Time {
+			break
+		}
+
+		for across := across0; across <= across1; across++ {
+			tileX, tileY := line, across
+			if vertical {
+				tileX, tileY = across, line
+			}
+
+			time, normalX, normalY, hit := l.sweepTile(s, tileX, tileY)
+			if hit && (!result.Hit || time < result.Time) {
+				result = collision.SweepResult{Hit: true, Time: time, NormalX: normalX, NormalY: normalY, TileX: tileX, TileY: tileY}
+			}
+		}
+	}
+
+	if result.Hit {
+		result.X, result.Y = x+deltaX*result.Time, y+deltaY*result.Time
+		l.snapToContact(&result, width, height)
+	}
+	return result
+}
+
+// sweepTile returns when a sweeping box first touches a tile and the contact normal
+func (l *Level) sweepTile(s sweep, tileX, tileY int) (time, normalX, normalY float64, hit bool) {
+	tile := l.GetTile(tileX, tileY)
+	if !tile.IsSolid() {
+		return 0, 0, 0, false
+	}
+	if tile.IsSlope() {
+		return l.sweepSlope(s, tile, tileX, tileY)
+	}
+
+	bounds := l.getTileShapeBounds(tile, tileX, tileY)
+	entryX, exitX := collision.SweepAxis(s.x, s.width, s.deltaX, bounds.X, bounds.Width, 0)
+	entryY, exitY := collision.SweepAxis(s.y, s.height, s.deltaY, bounds.Y, bounds.Height, 0)
+	entry, exit := math.Max(entryX, entryY), math.Min(exitX, exitY)
+	if entry >= exit || exit <= 0 || entry > 1 {
+		return 0, 0, 0, false
+	}
+
+	if entryX > entryY {
+		// Side contact. One-way platforms never block from the side, and tiles level with
+		// the feet are ground or steps rather than walls.
+		feet := s.y + s.height + s.deltaY*entry
+		if tile.IsOneWay() || bounds.Y >= feet-s.stepHeight || entry*math.Abs(s.deltaX) < -collision.SweepEpsilon {
+			return 0, 0, 0, false
+		}
+		return math.Max(entry, 0), -math.Copysign(1, s.deltaX), 0, true
+	}
+
+	if s.deltaY > 0 {
+		// Landing, possibly already sunk into the ground by up to the ground tolerance
+		if (tile.IsOneWay() && !s.landOnOneWay) || entry*s.deltaY < -GroundTolerance {
+			return 0, 0, 0, false
+		}
+		return math.Max(entry, 0), 0, -1, true
+	}
+
+	// Hitting a ceiling. One-way platforms are jumped through.
+	if tile.IsOneWay() || entry*-s.deltaY < -collision.SweepEpsilon {
+		return 0, 0, 0, false
+	}
+	return math.Max(entry, 0), 0, 1, true
+}
+
+// sweepSlope returns when a box moving down first stands on a slope: when its feet meet
+// the surface under its centre while the centre is over the tile
+func (l *Level) sweepSlope(s sweep, tile *Tile, tileX, tileY int) (time, normalX, normalY float64, hit bool) {
+	if s.deltaY <= 0 {
+		return 0, 0, 0, false
+	}
+
+	// The surface is a line through the tile, extended to where the centre starts
+	tileSize := float64(l.TileSize)
+	tileLeft := float64(tileX) * tileSize
+	gradient := tile.SlopeGradient()
+	centreX := s.x + s.width/2
+	surfaceY := float64(tileY+1)*tileSize - tile.SurfaceHeight(0, tileSize) + gradient*(centreX-tileLeft)
+
+	// The feet close on the surface at the fall speed less the surface's own drop
+	closing := s.deltaY - gradient*s.deltaX
+	if closing <= 0 {
+		return 0, 0, 0, false
+	}
+	time = (surfaceY - (s.y + s.height)) / closing
+	if time*closing < -GroundTolerance || time > 1 {
+		return 0, 0, 0, false
+	}
+	time = math.Max(time, 0)
+
+	if contactX := centreX + s.deltaX*time; contactX < tileLeft || contactX >= tileLeft+tileSize {
+		return 0, 0, 0, false
+	}
+
+	length := math.Hypot(gradient, 1)
+	return time, gradient / length, -1 / length, true
+}
+
+// snapToContact places a box exactly against the flat side it hit, removing rounding from
+// position + delta*time so the next sweep starts touching rather than overlapping
+func (l *Level) snapToContact(result *collision.SweepResult, width, height float64) {
+	if result.Time == 0 || (result.NormalX != 0 && result.NormalY != 0) {
+		return
+	}
+
+	tile := l.GetTile(result.TileX, result.TileY)
+	bounds := l.getTileShapeBounds(tile, result.TileX, result.TileY)
+	switch {
+	case result.NormalX < 0:
+		result.X = bounds.X - width
+	case result.NormalX > 0:
+		result.X = bounds.X + bounds.Width
+	case result.NormalY < 0 && !tile.IsSlope():
+		result.Y = bounds.Y - height
+	case result.NormalY > 0:
+		result.Y = bounds.Y + bounds.Height
+	}
+}
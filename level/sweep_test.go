@@ -0,0 +1,139 @@
+package level
+
+import (
+	"math"
+	"testing"
+
+	"ebiten-platformer/collision"
+)
+
+// newSweepTestLevel creates a floor at Y=256 with a wall, a ceiling tile, a one-way
+// platform, a half tile, a spike and a 45° slope to sweep against
+func newSweepTestLevel() *Level {
+	level := NewLevel(10, 10, 32, "Sweep Test")
+	for x := 0; x < level.Width; x++ {
+		level.SetTile(x, 8, TileSolid)
+	}
+	for y := 5; y <= 7; y++ {
+		level.SetTile(6, y, TileSolid) // Wall from X=192 to 224
+	}
+	level.SetTile(2, 2, TileSolid)        // Ceiling, bottom at Y=96
+	level.SetTile(3, 5, TileOneWay)       // One-way top at Y=160
+	level.SetTile(8, 7, TileHalf)         // Half tile, top at Y=240
+	level.SetTile(1, 7, TileSpike)        // Not solid
+	level.SetTile(4, 7, TileSlopeRight45) // Surface Y = 256 - (X - 128)
+	return level
+}
+
+func TestSweep(t *testing.T) {
+	level := newSweepTestLevel()
+	diagonal := -math.Sqrt(0.5)
+
+	tests := []struct {
+		name             string
+		x, y             float64
+		deltaX, deltaY   float64
+		landOnOneWay     bool
+		hit              bool
+		wantX, wantY     float64
+		normalX, normalY float64
+		tileX, tileY     int
+	}{
+		{"falls onto the floor", 0, 100, 0, 200, false, true, 0, 224, 0, -1, 0, 8},
+		{"fast fall can't tunnel", 0, 0, 0, 5000, false, true, 0, 224, 0, -1, 0, 8},
+		{"walks into a wall", 100, 224, 200, 0, false, true, 160, 224, -1, 0, 6, 7},
+		{"moves left into a wall", 230, 224, -100, 0, false, true, 224, 224, 1, 0, 6, 7},
+		{"hits a ceiling", 64, 150, 0, -100, false, true, 64, 96, 0, 1, 2, 2},
+		{"lands on a one-way platform", 96, 100, 0, 100, true, true, 96, 128, 0, -1, 3, 5},
+		{"drops through a one-way platform", 96, 100, 0, 200, false, true, 96, 224, 0, -1, 3, 8},
+		{"jumps up through a one-way platform", 96, 170, 0, -100, true, false, 96, 70, 0, 0, 0, 0},
+		{"already sunk into the ground", 0, 225, 0, 10, false, true, 0, 225, 0, -1, 0, 8},
+		{"walks along the floor while sunk into it", 0, 225, 100, 0, false, false, 100, 225, 0, 0, 0, 0},
+		{"half tile blocks from the side", 300, 224, -60, 0, false, true, 288, 224, 1, 0, 8, 7},
+		{"lands on a half tile", 256, 150, 0, 100, false, true, 256, 208, 0, -1, 8, 7},
+		{"lands on a slope under its centre", 128, 150, 0, 100, false, true, 128, 208, diagonal, diagonal, 4, 7},
+		{"spikes don't block", 32, 150, 0, 60, false, false, 32, 210, 0, 0, 0, 0},
+		{"diagonal move stops at the first contact", 0, 100, 100, 200, false, true, 62, 224, 0, -1, 1, 8},
+		{"no movement", 0, 100, 0, 0, false, false, 0, 100, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := level.Sweep(tt.x, tt.y, 32, 32, tt.deltaX, tt.deltaY, tt.landOnOneWay)
+			if result.Hit != tt.hit {
+				t.Fatalf("Expected hit=%v, got %+v", tt.hit, result)
+			}
+			if math.Abs(result.X-tt.wantX) > 1e-9 || math.Abs(result.Y-tt.wantY) > 1e-9 {
+				t.Errorf("Expected to stop at (%.2f, %.2f), got (%.2f, %.2f)", tt.wantX, tt.wantY, result.X, result.Y)
+			}
+			if !tt.hit {
+				if result.Time != 1 {
+					t.Errorf("Expected the whole move to complete, got time %v", result.Time)
+				}
+				return
+			}
+			if math.Abs(result.NormalX-tt.normalX) > 1e-9 || math.Abs(result.NormalY-tt.normalY) > 1e-9 {
+				t.Errorf("Expected normal (%.3f, %.3f), got (%.3f, %.3f)", tt.normalX, tt.normalY, result.NormalX, result.NormalY)
+			}
+			if result.TileX != tt.tileX || result.TileY != tt.tileY {
+				t.Errorf("Expected to hit tile (%d, %d), got (%d, %d)", tt.tileX, tt.tileY, result.TileX, result.TileY)
+			}
+		})
+	}
+}
+
+func TestSweepTimeOfImpact(t *testing.T) {
+	level := newSweepTestLevel()
+
+	result := level.Sweep(0, 100, 32, 32, 0, 200, false)
+	if expected := (256.0 - 132) / 200; math.Abs(result.Time-expected) > 1e-9 {
+		t.Errorf("Expected to land %.3f of the way through the move, got %.3f", expected, result.Time)
+	}
+
+	// A box left touching a wall is stopped straight away on the next sweep
+	first := level.Sweep(100.3, 224, 32, 32, 97.1, 0, false)
+	second := level.Sweep(first.X, first.Y, 32, 32, 5, 0, false)
+	if !second.Hit || second.Time != 0 || second.X != first.X {
+		t.Errorf("Expected a box touching a wall to stay put, moved from %v to %v", first.X, second.X)
+	}
+}
+
+func TestSweepDoesNotAllocate(t *testing.T) {
+	level := newSweepTestLevel()
+	allocs := testing.AllocsPerRun(100, func() {
+		level.Sweep(0, 100, 32, 32, 0, 200, true)
+		level.Sweep(100, 224, 32, 32, 200, 0, false)
+		level.Sweep(96, 100, 32, 32, 50, 150, true)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected sweeps not to allocate, got %v allocations per run", allocs)
+	}
+}
+
+func BenchmarkSweep(b *testing.B) {
+	level := CreateTestLevel()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		level.Sweep(64, 0, 32, 32, 0, 600, true)
+		level.Sweep(0, 544, 32, 32, 900, 0, false)
+	}
+}
+
+func BenchmarkCheckCollision(b *testing.B) {
+	level := CreateTestLevel()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		level.CheckCollision(64, 400, 32, 32)
+		level.CheckCollision(400, 544, 32, 32)
+	}
+}
+
+func BenchmarkCheckCollisionInto(b *testing.B) {
+	level := CreateTestLevel()
+	var result collision.Result
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		level.CheckCollisionInto(64, 400, 32, 32, &result)
+		level.CheckCollisionInto(400, 544, 32, 32, &result)
+	}
+}
@@ -43,6 +43,30 @@ func CreateTestLevel() *Level {
 	// Add some spike hazards
 	level.SetTile(18, level.Height-3, TileSpike)
 	level.SetTile(19, level.Height-3, TileSpike)
+
+	// A crumbling ledge sticks out from the climbable wall below the middle platform
+	level.SetTile(13, 14, TileCrumbling)
+	level.SetTile(14, 14, TileCrumbling)
+
+	// Start on the ground at the left, check in on the middle platform and leave from the high one
+	level.AddObject(Object{Type: ObjectPlayerStart, X: 64, Y: 512})
+	level.AddObject(Object{Name: "middle", Type: ObjectCheckpoint, X: 544, Y: 352, Width: 32, Height: 32})
+	level.AddObject(Object{Name: "exit", Type: ObjectExit, X: 864, Y: 192, Width: 32, Height: 64})
+
+	// Drones guard the middle platform, flying edge to edge, and circle in front of the exit
+	level.AddObject(Object{Name: "drone-middle", Type: ObjectEnemy, Kind: "drone", X: 576, Y: 320})
+	level.AddObject(Object{Name: "drone-exit", Type: ObjectEnemy, Kind: "drone", X: 752, Y: 200,
+		Properties: map[string]string{"waypoints": "752,200 900,200 900,140 752,140"}})
+
+	// A lift runs from the ground up to the high platform, and a one-way ferry waits beside
+	// the left platform to take the player up to the one-way platform
+	level.AddObject(Object{Name: "lift", Type: ObjectPlatform, Kind: "moving", X: 736, Y: 560, Width: 64, Height: 16,
+		Properties: map[string]string{"path": "736,256", "speed": "80"}})
+	level.AddObject(Object{Name: "ferry", Type: ObjectPlatform, Kind: "moving", X: 96, Y: 480, Width: 64, Height: 16,
+		Properties: map[string]string{"path": "96,320 192,320", "mode": "triggered", "one_way": "true"}})
+
+	// Loose rock under the middle platform falls on the player walking beneath
+	level.AddObject(Object{Name: "rockfall", Type: ObjectHazard, Kind: "debris", X: 520, Y: 416})
 	
 	return level
 }
@@ -60,6 +84,96 @@ func CreateSimpleLevel() *Level {
 	for x := 8; x <= 12; x++ {
 		level.SetTile(x, 10, TileSolid)
 	}
+
+	level.AddObject(Object{Type: ObjectPlayerStart, X: 100, Y: 200})
+
+	// Energy hearts on the ground either side of the platform and one on top of it
+	level.AddObject(Object{Name: "heart-left", Type: ObjectCollectible, Kind: "heart", X: 192, Y: 424})
+	level.AddObject(Object{Name: "heart-platform", Type: ObjectCollectible, Kind: "heart", X: 312, Y: 296})
+	level.AddObject(Object{Name: "heart-right", Type: ObjectCollectible, Kind: "heart", X: 480, Y: 424})
+
+	// Sad cats to give the hearts to, one on the platform and one on the ground to the right
+	level.AddObject(Object{Name: "cat-platform", Type: ObjectNPC, Kind: "cat", X: 360, Y: 300})
+	level.AddObject(Object{Name: "cat-ground", Type: ObjectNPC, Kind: "cat", X: 560, Y: 428})
 	
 	return level
 }
+
+// CreateSlopeTestLevel creates a level with 22.5° and 45° ramps and half-height tiles.
+// The ground top is at Y=320; plateaus on top of the ramps are at Y=288 and the half
+// tiles' top is at Y=304.
+func CreateSlopeTestLevel() *Level {
+	level := NewLevel(30, 12, 32, "Slope Test Level")
+
+	for x := 0; x < level.Width; x++ {
+		level.SetTile(x, 10, TileSolid)
+		level.SetTile(x, 11, TileSolid)
+	}
+
+	// 22.5° ramp up, plateau, ramp down (columns 3-10)
+	level.SetTile(3, 9, TileSlopeRight22Low)
+	level.SetTile(4, 9, TileSlopeRight22High)
+	for x := 5; x <= 8; x++ {
+		level.SetTile(x, 9, TileSolid)
+	}
+	level.SetTile(9, 9, TileSlopeLeft22High)
+	level.SetTile(10, 9, TileSlopeLeft22Low)
+
+	// 45° ramp up, plateau, ramp down (columns 14-18)
+	level.SetTile(14, 9, TileSlopeRight45)
+	for x := 15; x <= 17; x++ {
+		level.SetTile(x, 9, TileSolid)
+	}
+	level.SetTile(18, 9, TileSlopeLeft45)
+
+	// Half-height step (columns 22-23)
+	level.SetTile(22, 9, TileHalf)
+	level.SetTile(23, 9, TileHalf)
+
+	return level
+}
+
+// CreateSignalTestLevel creates a corridor of switch puzzles. The floor top is at Y=416 and
+// a ceiling at Y=288 stops the player jumping over anything. Stepping on a pressure plate
+// opens a door for a few seconds, a lever switches off an energy barrier and, with the
+// lever still on, stepping on a second plate unlocks the vault in front of the exit.
+func CreateSignalTestLevel() *Level {
+	level := NewLevel(30, 15, 32, "Signal Test Level")
+
+	for x := 0; x < level.Width; x++ {
+		level.SetTile(x, 8, TileSolid)
+		level.SetTile(x, 13, TileSolid)
+		level.SetTile(x, 14, TileSolid)
+	}
+	for y := 9; y <= 12; y++ {
+		level.SetTile(8, y, TileDoor)
+		level.SetTile(16, y, TileBarrier)
+		level.SetTile(24, y, TileDoor)
+	}
+
+	level.AddObject(Object{Type: ObjectPlayerStart, X: 64, Y: 384})
+	level.AddObject(Object{Name: "exit", Type: ObjectExit, X: 864, Y: 352, Width: 32, Height: 64})
+
+	// A timed door: the plate starts a timer that holds the gate open
+	level.AddObject(Object{Name: "plate", Type: ObjectSwitch, Kind: "pressure_plate", X: 160, Y: 384})
+	level.AddObject(Object{Name: "gate-timer", Type: ObjectLogic, Kind: "timer", X: 160, Y: 384,
+		Properties: map[string]string{"inputs": "plate", "duration": "2"}})
+	level.AddObject(Object{Name: "gate", Type: ObjectDoor, Kind: "door", X: 256, Y: 288, Width: 32, Height: 128,
+		Properties: map[string]string{"inputs": "gate-timer"}})
+
+	// A lever switches off the energy barrier
+	level.AddObject(Object{Name: "lever", Type: ObjectSwitch, Kind: "lever", X: 384, Y: 384})
+	level.AddObject(Object{Name: "field", Type: ObjectDoor, Kind: "barrier", X: 512, Y: 288, Width: 32, Height: 128,
+		Properties: map[string]string{"inputs": "lever"}})
+
+	// The vault needs the lever on and the latch, which the far plate flips, on too
+	level.AddObject(Object{Name: "plate-far", Type: ObjectSwitch, Kind: "pressure_plate", X: 608, Y: 384})
+	level.AddObject(Object{Name: "latch", Type: ObjectLogic, Kind: "toggle", X: 608, Y: 384,
+		Properties: map[string]string{"inputs": "plate-far"}})
+	level.AddObject(Object{Name: "unlock", Type: ObjectLogic, Kind: "and", X: 768, Y: 384,
+		Properties: map[string]string{"inputs": "lever latch"}})
+	level.AddObject(Object{Name: "vault", Type: ObjectDoor, Kind: "door", X: 768, Y: 288, Width: 32, Height: 128,
+		Properties: map[string]string{"inputs": "unlock"}})
+
+	return level
+}
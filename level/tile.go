@@ -1,10 +1,13 @@
 package level
 
 import (
+	"math"
+
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-// TileType represents different types of tiles
+// TileType represents different types of tiles. The constants below are built in;
+// more types can be added at runtime with RegisterTileType or LoadTileTypes.
 type TileType int
 
 const (
@@ -13,45 +16,48 @@ const (
 	TileClimbable
 	TileSpike
 	TileOneWay // Platform you can jump through from below
+
+	// Slopes are named after the direction their surface rises towards.
+	// The 22.5° slopes span two tiles: Low covers the first half of the rise, High the second.
+	TileSlopeRight45     // Rises from bottom-left to top-right
+	TileSlopeLeft45      // Rises from bottom-right to top-left
+	TileSlopeRight22Low  // Rises from the bottom to half height, left to right
+	TileSlopeRight22High // Rises from half height to the top, left to right
+	TileSlopeLeft22High  // Falls from the top to half height, left to right
+	TileSlopeLeft22Low   // Falls from half height to the bottom, left to right
+	TileHalf             // Solid bottom half of a tile
+
+	// Solid tiles with surface materials (see material.go)
+	TileIce           // Slippery: slow to speed up and slow down
+	TileConveyorLeft  // Carries anything standing on it to the left
+	TileConveyorRight // Carries anything standing on it to the right
+	TileBouncePad     // Launches anything that lands or walks on it
+	TileMud           // Slows walking and weakens jumps
+
+	// Hazards
+	TileCrumbling // Shakes and collapses after the player stands on it, then returns
+
+	// Doors open and close in response to signals (see docs/signals.md)
+	TileDoor    // Solid while its door is closed
+	TileBarrier // Energy field that blocks while switched on; its door makes it hurt to touch
 )
 
 // Tile represents a single tile in the level
 type Tile struct {
-	Type     TileType
-	X, Y     int     // Grid coordinates
-	Solid    bool    // Whether the tile blocks movement
-	Climbable bool   // Whether the player can climb on this tile
-	Sprite   *ebiten.Image // Visual representation (optional)
+	Type      TileType
+	X, Y      int           // Grid coordinates
+	Sprite    *ebiten.Image // Visual representation (optional)
+	TileIndex int           // Tileset index to draw (-1 uses the default for the tile type)
 }
 
 // NewTile creates a new tile with the given type and position
 func NewTile(tileType TileType, x, y int) *Tile {
-	tile := &Tile{
-		Type: tileType,
-		X:    x,
-		Y:    y,
-	}
-	
-	// Set properties based on tile type
-	switch tileType {
-	case TileEmpty:
-		tile.Solid = false
-		tile.Climbable = false
-	case TileSolid:
-		tile.Solid = true
-		tile.Climbable = false
-	case TileClimbable:
-		tile.Solid = true
-		tile.Climbable = true
-	case TileSpike:
-		tile.Solid = false
-		tile.Climbable = false
-	case TileOneWay:
-		tile.Solid = true // Special handling in collision detection
-		tile.Climbable = false
+	return &Tile{
+		Type:      tileType,
+		X:         x,
+		Y:         y,
+		TileIndex: -1,
 	}
-	
-	return tile
 }
 
 // GetBounds returns the world-space bounds of this tile
@@ -61,20 +67,89 @@ func (t *Tile) GetBounds(tileSize int) (x, y, width, height float64) {
 
 // IsClimbable returns whether this tile can be climbed
 func (t *Tile) IsClimbable() bool {
-	return t.Climbable
+	return t.Properties().Climbable
 }
 
 // IsSolid returns whether this tile blocks movement
 func (t *Tile) IsSolid() bool {
-	return t.Solid
+	return t.Properties().Solid
+}
+
+// Properties returns the registered properties of this tile's type
+func (t *Tile) Properties() *TileProperties {
+	return t.Type.Properties()
 }
 
 // IsDangerous returns whether this tile damages the player
 func (t *Tile) IsDangerous() bool {
-	return t.Type == TileSpike
+	return t.Properties().Damage > 0
 }
 
 // IsOneWay returns whether this is a one-way platform
 func (t *Tile) IsOneWay() bool {
-	return t.Type == TileOneWay
+	return t.Properties().OneWay
+}
+
+// IsSlope returns whether this tile has a sloped surface
+func (t *Tile) IsSlope() bool {
+	return t.SlopeGradient() != 0
+}
+
+// IsHalf returns whether this is a half-height tile
+func (t *Tile) IsHalf() bool {
+	props := t.Properties()
+	return props.Solid && props.Shape == ShapeHalf
+}
+
+// SlopeGradient returns the change in surface Y per pixel moved right (screen Y points down,
+// so a surface rising to the right is negative). Flat tiles return 0.
+func (t *Tile) SlopeGradient() float64 {
+	props := t.Properties()
+	if !props.Solid {
+		return 0
+	}
+
+	switch props.Shape {
+	case ShapeSlopeRight45:
+		return -1
+	case ShapeSlopeLeft45:
+		return 1
+	case ShapeSlopeRight22Low, ShapeSlopeRight22High:
+		return -0.5
+	case ShapeSlopeLeft22High, ShapeSlopeLeft22Low:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// SurfaceHeight returns the height of solid ground above the tile's bottom edge at a
+// horizontal offset into the tile (0 to tileSize). Tiles that aren't solid have no height.
+func (t *Tile) SurfaceHeight(localX, tileSize float64) float64 {
+	props := t.Properties()
+	if !props.Solid {
+		return 0
+	}
+
+	localX = math.Max(0, math.Min(localX, tileSize))
+	half := tileSize / 2
+
+	switch props.Shape {
+	case ShapeSlopeRight45:
+		return localX
+	case ShapeSlopeLeft45:
+		return tileSize - localX
+	case ShapeSlopeRight22Low:
+		return localX / 2
+	case ShapeSlopeRight22High:
+		return half + localX/2
+	case ShapeSlopeLeft22High:
+		return tileSize - localX/2
+	case ShapeSlopeLeft22Low:
+		return half - localX/2
+	case ShapeHalf:
+		return half
+	default:
+		return tileSize
+	}
 }
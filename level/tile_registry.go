@@ -0,0 +1,338 @@
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+
+	"ebiten-platformer/collision"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TileShape is the collision shape of a solid tile
+type TileShape int
+
+const (
+	ShapeFull TileShape = iota // Fills the whole tile
+	ShapeHalf                  // Fills the bottom half of the tile
+
+	// Slopes are named after the direction their surface rises towards (see the TileSlope types)
+	ShapeSlopeRight45
+	ShapeSlopeLeft45
+	ShapeSlopeRight22Low
+	ShapeSlopeRight22High
+	ShapeSlopeLeft22High
+	ShapeSlopeLeft22Low
+)
+
+// shapeNames are the names used for shapes in tile type definitions
+var shapeNames = map[string]TileShape{
+	"full":                ShapeFull,
+	"half":                ShapeHalf,
+	"slope_right_45":      ShapeSlopeRight45,
+	"slope_left_45":       ShapeSlopeLeft45,
+	"slope_right_22_low":  ShapeSlopeRight22Low,
+	"slope_right_22_high": ShapeSlopeRight22High,
+	"slope_left_22_high":  ShapeSlopeLeft22High,
+	"slope_left_22_low":   ShapeSlopeLeft22Low,
+}
+
+// TileProperties describes how tiles of one type collide, affect entities and look
+type TileProperties struct {
+	Name      string    // Unique name used by level data
+	Solid     bool      // Blocks movement
+	OneWay    bool      // Only supports entities from above
+	Climbable bool      // Can be climbed
+	Shape     TileShape // Collision shape of solid tiles
+	Damage    int       // Damage dealt on contact (0 is harmless)
+
+	// Crumbling tiles collapse a while after the player stands on them
+	CrumbleDelay float64 // Seconds a tile shakes under the player before collapsing (0 never crumbles)
+	RespawnDelay float64 // Seconds a collapsed tile stays gone before it returns (0 never returns)
+
+	collision.Material               // Surface material: friction, bounciness, conveyor speed...
+	Colour             color.RGBA    // Placeholder colour when no tile art is supplied (transparent draws nothing)
+	Sprite             *ebiten.Image // Image drawn for tiles of this type when the tileset has none
+}
+
+// Crumbling tile defaults
+const (
+	DefaultCrumbleDelay = 0.5 // Seconds a crumbling tile shakes before collapsing
+	DefaultRespawnDelay = 3.0 // Seconds a collapsed crumbling tile stays gone
+)
+
+// solidColour is the placeholder colour of solid ground
+var solidColour = color.RGBA{128, 128, 128, 255} // Gray
+
+// builtinTileTypes are the properties of the TileType constants, indexed by type
+var builtinTileTypes = []TileProperties{
+	TileEmpty:     {Name: "empty", Material: MaterialDefault},
+	TileSolid:     {Name: "solid", Solid: true, Material: MaterialDefault, Colour: solidColour},
+	TileClimbable: {Name: "climbable", Solid: true, Climbable: true, Material: MaterialDefault, Colour: color.RGBA{139, 69, 19, 255}}, // Brown (like metal/wood)
+	TileSpike:     {Name: "spike", Damage: 1, Material: MaterialDefault, Colour: color.RGBA{255, 0, 0, 255}},                          // Red
+	TileOneWay:    {Name: "one_way", Solid: true, OneWay: true, Material: MaterialDefault, Colour: color.RGBA{0, 255, 0, 255}},        // Green
+
+	// Shaped terrain uses the solid colour
+	TileSlopeRight45:     {Name: "slope_right_45", Solid: true, Shape: ShapeSlopeRight45, Material: MaterialDefault, Colour: solidColour},
+	TileSlopeLeft45:      {Name: "slope_left_45", Solid: true, Shape: ShapeSlopeLeft45, Material: MaterialDefault, Colour: solidColour},
+	TileSlopeRight22Low:  {Name: "slope_right_22_low", Solid: true, Shape: ShapeSlopeRight22Low, Material: MaterialDefault, Colour: solidColour},
+	TileSlopeRight22High: {Name: "slope_right_22_high", Solid: true, Shape: ShapeSlopeRight22High, Material: MaterialDefault, Colour: solidColour},
+	TileSlopeLeft22High:  {Name: "slope_left_22_high", Solid: true, Shape: ShapeSlopeLeft22High, Material: MaterialDefault, Colour: solidColour},
+	TileSlopeLeft22Low:   {Name: "slope_left_22_low", Solid: true, Shape: ShapeSlopeLeft22Low, Material: MaterialDefault, Colour: solidColour},
+	TileHalf:             {Name: "half", Solid: true, Shape: ShapeHalf, Material: MaterialDefault, Colour: solidColour},
+
+	// Surface materials
+	TileIce:           {Name: "ice", Solid: true, Material: MaterialIce, Colour: color.RGBA{170, 220, 255, 255}},                            // Pale blue
+	TileConveyorLeft:  {Name: "conveyor_left", Solid: true, Material: conveyor(-DefaultConveyorSpeed), Colour: color.RGBA{70, 70, 90, 255}}, // Dark slate
+	TileConveyorRight: {Name: "conveyor_right", Solid: true, Material: conveyor(DefaultConveyorSpeed), Colour: color.RGBA{90, 70, 70, 255}},
+	TileBouncePad:     {Name: "bounce_pad", Solid: true, Material: MaterialBouncePad, Colour: color.RGBA{255, 200, 0, 255}}, // Yellow
+	TileMud:           {Name: "mud", Solid: true, Material: MaterialMud, Colour: color.RGBA{90, 60, 30, 255}},               // Dark brown
+
+	// Hazards
+	TileCrumbling: {Name: "crumbling", Solid: true, CrumbleDelay: DefaultCrumbleDelay, RespawnDelay: DefaultRespawnDelay, Material: MaterialDefault, Colour: color.RGBA{190, 150, 100, 255}}, // Sandstone
+
+	// Doors
+	TileDoor:    {Name: "door", Solid: true, Material: MaterialDefault, Colour: color.RGBA{80, 100, 130, 255}},    // Steel blue
+	TileBarrier: {Name: "barrier", Solid: true, Material: MaterialDefault, Colour: color.RGBA{90, 220, 255, 255}}, // Glowing cyan
+}
+
+// unknownTileType is returned for types that were never registered
+var unknownTileType = &TileProperties{Name: "unknown", Material: MaterialDefault}
+
+// tileRegistry holds the properties of every tile type, indexed by TileType
+type tileRegistry struct {
+	types []*TileProperties
+	names map[string]TileType
+}
+
+// registry is the process-wide tile type registry. Tile types are registered while
+// loading, before levels using them are updated or drawn.
+var registry = newTileRegistry()
+
+// newTileRegistry creates a registry holding the built-in tile types
+func newTileRegistry() *tileRegistry {
+	r := &tileRegistry{names: make(map[string]TileType)}
+	for tileType := range builtinTileTypes {
+		props := builtinTileTypes[tileType]
+		r.types = append(r.types, &props)
+		r.names[props.Name] = TileType(tileType)
+	}
+	return r
+}
+
+// RegisterTileType adds a tile type with the given properties and returns its TileType.
+// Material fields left at zero take MaterialDefault's values, so a material that only sets
+// its friction still walks and jumps normally. Registering a name again replaces
+// that type's properties and returns the same TileType, so reloading a level keeps
+// existing tiles valid. Built-in types can't be replaced.
+func RegisterTileType(props TileProperties) (TileType, error) {
+	if props.Name == "" {
+		return TileEmpty, fmt.Errorf("tile type needs a name")
+	}
+	props.Material = withMaterialDefaults(props.Material)
+
+	if tileType, exists := registry.names[props.Name]; exists {
+		if int(tileType) < len(builtinTileTypes) {
+			return TileEmpty, fmt.Errorf("tile type %q is built in and can't be redefined", props.Name)
+		}
+		*registry.types[tileType] = props
+		return tileType, nil
+	}
+
+	tileType := TileType(len(registry.types))
+	registry.types = append(registry.types, &props)
+	registry.names[props.Name] = tileType
+	return tileType, nil
+}
+
+// withMaterialDefaults fills in the fields of a material left at zero whose zero would
+// stop the player: its name, friction and walking and jump scales
+func withMaterialDefaults(material collision.Material) collision.Material {
+	if material.Name == "" {
+		material.Name = MaterialDefault.Name
+	}
+	if material.Friction == 0 {
+		material.Friction = MaterialDefault.Friction
+	}
+	if material.SpeedScale == 0 {
+		material.SpeedScale = MaterialDefault.SpeedScale
+	}
+	if material.JumpScale == 0 {
+		material.JumpScale = MaterialDefault.JumpScale
+	}
+	return material
+}
+
+// LookupTileType returns the tile type registered under a name
+func LookupTileType(name string) (TileType, bool) {
+	tileType, exists := registry.names[name]
+	return tileType, exists
+}
+
+// TileTypes returns every registered tile type in registration order, built-in types first
+func TileTypes() []TileType {
+	types := make([]TileType, len(registry.types))
+	for i := range types {
+		types[i] = TileType(i)
+	}
+	return types
+}
+
+// Crumbles returns whether tiles of this type collapse after the player stands on them
+func (p *TileProperties) Crumbles() bool {
+	return p.CrumbleDelay > 0
+}
+
+// Properties returns the registered properties of a tile type. They are shared, so
+// callers must not modify them; use RegisterTileType instead.
+func (t TileType) Properties() *TileProperties {
+	if t < 0 || int(t) >= len(registry.types) {
+		return unknownTileType
+	}
+	return registry.types[t]
+}
+
+// String returns the tile type's registered name
+func (t TileType) String() string {
+	return t.Properties().Name
+}
+
+// TileTypeDefinition is a tile type as written in level data
+type TileTypeDefinition struct {
+	Name          string   `json:"name"`
+	Solid         bool     `json:"solid"`
+	OneWay        bool     `json:"one_way"`
+	Climbable     bool     `json:"climbable"`
+	Shape         string   `json:"shape"` // A shapeNames key; empty is "full"
+	Damage        int      `json:"damage"`
+	CrumbleDelay  float64  `json:"crumble_delay"`
+	RespawnDelay  float64  `json:"respawn_delay"`
+	Material      string   `json:"material"` // A Materials key the fields below override; empty is "default"
+	Friction      *float64 `json:"friction"`
+	Bounciness    *float64 `json:"bounciness"`
+	LaunchSpeed   *float64 `json:"launch_speed"`
+	ConveyorSpeed *float64 `json:"conveyor_speed"`
+	SpeedScale    *float64 `json:"speed_scale"`
+	JumpScale     *float64 `json:"jump_scale"`
+	Colour        string   `json:"colour"` // "#rrggbb" or "#rrggbbaa"
+	Sprite        string   `json:"sprite"` // Image path passed to the sprite loader
+}
+
+// Properties converts a definition into tile properties, loading its sprite with
+// loadSprite (which may be nil when the definition has no sprite)
+func (def TileTypeDefinition) Properties(loadSprite func(path string) (*ebiten.Image, error)) (TileProperties, error) {
+	props := TileProperties{
+		Name:      def.Name,
+		Solid:     def.Solid || def.OneWay, // One-way platforms collide as solid from above
+		OneWay:    def.OneWay,
+		Climbable: def.Climbable,
+		Damage:    def.Damage,
+		Material:  MaterialDefault,
+
+		CrumbleDelay: def.CrumbleDelay,
+		RespawnDelay: def.RespawnDelay,
+	}
+	if props.CrumbleDelay < 0 || props.RespawnDelay < 0 {
+		return props, fmt.Errorf("tile type %q has a negative crumble or respawn delay", def.Name)
+	}
+
+	if def.Material != "" {
+		material, exists := Materials[def.Material]
+		if !exists {
+			return props, fmt.Errorf("tile type %q has unknown material %q", def.Name, def.Material)
+		}
+		props.Material = material
+	}
+	for _, override := range []struct {
+		value *float64
+		field *float64
+	}{
+		{def.Friction, &props.Friction},
+		{def.Bounciness, &props.Bounciness},
+		{def.LaunchSpeed, &props.LaunchSpeed},
+		{def.ConveyorSpeed, &props.ConveyorSpeed},
+		{def.SpeedScale, &props.SpeedScale},
+		{def.JumpScale, &props.JumpScale},
+	} {
+		if override.value != nil {
+			*override.field = *override.value
+		}
+	}
+
+	if def.Shape != "" {
+		shape, exists := shapeNames[def.Shape]
+		if !exists {
+			return props, fmt.Errorf("tile type %q has unknown shape %q", def.Name, def.Shape)
+		}
+		props.Shape = shape
+	}
+
+	if def.Colour != "" {
+		colour, err := parseColour(def.Colour)
+		if err != nil {
+			return props, fmt.Errorf("tile type %q: %w", def.Name, err)
+		}
+		props.Colour = colour
+	}
+
+	if def.Sprite != "" {
+		if loadSprite == nil {
+			return props, fmt.Errorf("tile type %q has a sprite but no sprite loader was given", def.Name)
+		}
+		sprite, err := loadSprite(def.Sprite)
+		if err != nil {
+			return props, fmt.Errorf("failed to load sprite for tile type %q: %w", def.Name, err)
+		}
+		props.Sprite = sprite
+	}
+
+	return props, nil
+}
+
+// LoadTileTypes reads a JSON array of tile type definitions and registers them, returning
+// their types in order. loadSprite resolves sprite paths, for example AssetManager.LoadImage.
+func LoadTileTypes(r io.Reader, loadSprite func(path string) (*ebiten.Image, error)) ([]TileType, error) {
+	var defs []TileTypeDefinition
+	if err := json.NewDecoder(r).Decode(&defs); err != nil {
+		return nil, fmt.Errorf("failed to decode tile types: %w", err)
+	}
+	return registerTileTypes(defs, loadSprite)
+}
+
+// registerTileTypes registers tile type definitions in order, returning their types
+func registerTileTypes(defs []TileTypeDefinition, loadSprite func(path string) (*ebiten.Image, error)) ([]TileType, error) {
+	types := make([]TileType, 0, len(defs))
+	for _, def := range defs {
+		props, err := def.Properties(loadSprite)
+		if err != nil {
+			return nil, err
+		}
+		tileType, err := RegisterTileType(props)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, tileType)
+	}
+	return types, nil
+}
+
+// parseColour parses a "#rrggbb" or "#rrggbbaa" hex colour
+func parseColour(s string) (color.RGBA, error) {
+	var c color.RGBA
+	c.A = 255
+
+	var n int
+	var err error
+	switch len(s) {
+	case 7:
+		n, err = fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	case 9:
+		n, err = fmt.Sscanf(s, "#%02x%02x%02x%02x", &c.R, &c.G, &c.B, &c.A)
+	}
+	if err != nil || n < 3 {
+		return c, fmt.Errorf("invalid colour %q, expected #rrggbb or #rrggbbaa", s)
+	}
+	return c, nil
+}
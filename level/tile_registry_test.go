@@ -0,0 +1,244 @@
+package level
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+)
+
+func TestBuiltinTileTypes(t *testing.T) {
+	tests := []struct {
+		tileType  TileType
+		name      string
+		solid     bool
+		oneWay    bool
+		climbable bool
+		dangerous bool
+	}{
+		{TileEmpty, "empty", false, false, false, false},
+		{TileSolid, "solid", true, false, false, false},
+		{TileClimbable, "climbable", true, false, true, false},
+		{TileSpike, "spike", false, false, false, true},
+		{TileOneWay, "one_way", true, true, false, false},
+		{TileHalf, "half", true, false, false, false},
+		{TileCrumbling, "crumbling", true, false, false, false},
+		{TileDoor, "door", true, false, false, false},
+		{TileBarrier, "barrier", true, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tile := NewTile(tt.tileType, 0, 0)
+			if tile.IsSolid() != tt.solid || tile.IsOneWay() != tt.oneWay ||
+				tile.IsClimbable() != tt.climbable || tile.IsDangerous() != tt.dangerous {
+				t.Errorf("Unexpected properties for %v: solid=%v one-way=%v climbable=%v dangerous=%v",
+					tt.tileType, tile.IsSolid(), tile.IsOneWay(), tile.IsClimbable(), tile.IsDangerous())
+			}
+
+			if tt.tileType.String() != tt.name {
+				t.Errorf("Expected name %q, got %q", tt.name, tt.tileType.String())
+			}
+			if found, exists := LookupTileType(tt.name); !exists || found != tt.tileType {
+				t.Errorf("Expected %q to look up %v, got %v (exists=%v)", tt.name, tt.tileType, found, exists)
+			}
+			if tt.tileType.Properties().Friction != 1 {
+				t.Error("Built-in tiles should have normal friction")
+			}
+		})
+	}
+
+	if crumbling := TileCrumbling.Properties(); !crumbling.Crumbles() || crumbling.RespawnDelay != DefaultRespawnDelay {
+		t.Errorf("Expected crumbling tiles to crumble and return, got %+v", crumbling)
+	}
+	if TileSolid.Properties().Crumbles() {
+		t.Error("Expected solid tiles not to crumble")
+	}
+
+	if TileType(9999).Properties().Solid {
+		t.Error("Unregistered tile types should behave as empty")
+	}
+}
+
+func TestRegisterTileType(t *testing.T) {
+	crate, err := RegisterTileType(TileProperties{Name: "test_crate", Solid: true, Material: MaterialDefault})
+	if err != nil {
+		t.Fatalf("Failed to register tile type: %v", err)
+	}
+	if int(crate) < len(builtinTileTypes) {
+		t.Errorf("Registered types should come after the built-in types, got %d", crate)
+	}
+
+	// Registered types collide like the built-in ones
+	level := NewLevel(5, 5, 32, "Registry")
+	level.SetTile(1, 3, crate)
+	if result := level.CheckCollision(32, 64, 32, 32); !result.OnGround {
+		t.Error("Expected to stand on a registered solid tile")
+	}
+
+	// Registering the name again updates the existing type
+	again, err := RegisterTileType(TileProperties{Name: "test_crate", Solid: true, Damage: 2, Material: MaterialDefault})
+	if err != nil || again != crate {
+		t.Fatalf("Expected re-registering to return %v, got %v (%v)", crate, again, err)
+	}
+	if !level.GetTile(1, 3).IsDangerous() {
+		t.Error("Existing tiles should see the updated properties")
+	}
+
+	if _, err := RegisterTileType(TileProperties{Name: "solid"}); err == nil {
+		t.Error("Expected an error redefining a built-in type")
+	}
+	if _, err := RegisterTileType(TileProperties{}); err == nil {
+		t.Error("Expected an error for a nameless type")
+	}
+}
+
+func TestRegisterTileType_PartialMaterial(t *testing.T) {
+	tests := []struct {
+		name     string
+		material collision.Material
+		want     collision.Material
+	}{
+		{"zero", collision.Material{}, MaterialDefault},
+		{"friction only", collision.Material{Name: "slick", Friction: 0.1},
+			collision.Material{Name: "slick", Friction: 0.1, SpeedScale: 1, JumpScale: 1}},
+		{"unnamed bounce", collision.Material{Bounciness: 0.5, JumpScale: 2},
+			collision.Material{Name: MaterialDefault.Name, Friction: 1, Bounciness: 0.5, SpeedScale: 1, JumpScale: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tileType, err := RegisterTileType(TileProperties{Name: "test_partial_material", Solid: true, Material: tt.material})
+			if err != nil {
+				t.Fatalf("Failed to register tile type: %v", err)
+			}
+			if got := tileType.Properties().Material; got != tt.want {
+				t.Errorf("Expected material %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegisterTileType_RedefineChangesCollision(t *testing.T) {
+	ledge, err := RegisterTileType(TileProperties{Name: "test_ledge", Solid: true, Climbable: true})
+	if err != nil {
+		t.Fatalf("Failed to register tile type: %v", err)
+	}
+	level := NewLevel(5, 5, 32, "Redefine")
+	level.SetTile(1, 3, ledge)
+	tile := level.GetTile(1, 3)
+	if result := level.CheckCollision(32, 64, 32, 32); !result.OnGround || !tile.IsClimbable() {
+		t.Fatal("Expected to stand on a solid, climbable ledge")
+	}
+
+	// Redefined as neither, the tile already in the level is walked through
+	if _, err := RegisterTileType(TileProperties{Name: "test_ledge"}); err != nil {
+		t.Fatalf("Failed to redefine tile type: %v", err)
+	}
+	if tile.IsSolid() || tile.IsClimbable() {
+		t.Errorf("Expected the existing tile to follow its redefined type, solid=%v climbable=%v", tile.IsSolid(), tile.IsClimbable())
+	}
+	if result := level.CheckCollision(32, 64, 32, 32); result.OnGround || result.CollisionY {
+		t.Errorf("Expected to pass through the redefined tile, on ground=%v", result.OnGround)
+	}
+}
+
+func TestLoadTileTypes(t *testing.T) {
+	sprite := ebiten.NewImage(32, 32)
+	var loaded []string
+	loadSprite := func(path string) (*ebiten.Image, error) {
+		loaded = append(loaded, path)
+		return sprite, nil
+	}
+
+	data := `[
+		{"name": "test_ice", "solid": true, "friction": 0.1, "colour": "#a0e0ff"},
+		{"name": "test_belt", "solid": true, "conveyor_speed": -60, "sprite": "tiles/belt.png"},
+		{"name": "test_ice_ramp", "solid": true, "shape": "slope_right_45", "friction": 0.1},
+		{"name": "test_cloud", "one_way": true, "bounciness": 0.5, "colour": "#ffffff80"},
+		{"name": "test_rubble", "solid": true, "crumble_delay": 1.5}
+	]`
+
+	types, err := LoadTileTypes(strings.NewReader(data), loadSprite)
+	if err != nil {
+		t.Fatalf("Failed to load tile types: %v", err)
+	}
+	if len(types) != 5 {
+		t.Fatalf("Expected 5 tile types, got %d", len(types))
+	}
+
+	ice := types[0].Properties()
+	if ice.Friction != 0.1 || ice.Colour != (color.RGBA{0xa0, 0xe0, 0xff, 255}) {
+		t.Errorf("Unexpected ice properties: %+v", ice)
+	}
+
+	belt := types[1].Properties()
+	if belt.Friction != 1 || belt.ConveyorSpeed != -60 || belt.Sprite != sprite {
+		t.Errorf("Unexpected conveyor properties: %+v", belt)
+	}
+	if len(loaded) != 1 || loaded[0] != "tiles/belt.png" {
+		t.Errorf("Expected the belt sprite to be loaded, loaded %v", loaded)
+	}
+
+	ramp := NewTile(types[2], 0, 0)
+	if !ramp.IsSlope() || ramp.SlopeGradient() != -1 {
+		t.Error("Expected a shape from level data to make a slope")
+	}
+
+	cloud := NewTile(types[3], 0, 0)
+	if !cloud.IsOneWay() || !cloud.IsSolid() || cloud.Properties().Colour.A != 0x80 {
+		t.Errorf("Unexpected one-way properties: %+v", cloud.Properties())
+	}
+
+	rubble := types[4].Properties()
+	if !rubble.Crumbles() || rubble.CrumbleDelay != 1.5 || rubble.RespawnDelay != 0 {
+		t.Errorf("Expected rubble that crumbles after 1.5s and never returns, got %+v", rubble)
+	}
+}
+
+func TestLoadTileTypes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"invalid json", `{"name": `},
+		{"unknown shape", `[{"name": "test_bad_shape", "shape": "circle"}]`},
+		{"bad colour", `[{"name": "test_bad_colour", "colour": "blue"}]`},
+		{"sprite without loader", `[{"name": "test_no_loader", "sprite": "x.png"}]`},
+		{"built-in name", `[{"name": "spike"}]`},
+		{"negative delay", `[{"name": "test_bad_delay", "solid": true, "crumble_delay": 1, "respawn_delay": -1}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadTileTypes(strings.NewReader(tt.data), nil); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	failing := func(path string) (*ebiten.Image, error) { return nil, fmt.Errorf("missing %s", path) }
+	if _, err := LoadTileTypes(strings.NewReader(`[{"name": "test_missing_sprite", "sprite": "x.png"}]`), failing); err == nil {
+		t.Error("Expected sprite loading errors to be returned")
+	}
+}
+
+func TestTileRenderer_RegisteredSprite(t *testing.T) {
+	sprite := ebiten.NewImage(32, 32)
+	tileType, err := RegisterTileType(TileProperties{Name: "test_sprite_tile", Solid: true, Material: MaterialDefault, Sprite: sprite})
+	if err != nil {
+		t.Fatalf("Failed to register tile type: %v", err)
+	}
+
+	level := NewLevel(2, 2, 32, "Sprites")
+	level.SetTile(0, 0, tileType)
+	renderer := NewTileRenderer(level, nil)
+
+	if img := renderer.tileImage(level.GetTile(0, 0)); img != sprite {
+		t.Error("Expected the type's registered sprite to be drawn")
+	}
+}
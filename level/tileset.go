@@ -0,0 +1,103 @@
+package level
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tileset is a shared atlas of equally sized tile images
+type Tileset struct {
+	Image    *ebiten.Image // Atlas image, tiles laid out left to right, top to bottom
+	TileSize int           // Size of each tile in pixels
+	Columns  int           // Number of tiles per atlas row
+	tiles    []*ebiten.Image
+}
+
+// NewTileset slices an atlas image into tiles. Sub-images share the atlas texture,
+// so drawing many of them batches into few draw calls.
+func NewTileset(atlas *ebiten.Image, tileSize int) *Tileset {
+	bounds := atlas.Bounds()
+	columns := bounds.Dx() / tileSize
+	rows := bounds.Dy() / tileSize
+
+	tiles := make([]*ebiten.Image, 0, columns*rows)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < columns; col++ {
+			x := bounds.Min.X + col*tileSize
+			y := bounds.Min.Y + row*tileSize
+			tiles = append(tiles, atlas.SubImage(image.Rect(x, y, x+tileSize, y+tileSize)).(*ebiten.Image))
+		}
+	}
+
+	return &Tileset{
+		Image:    atlas,
+		TileSize: tileSize,
+		Columns:  columns,
+		tiles:    tiles,
+	}
+}
+
+// Tile returns the image at a tileset index, or nil if out of range
+func (ts *Tileset) Tile(index int) *ebiten.Image {
+	if index < 0 || index >= len(ts.tiles) {
+		return nil
+	}
+	return ts.tiles[index]
+}
+
+// TileCount returns the number of tiles in the atlas
+func (ts *Tileset) TileCount() int {
+	return len(ts.tiles)
+}
+
+// colourTileCount returns the number of atlas slots needed to index every coloured tile type
+func colourTileCount() int {
+	count := 0
+	for _, tileType := range TileTypes() {
+		if tileType.Properties().Colour.A > 0 {
+			count = int(tileType) + 1
+		}
+	}
+	return count
+}
+
+// fillColourTiles draws every coloured tile type into the top of an atlas with the
+// given number of columns, so each type's tileset index is its TileType value
+func fillColourTiles(atlas *ebiten.Image, columns, tileSize int) {
+	for _, tileType := range TileTypes() {
+		if c := tileType.Properties().Colour; c.A > 0 {
+			x := int(tileType) % columns * tileSize
+			y := int(tileType) / columns * tileSize
+			fillTileShape(atlas, tileType, x, y, tileSize, c)
+		}
+	}
+}
+
+// NewColourTileset builds a placeholder atlas with one flat colour tile per registered
+// TileType with a colour, indexed by the TileType value. Index 0 (TileEmpty) is left transparent.
+func NewColourTileset(tileSize int) *Tileset {
+	count := max(colourTileCount(), 1)
+	atlas := ebiten.NewImage(count*tileSize, tileSize)
+	fillColourTiles(atlas, count, tileSize)
+	return NewTileset(atlas, tileSize)
+}
+
+// fillTileShape fills a tile-sized cell of the atlas, following the surface of
+// slopes and half tiles so they look the way they collide
+func fillTileShape(atlas *ebiten.Image, tileType TileType, x, y, tileSize int, c color.RGBA) {
+	tile := &Tile{Type: tileType}
+	if !tile.IsSlope() && !tile.IsHalf() {
+		atlas.SubImage(image.Rect(x, y, x+tileSize, y+tileSize)).(*ebiten.Image).Fill(c)
+		return
+	}
+
+	for column := 0; column < tileSize; column++ {
+		height := int(tile.SurfaceHeight(float64(column)+0.5, float64(tileSize)) + 0.5)
+		if height <= 0 {
+			continue
+		}
+		atlas.SubImage(image.Rect(x+column, y+tileSize-height, x+column+1, y+tileSize)).(*ebiten.Image).Fill(c)
+	}
+}
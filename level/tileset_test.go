@@ -0,0 +1,45 @@
+package level
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestNewTileset(t *testing.T) {
+	atlas := ebiten.NewImage(96, 64) // 3x2 tiles of 32px
+	tileset := NewTileset(atlas, 32)
+
+	if tileset.TileCount() != 6 {
+		t.Errorf("Expected 6 tiles, got %d", tileset.TileCount())
+	}
+	if tileset.Columns != 3 {
+		t.Errorf("Expected 3 columns, got %d", tileset.Columns)
+	}
+
+	// Index 4 is the second tile of the second row
+	bounds := tileset.Tile(4).Bounds()
+	if bounds.Min.X != 32 || bounds.Min.Y != 32 || bounds.Dx() != 32 || bounds.Dy() != 32 {
+		t.Errorf("Unexpected bounds for tile 4: %v", bounds)
+	}
+
+	if tileset.Tile(-1) != nil || tileset.Tile(6) != nil {
+		t.Error("Out-of-range indices should return nil")
+	}
+}
+
+func TestNewColourTileset(t *testing.T) {
+	tileset := NewColourTileset(16)
+
+	for _, tileType := range TileTypes() {
+		if tileType.Properties().Colour.A == 0 {
+			continue
+		}
+		if tileset.Tile(int(tileType)) == nil {
+			t.Errorf("Expected a tile for type %v", tileType)
+		}
+	}
+	if tileset.Tile(int(TileSolid)).Bounds().Dx() != 16 {
+		t.Error("Colour tiles should match the requested tile size")
+	}
+}
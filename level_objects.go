@@ -0,0 +1,588 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/collision"
+	"ebiten-platformer/entities"
+	"ebiten-platformer/level"
+)
+
+// Collectible kinds
+const (
+	kindHeart = "heart"
+)
+
+// NPC kinds
+const (
+	kindCat = "cat"
+)
+
+// Enemy kinds
+const (
+	kindDrone = "drone"
+)
+
+// Platform kinds
+const (
+	kindMovingPlatform = "moving"
+)
+
+// Hazard kinds
+const (
+	kindDebris = "debris"
+)
+
+// Switch kinds
+const (
+	kindPressurePlate = "pressure_plate"
+	kindLever         = "lever"
+)
+
+// Door kinds
+const (
+	kindDoor    = "door"
+	kindBarrier = "barrier"
+)
+
+// catScore is the default score for cheering up a cat, unless its "score" property says otherwise
+const catScore = 100
+
+// objectSpawner creates the entities for a level object and spawns them into the game's world
+type objectSpawner func(g *RoboGame, object *level.Object) error
+
+// objectSpawners spawn each type of level object. The player start isn't here: populateLevel
+// spawns the player first so other entities can find it.
+var objectSpawners = map[level.ObjectType]objectSpawner{
+	level.ObjectCheckpoint:  spawnCheckpoint,
+	level.ObjectCollectible: spawnCollectible,
+	level.ObjectEnemy:       spawnEnemy,
+	level.ObjectTrigger:     spawnTrigger,
+	level.ObjectExit:        spawnExit,
+	level.ObjectNPC:         spawnNPC,
+	level.ObjectPlatform:    spawnPlatform,
+	level.ObjectHazard:      spawnHazard,
+	level.ObjectSwitch:      spawnSwitch,
+	level.ObjectDoor:        spawnDoor,
+	level.ObjectLogic:       spawnLogic,
+}
+
+// loadLevel makes a level the current one: it prepares the level's tiles, picks up the
+// player's saved progress in it and populates it
+func (g *RoboGame) loadLevel(lvl *level.Level) error {
+	if _, exists := lvl.PlayerStart(); !exists {
+		return fmt.Errorf("level %q has no player start", lvl.Name)
+	}
+
+	// Placeholder art until real tiles exist; autotiling picks edge variants
+	if lvl.Tileset == nil {
+		tileset, autotileRules := level.NewPlaceholderAutotileset(lvl.TileSize)
+		lvl.Tileset = tileset
+		level.NewAutotiler(lvl, autotileRules...).Apply()
+	}
+	g.currentLevel = lvl
+	g.lastCheckpoint = nil
+
+	g.levelProgress = g.GetSaveManager().GetLevelProgress(lvl.Name)
+	g.levelProgress.HeartsTotal = countHearts(lvl)
+
+	return g.populateLevel()
+}
+
+// loadLevelFile reads a JSON level file, registering its tile types and resolving their
+// sprite paths with loadSprite
+func loadLevelFile(path string, loadSprite func(path string) (*ebiten.Image, error)) (*level.Level, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open level: %w", err)
+	}
+	defer file.Close()
+
+	lvl, err := level.LoadLevel(file, loadSprite)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return lvl, nil
+}
+
+// respawn puts the player back at the last checkpoint, or the level's start, and resets
+// everything else in the level. Collected hearts stay collected and helped cats stay happy.
+func (g *RoboGame) respawn() error {
+	log.Printf("Respawning in level %q", g.currentLevel.Name)
+	return g.populateLevel()
+}
+
+// populateLevel creates a new world for the current level with the player at its spawn
+// point and an entity for every object placed in the level. Tiles changed while playing,
+// such as collapsed crumbling tiles and open doors, are put back first, and every signal
+// starts off.
+func (g *RoboGame) populateLevel() error {
+	lvl := g.currentLevel
+	lvl.RestoreTiles()
+	g.player = entities.NewPlayer(0, 0, g.playerImage)
+	g.player.SetPosition(g.spawnPoint())
+
+	// Moving platforms add themselves to the level's tiles for the player to collide with
+	g.solids = collision.NewSolids(lvl)
+	g.player.SetLevel(g.solids)
+	g.inputHandler = entities.NewInputHandler(g.player)
+
+	// Everything else in the level lives alongside the player in the world
+	g.world = entities.NewWorld(lvl.NewSpatialHash())
+	g.world.Spawn(g.player)
+	g.spawnCrumblingBlocks()
+	g.signals = entities.NewSignals()
+
+	for _, object := range lvl.Objects {
+		if object.Type == level.ObjectPlayerStart {
+			continue
+		}
+		spawn, exists := objectSpawners[object.Type]
+		if !exists {
+			log.Printf("Skipping %s %q in level %q: nothing spawns %s objects yet", object.Type, object.Kind, lvl.Name, object.Type)
+			continue
+		}
+		if err := spawn(g, object); err != nil {
+			return fmt.Errorf("failed to spawn objects in level %q: %w", lvl.Name, err)
+		}
+	}
+	return nil
+}
+
+// spawnPoint returns where the player appears: standing at the bottom centre of the last
+// checkpoint, or at the level's player start
+func (g *RoboGame) spawnPoint() (x, y float64) {
+	if g.lastCheckpoint == nil {
+		start, _ := g.currentLevel.PlayerStart()
+		return start.X, start.Y
+	}
+
+	_, _, playerWidth, playerHeight := g.player.GetBounds()
+	x, y, width, height := g.objectArea(g.lastCheckpoint)
+	return x + (width-playerWidth)/2, y + height - playerHeight
+}
+
+// hasFallenOut returns whether the player has dropped below the bottom of the level
+func (g *RoboGame) hasFallenOut() bool {
+	_, levelHeight := g.currentLevel.GetWorldBounds()
+	_, y, _, _ := g.player.GetBounds()
+	return y > levelHeight
+}
+
+// spawnCheckpoint spawns an area that becomes the player's checkpoint when touched
+func spawnCheckpoint(g *RoboGame, object *level.Object) error {
+	area := g.spawnObjectArea(object, entities.TagCheckpoint)
+	area.OnEnter = func(area *entities.Area, other entities.Entity) {
+		if other == g.player && g.lastCheckpoint != object {
+			g.lastCheckpoint = object
+			log.Printf("Checkpoint %q reached", object.Name)
+		}
+	}
+	return nil
+}
+
+// spawnCollectible spawns a collectible that the player hasn't collected yet
+func spawnCollectible(g *RoboGame, object *level.Object) error {
+	switch object.Kind {
+	case kindHeart:
+		key := object.Key()
+		if g.levelProgress.HasHeart(key) {
+			return nil
+		}
+
+		heart := entities.NewHeart(key, object.X, object.Y)
+		var err error
+		if heart.BobHeight, err = object.FloatProperty("bob_height", heart.BobHeight); err != nil {
+			return err
+		}
+		if heart.BobSpeed, err = object.FloatProperty("bob_speed", heart.BobSpeed); err != nil {
+			return err
+		}
+		heart.OnCollect = g.collectHeart
+		g.world.Spawn(heart)
+		return nil
+	}
+	return fmt.Errorf("collectible %q has unknown kind %q", object.Key(), object.Kind)
+}
+
+// collectHeart records a collected heart in the level's progress and saves it
+func (g *RoboGame) collectHeart(heart *entities.Heart) {
+	if !g.levelProgress.CollectHeart(heart.Key) {
+		return
+	}
+	log.Printf("Heart collected: %d/%d in level %q",
+		g.levelProgress.HeartsCollectedCount(), g.levelProgress.HeartsTotal, g.currentLevel.Name)
+	if err := g.GetSaveManager().Save(); err != nil {
+		log.Printf("Could not save progress: %v", err)
+	}
+}
+
+// spawnNPC spawns a non-player character. Cats the player has already helped spawn happy.
+func spawnNPC(g *RoboGame, object *level.Object) error {
+	switch object.Kind {
+	case kindCat:
+		cat := entities.NewCat(object.Key(), object.X, object.Y)
+		var err error
+		if cat.WalkSpeed, err = object.FloatProperty("walk_speed", cat.WalkSpeed); err != nil {
+			return err
+		}
+		score, err := object.IntProperty("score", catScore)
+		if err != nil {
+			return err
+		}
+		if g.levelProgress.HasHelpedCat(cat.Key) {
+			cat.SetMood(entities.CatHappy)
+		}
+		cat.SetLevel(g.currentLevel)
+		cat.OnHelp = func(cat *entities.Cat) bool {
+			return g.helpCat(cat, score)
+		}
+		g.world.Spawn(cat)
+		return nil
+	}
+	return fmt.Errorf("npc %q has unknown kind %q", object.Key(), object.Kind)
+}
+
+// helpCat gives a sad cat one of the player's spare hearts and scores it. It returns false,
+// leaving the cat sad, when every collected heart has already been given away.
+func (g *RoboGame) helpCat(cat *entities.Cat, score int) bool {
+	if g.levelProgress.HeartsAvailable() == 0 {
+		log.Printf("Cat %q needs a heart, but there are none to spare", cat.Key)
+		return false
+	}
+	if !g.levelProgress.HelpCat(cat.Key) {
+		return false
+	}
+	g.levelProgress.Score += score
+	log.Printf("Cat %q cheered up: %d cats helped, score %d in level %q",
+		cat.Key, g.levelProgress.CatsHelpedCount(), g.levelProgress.Score, g.currentLevel.Name)
+	if err := g.GetSaveManager().Save(); err != nil {
+		log.Printf("Could not save progress: %v", err)
+	}
+	return true
+}
+
+// spawnEnemy spawns an enemy
+func spawnEnemy(g *RoboGame, object *level.Object) error {
+	switch object.Kind {
+	case kindDrone:
+		drone := entities.NewDrone(object.X, object.Y)
+		waypoints, err := object.PointsProperty("waypoints")
+		if err != nil {
+			return err
+		}
+		if waypoints == nil {
+			drone.Waypoints = platformPatrol(g.currentLevel, drone)
+		}
+		for _, point := range waypoints {
+			drone.Waypoints = append(drone.Waypoints, entities.Waypoint{X: point.X, Y: point.Y})
+		}
+
+		for _, setting := range []struct {
+			key   string
+			value *float64
+		}{
+			{"patrol_speed", &drone.PatrolSpeed},
+			{"chase_speed", &drone.ChaseSpeed},
+			{"sight_range", &drone.SightRange},
+		} {
+			if *setting.value, err = object.FloatProperty(setting.key, *setting.value); err != nil {
+				return err
+			}
+		}
+		drone.SetLevel(g.currentLevel)
+		g.world.Spawn(drone)
+		return nil
+	}
+	return fmt.Errorf("enemy %q has unknown kind %q", object.Key(), object.Kind)
+}
+
+// platformPatrol returns waypoints that fly a drone from one edge of the platform below it
+// to the other at its current height, or none to hover in place if there is no ground below
+func platformPatrol(lvl *level.Level, drone *entities.Drone) []entities.Waypoint {
+	x, y, width, height := drone.GetBounds()
+	left, right, _, found := lvl.PlatformSpan(x+width/2, y+height)
+	if !found {
+		return nil
+	}
+	return []entities.Waypoint{{X: left, Y: y}, {X: right - width, Y: y}}
+}
+
+// spawnPlatform spawns a moving platform the size of the object that travels its path.
+// Platforms placed as points are a tile high and three tiles wide.
+func spawnPlatform(g *RoboGame, object *level.Object) error {
+	switch object.Kind {
+	case kindMovingPlatform:
+		x, y, width, height := object.GetBounds()
+		tileSize := float64(g.currentLevel.TileSize)
+		if width <= 0 {
+			width = 3 * tileSize
+		}
+		if height <= 0 {
+			height = tileSize
+		}
+
+		modeName := object.StringProperty("mode", entities.PlatformPingPong.String())
+		mode, exists := entities.LookupPlatformMode(modeName)
+		if !exists {
+			return fmt.Errorf("platform %q has unknown mode %q", object.Key(), modeName)
+		}
+		points, err := object.PointsProperty("path")
+		if err != nil {
+			return err
+		}
+		path := make([]entities.Waypoint, len(points))
+		for i, point := range points {
+			path[i] = entities.Waypoint{X: point.X, Y: point.Y}
+		}
+
+		platform := entities.NewPlatform(x, y, width, height, mode, path...)
+		if platform.Speed, err = object.FloatProperty("speed", platform.Speed); err != nil {
+			return err
+		}
+		if platform.OneWay, err = object.BoolProperty("one_way", platform.OneWay); err != nil {
+			return err
+		}
+		if name, exists := object.Property("material"); exists {
+			if platform.Material, exists = level.Materials[name]; !exists {
+				return fmt.Errorf("platform %q has unknown material %q", object.Key(), name)
+			}
+		}
+		platform.SetLevel(g.currentLevel)
+		g.solids.Add(&platform.Solid)
+		g.world.Spawn(platform)
+		return nil
+	}
+	return fmt.Errorf("platform %q has unknown kind %q", object.Key(), object.Kind)
+}
+
+// spawnHazard spawns a trap
+func spawnHazard(g *RoboGame, object *level.Object) error {
+	switch object.Kind {
+	case kindDebris:
+		x, y, width, height := object.GetBounds()
+		if width <= 0 {
+			width = entities.DebrisSize
+		}
+		if height <= 0 {
+			height = entities.DebrisSize
+		}
+
+		debris := entities.NewDebris(x, y, width, height)
+		for _, setting := range []struct {
+			key   string
+			value *float64
+		}{
+			{"trigger_range", &debris.TriggerRange},
+			{"warn_time", &debris.WarnTime},
+			{"rest_time", &debris.RestTime},
+		} {
+			var err error
+			if *setting.value, err = object.FloatProperty(setting.key, *setting.value); err != nil {
+				return err
+			}
+		}
+		debris.SetLevel(g.currentLevel)
+		g.world.Spawn(debris)
+		return nil
+	}
+	return fmt.Errorf("hazard %q has unknown kind %q", object.Key(), object.Kind)
+}
+
+// spawnCrumblingBlocks spawns a block for every crumbling tile in the level. The block
+// decides when its tile collapses and returns; the level's tiles are changed here.
+func (g *RoboGame) spawnCrumblingBlocks() {
+	lvl := g.currentLevel
+	tileSize := float64(lvl.TileSize)
+	for y, row := range lvl.Tiles {
+		for x, tile := range row {
+			props := tile.Properties()
+			if !props.Crumbles() {
+				continue
+			}
+
+			block := entities.NewCrumblingBlock(float64(x)*tileSize, float64(y)*tileSize, tileSize, tileSize,
+				props.CrumbleDelay, props.RespawnDelay, props.Colour)
+			block.Sprite = props.Sprite
+			block.OnCollapse = func(*entities.CrumblingBlock) {
+				lvl.ReplaceTile(x, y, level.TileEmpty)
+			}
+			block.OnRestore = func(*entities.CrumblingBlock) {
+				lvl.RestoreTile(x, y)
+			}
+			g.world.Spawn(block)
+		}
+	}
+}
+
+// spawnSwitch spawns a switch that sends a signal named after the object. Pressure plates
+// placed as points lie along the bottom of their tile.
+func spawnSwitch(g *RoboGame, object *level.Object) error {
+	x, y, width, height := g.objectArea(object)
+	switch object.Kind {
+	case kindPressurePlate:
+		if object.Height <= 0 {
+			y, height = y+height-entities.PlateHeight, entities.PlateHeight
+		}
+		g.world.Spawn(entities.NewPressurePlate(g.signals, object.Key(), x, y, width, height))
+		return nil
+	case kindLever:
+		lever := entities.NewLever(g.signals, object.Key(), x, y, width, height)
+		on, err := object.BoolProperty("on", lever.IsOn())
+		if err != nil {
+			return err
+		}
+		lever.SetOn(on)
+		g.world.Spawn(lever)
+		return nil
+	}
+	return fmt.Errorf("switch %q has unknown kind %q", object.Key(), object.Kind)
+}
+
+// spawnLogic spawns a logic gate that reads the signals named by its "inputs" property and
+// sends one named after the object
+func spawnLogic(g *RoboGame, object *level.Object) error {
+	kind, exists := entities.LookupLogicKind(object.Kind)
+	if !exists {
+		return fmt.Errorf("logic %q has unknown kind %q", object.Key(), object.Kind)
+	}
+	inputs, err := signalInputs(g.currentLevel, object)
+	if err != nil {
+		return err
+	}
+
+	gate := entities.NewLogicGate(g.signals, object.Key(), kind, object.X, object.Y, inputs...)
+	if gate.Invert, err = object.BoolProperty("invert", gate.Invert); err != nil {
+		return err
+	}
+	if gate.Duration, err = object.FloatProperty("duration", gate.Duration); err != nil {
+		return err
+	}
+	if gate.Duration < 0 {
+		return fmt.Errorf("logic %q has negative duration %v", object.Key(), gate.Duration)
+	}
+	g.world.Spawn(gate)
+	return nil
+}
+
+// spawnDoor spawns a door that opens the tiles it covers while the signals named by its
+// "inputs" property say so, and puts them back as it closes
+func spawnDoor(g *RoboGame, object *level.Object) error {
+	if object.Kind != kindDoor && object.Kind != kindBarrier {
+		return fmt.Errorf("door %q has unknown kind %q", object.Key(), object.Kind)
+	}
+	inputs, err := signalInputs(g.currentLevel, object)
+	if err != nil {
+		return err
+	}
+
+	lvl := g.currentLevel
+	x, y, width, height := g.objectArea(object)
+	tiles := coveredTiles(lvl, x, y, width, height)
+	if len(tiles) == 0 {
+		return fmt.Errorf("door %q covers no tiles", object.Key())
+	}
+
+	door := entities.NewDoor(g.signals, x, y, width, height, inputs...)
+	door.Barrier = object.Kind == kindBarrier
+	door.Colour = tiles[0].Properties().Colour
+	if door.Invert, err = object.BoolProperty("invert", door.Invert); err != nil {
+		return err
+	}
+	door.OnOpen = func(*entities.Door) {
+		for _, tile := range tiles {
+			lvl.ReplaceTile(tile.X, tile.Y, level.TileEmpty)
+		}
+	}
+	door.OnClose = func(*entities.Door) {
+		for _, tile := range tiles {
+			lvl.RestoreTile(tile.X, tile.Y)
+		}
+	}
+	g.world.Spawn(door)
+	return nil
+}
+
+// signalInputs returns the signals an object's "inputs" property names. Each must be the
+// name of a switch or logic gate in the level.
+func signalInputs(lvl *level.Level, object *level.Object) ([]string, error) {
+	inputs := object.NamesProperty("inputs")
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%s %q has no inputs", object.Type, object.Key())
+	}
+	for _, name := range inputs {
+		source, exists := lvl.FindObject(name)
+		if !exists || (source.Type != level.ObjectSwitch && source.Type != level.ObjectLogic) {
+			return nil, fmt.Errorf("%s %q is wired to %q, which is not a switch or logic", object.Type, object.Key(), name)
+		}
+	}
+	return inputs, nil
+}
+
+// coveredTiles returns the tiles that aren't empty within an area of a level
+func coveredTiles(lvl *level.Level, x, y, width, height float64) []*level.Tile {
+	tileSize := float64(lvl.TileSize)
+	var tiles []*level.Tile
+	for tileY := int(math.Floor(y / tileSize)); float64(tileY)*tileSize < y+height; tileY++ {
+		for tileX := int(math.Floor(x / tileSize)); float64(tileX)*tileSize < x+width; tileX++ {
+			if tile := lvl.GetTile(tileX, tileY); tile != nil && tile.Type != level.TileEmpty {
+				tiles = append(tiles, tile)
+			}
+		}
+	}
+	return tiles
+}
+
+// countHearts returns how many energy hearts are placed in a level
+func countHearts(lvl *level.Level) int {
+	count := 0
+	for _, object := range lvl.ObjectsOfType(level.ObjectCollectible) {
+		if object.Kind == kindHeart {
+			count++
+		}
+	}
+	return count
+}
+
+// spawnTrigger spawns an area that other objects can find by name
+func spawnTrigger(g *RoboGame, object *level.Object) error {
+	g.spawnObjectArea(object, entities.TagTrigger)
+	return nil
+}
+
+// spawnExit spawns an area that completes the level when the player reaches it
+func spawnExit(g *RoboGame, object *level.Object) error {
+	area := g.spawnObjectArea(object, entities.TagExit)
+	area.OnEnter = func(area *entities.Area, other entities.Entity) {
+		if other == g.player {
+			log.Printf("Level %q complete", g.currentLevel.Name)
+		}
+	}
+	return nil
+}
+
+// spawnObjectArea spawns an area covering a level object
+func (g *RoboGame) spawnObjectArea(object *level.Object, tag string) *entities.Area {
+	x, y, width, height := g.objectArea(object)
+	area := entities.NewArea(object.Name, x, y, width, height, tag)
+	g.world.Spawn(area)
+	return area
+}
+
+// objectArea returns the area an object covers. Objects placed as points cover one tile.
+func (g *RoboGame) objectArea(object *level.Object) (x, y, width, height float64) {
+	x, y, width, height = object.GetBounds()
+	tileSize := float64(g.currentLevel.TileSize)
+	if width <= 0 {
+		width = tileSize
+	}
+	if height <= 0 {
+		height = tileSize
+	}
+	return x, y, width, height
+}
@@ -0,0 +1,897 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"ebiten-platformer/engine"
+	"ebiten-platformer/entities"
+	"ebiten-platformer/level"
+)
+
+// newLevelTestGame creates a game ready to load levels, without loading assets
+func newLevelTestGame() *RoboGame {
+	game := NewRoboGame()
+	game.playerImage = entities.CreateTestSpriteSheet()
+	return game
+}
+
+func TestLoadLevel_SpawnsObjects(t *testing.T) {
+	game := newLevelTestGame()
+	testLevel := level.CreateTestLevel()
+	testLevel.AddObject(level.Object{Name: "secret", Type: level.ObjectTrigger, X: 300, Y: 300, Width: 64, Height: 64})
+	testLevel.AddObject(level.Object{Type: level.ObjectEnemy, Kind: "drone", X: 200, Y: 400})
+
+	if err := game.loadLevel(testLevel); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+
+	start, _ := testLevel.PlayerStart()
+	if x, y := game.player.GetPosition(); x != start.X || y != start.Y {
+		t.Errorf("Expected the player at the level's start (%v, %v), got (%v, %v)", start.X, start.Y, x, y)
+	}
+	if game.world.FirstWithTag(entities.TagPlayer) != game.player {
+		t.Error("Expected the player to be in the world")
+	}
+
+	tests := []struct {
+		tag    string
+		name   string
+		width  float64
+		height float64
+	}{
+		{entities.TagCheckpoint, "middle", 32, 32},
+		{entities.TagExit, "exit", 32, 64},
+		{entities.TagTrigger, "secret", 64, 64},
+	}
+	for _, tt := range tests {
+		areas := game.world.WithTag(tt.tag, nil)
+		if len(areas) != 1 {
+			t.Errorf("Expected one %s area, got %d", tt.tag, len(areas))
+			continue
+		}
+		area := areas[0].(*entities.Area)
+		if area.Name != tt.name || area.Width != tt.width || area.Height != tt.height {
+			t.Errorf("Expected %s area %q of %vx%v, got %q of %vx%v", tt.tag, tt.name, tt.width, tt.height, area.Name, area.Width, area.Height)
+		}
+	}
+
+	// The added drone joins the test level's own two
+	if drones := game.world.WithTag(entities.TagDrone, nil); len(drones) != 3 {
+		t.Errorf("Expected three drones, got %d", len(drones))
+	}
+	if game.world.Len() != 12 {
+		t.Errorf("Expected the player, three areas, three drones, two platforms, two crumbling blocks and debris, got %d entities", game.world.Len())
+	}
+}
+
+func TestLoadLevel_PointAreasCoverATile(t *testing.T) {
+	game := newLevelTestGame()
+	simpleLevel := level.CreateSimpleLevel()
+	simpleLevel.AddObject(level.Object{Name: "flag", Type: level.ObjectCheckpoint, X: 400, Y: 416})
+
+	if err := game.loadLevel(simpleLevel); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	area := game.world.FirstWithTag(entities.TagCheckpoint).(*entities.Area)
+	if area.Width != 32 || area.Height != 32 {
+		t.Errorf("Expected a point checkpoint to cover one tile, got %vx%v", area.Width, area.Height)
+	}
+}
+
+func TestLoadLevel_CheckpointReached(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	if game.lastCheckpoint != nil {
+		t.Fatalf("Expected no checkpoint before reaching one, got %q", game.lastCheckpoint.Name)
+	}
+
+	// Put the player on the middle platform, inside the checkpoint
+	game.player.SetPosition(544, 352)
+	game.world.Update(1.0 / 60.0)
+	if game.lastCheckpoint == nil || game.lastCheckpoint.Name != "middle" {
+		t.Errorf("Expected the middle checkpoint to be reached, got %v", game.lastCheckpoint)
+	}
+}
+
+func TestLoadLevel_NeedsPlayerStart(t *testing.T) {
+	game := newLevelTestGame()
+	err := game.loadLevel(level.NewLevel(10, 10, 32, "Empty"))
+	if err == nil || !strings.Contains(err.Error(), "no player start") {
+		t.Errorf("Expected a missing player start error, got %v", err)
+	}
+}
+
+func TestLoadLevelFile_CustomTileType(t *testing.T) {
+	const data = `{
+		"name": "Custom tiles",
+		"tile_types": [{"name": "test_file_crate", "solid": true, "colour": "#aa7733"}],
+		"legend": {"#": "solid", "c": "test_file_crate"},
+		"tiles": [
+			"#......#",
+			"#......#",
+			"#......#",
+			"#......#",
+			"#cccccc#"
+		],
+		"objects": [{"type": "player_start", "x": 96, "y": 64}]
+	}`
+	path := filepath.Join(t.TempDir(), "custom.json")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lvl, err := loadLevelFile(path, nil)
+	if err != nil {
+		t.Fatalf("loadLevelFile failed: %v", err)
+	}
+	game := newLevelTestGame()
+	if err := game.loadLevel(lvl); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+
+	// The player lands on the custom tiles
+	for range 60 {
+		game.world.Update(1.0 / 60.0)
+	}
+	_, y, _, height := game.player.GetBounds()
+	if !game.player.OnGround || math.Abs(y+height-128) > level.GroundTolerance {
+		t.Errorf("Expected the player to stand on the custom tiles at Y=128, got bottom %v on ground=%v", y+height, game.player.OnGround)
+	}
+
+	// And they are drawn
+	crate, _ := level.LookupTileType("test_file_crate")
+	renderer := lvl.Renderer()
+	if index, exists := renderer.TypeIndices[crate]; !exists || renderer.Tileset().Tile(index) == nil {
+		t.Error("Expected the renderer to have an image for the custom tile type")
+	}
+	lvl.DrawWithCamera(ebiten.NewImage(256, 160), 0, 0)
+	if renderer.Stats().TilesDrawn == 0 {
+		t.Error("Expected the level's tiles to be drawn")
+	}
+}
+
+func TestHearts_CollectSurvivesRespawn(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateSimpleLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	if game.levelProgress.HeartsTotal != 3 || len(game.world.WithTag(entities.TagHeart, nil)) != 3 {
+		t.Fatalf("Expected three hearts, total %d", game.levelProgress.HeartsTotal)
+	}
+
+	// Stand the player on the left heart
+	heartObject, _ := game.currentLevel.FindObject("heart-left")
+	game.player.SetPosition(heartObject.X-8, 416)
+	game.world.Update(1.0 / 60.0)
+	if !game.levelProgress.HasHeart("heart-left") || game.levelProgress.HeartsCollectedCount() != 1 {
+		t.Fatalf("Expected the left heart to be collected, got %v", game.levelProgress.HeartsCollected)
+	}
+
+	// Falling out of the level respawns the player without bringing the heart back
+	game.player.SetPosition(100, 1000)
+	if !game.hasFallenOut() {
+		t.Fatal("Expected the player to have fallen out of the level")
+	}
+	if err := game.respawn(); err != nil {
+		t.Fatalf("respawn failed: %v", err)
+	}
+	if hearts := game.world.WithTag(entities.TagHeart, nil); len(hearts) != 2 {
+		t.Errorf("Expected two hearts after respawning, got %d", len(hearts))
+	}
+	if game.levelProgress.HeartsCollectedCount() != 1 || game.levelProgress.HeartsTotal != 3 {
+		t.Errorf("Expected 1/3 hearts after respawning, got %d/%d",
+			game.levelProgress.HeartsCollectedCount(), game.levelProgress.HeartsTotal)
+	}
+	if game.hasFallenOut() {
+		t.Error("Expected the player back in the level")
+	}
+
+	// Loading the level again picks the saved progress back up
+	if err := game.loadLevel(level.CreateSimpleLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	if hearts := game.world.WithTag(entities.TagHeart, nil); len(hearts) != 2 {
+		t.Errorf("Expected the collected heart to stay collected, got %d hearts", len(hearts))
+	}
+}
+
+func TestHearts_SavedWhenCollected(t *testing.T) {
+	game := newLevelTestGame()
+	savePath := filepath.Join(t.TempDir(), "save.json")
+	game.GetSaveManager().SetPath(savePath)
+	if err := game.loadLevel(level.CreateSimpleLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+
+	heart := game.world.FirstWithTag(entities.TagHeart).(*entities.Heart)
+	game.player.SetPosition(heart.X, heart.Y)
+	game.world.Update(1.0 / 60.0)
+
+	saves := engine.NewSaveManager(savePath)
+	if err := saves.Load(); err != nil {
+		t.Fatalf("Failed to load the save: %v", err)
+	}
+	progress := saves.GetLevelProgress(game.currentLevel.Name)
+	if !progress.HasHeart(heart.Key) || progress.HeartsTotal != 3 {
+		t.Errorf("Expected the collected heart in the save file, got %+v", progress)
+	}
+}
+
+func TestRespawn_AtCheckpoint(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	checkpoint, _ := game.currentLevel.FindObject("middle")
+	game.lastCheckpoint = checkpoint
+
+	if err := game.respawn(); err != nil {
+		t.Fatalf("respawn failed: %v", err)
+	}
+	x, y, width, height := game.player.GetBounds()
+	if x+width/2 != checkpoint.X+checkpoint.Width/2 || y+height != checkpoint.Y+checkpoint.Height {
+		t.Errorf("Expected the player standing in the checkpoint, got (%v, %v)", x, y)
+	}
+	if game.lastCheckpoint != checkpoint {
+		t.Error("Expected respawning to keep the checkpoint")
+	}
+}
+
+func TestCollectible_UnknownKind(t *testing.T) {
+	game := newLevelTestGame()
+	simpleLevel := level.CreateSimpleLevel()
+	simpleLevel.AddObject(level.Object{Name: "mystery", Type: level.ObjectCollectible, Kind: "coin"})
+
+	err := game.loadLevel(simpleLevel)
+	if err == nil || !strings.Contains(err.Error(), `unknown kind "coin"`) {
+		t.Errorf("Expected an unknown kind error, got %v", err)
+	}
+}
+
+func TestCats_StayOnTheirPlatforms(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateSimpleLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	cats := game.world.WithTag(entities.TagCat, nil)
+	if len(cats) != 2 {
+		t.Fatalf("Expected two cats, got %d", len(cats))
+	}
+
+	// The platform cat wanders the platform from x=256 to 416 without falling off
+	cat, _ := game.world.FirstWithTag(entities.TagCat).(*entities.Cat)
+	if cat.Key != "cat-platform" || cat.IsHappy() {
+		t.Fatalf("Expected the sad platform cat first, got %q happy=%v", cat.Key, cat.IsHappy())
+	}
+	for i := 0; i < 30*60; i++ {
+		game.world.Update(1.0 / 60.0)
+		if x, y, width, height := cat.GetBounds(); x < 256-1 || x+width > 416+1 || y+height != 320 {
+			t.Fatalf("Expected the cat to stay on the platform, got (%v, %v) after %d frames", x, y, i)
+		}
+	}
+}
+
+func TestCats_HelpWithHeart(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateSimpleLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	findCat := func(key string) *entities.Cat {
+		for _, e := range game.world.WithTag(entities.TagCat, nil) {
+			if cat := e.(*entities.Cat); cat.Key == key {
+				return cat
+			}
+		}
+		t.Fatalf("Expected cat %q in the world", key)
+		return nil
+	}
+	cat := findCat("cat-ground")
+
+	// Walk up to the cat with no hearts: it stays sad
+	game.player.SetPosition(cat.X-40, 416)
+	game.world.Update(1.0 / 60.0)
+	if game.player.Interact() || cat.IsHappy() {
+		t.Fatal("Expected a cat to need a heart")
+	}
+
+	// Collect the right heart, then give it to the cat
+	heart, _ := game.currentLevel.FindObject("heart-right")
+	game.player.SetPosition(heart.X, 416)
+	game.world.Update(1.0 / 60.0)
+	game.player.SetPosition(cat.X-40, 416)
+	game.world.Update(1.0 / 60.0)
+	if !game.player.Interact() || !cat.IsHappy() {
+		t.Fatal("Expected the cat to cheer up with a heart")
+	}
+	progress := game.levelProgress
+	if !progress.HasHelpedCat("cat-ground") || progress.Score != catScore || progress.HeartsAvailable() != 0 {
+		t.Errorf("Expected the help to be scored and spend the heart, got %+v", progress)
+	}
+
+	// The heart is spent, so the platform cat has to wait
+	other := findCat("cat-platform")
+	game.player.SetPosition(other.X+other.Width+8, other.Y+other.Height-32)
+	game.world.Update(1.0 / 60.0)
+	if game.player.Interact() || other.IsHappy() {
+		t.Error("Expected a spent heart not to help a second cat")
+	}
+
+	// Helped cats stay happy after respawning
+	if err := game.respawn(); err != nil {
+		t.Fatalf("respawn failed: %v", err)
+	}
+	if !findCat("cat-ground").IsHappy() || findCat("cat-platform").IsHappy() {
+		t.Error("Expected only the helped cat to respawn happy")
+	}
+	if progress.Score != catScore {
+		t.Errorf("Expected respawning to keep the score, got %d", progress.Score)
+	}
+}
+
+func TestNPC_Properties(t *testing.T) {
+	tests := []struct {
+		name      string
+		object    level.Object
+		wantErr   string
+		wantSpeed float64
+	}{
+		{"defaults", level.Object{Name: "tom", Type: level.ObjectNPC, Kind: "cat", X: 600, Y: 428}, "", entities.DefaultCatWalkSpeed},
+		{"walk speed", level.Object{Name: "tom", Type: level.ObjectNPC, Kind: "cat", X: 600, Y: 428,
+			Properties: map[string]string{"walk_speed": "35"}}, "", 35},
+		{"bad score", level.Object{Name: "tom", Type: level.ObjectNPC, Kind: "cat", X: 600, Y: 428,
+			Properties: map[string]string{"score": "lots"}}, `property "score"`, 0},
+		{"unknown kind", level.Object{Name: "rex", Type: level.ObjectNPC, Kind: "dog"}, `unknown kind "dog"`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newLevelTestGame()
+			simpleLevel := level.CreateSimpleLevel()
+			simpleLevel.AddObject(tt.object)
+
+			err := game.loadLevel(simpleLevel)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadLevel failed: %v", err)
+			}
+			cats := game.world.WithTag(entities.TagCat, nil)
+			if cat := cats[len(cats)-1].(*entities.Cat); cat.Key != "tom" || cat.WalkSpeed != tt.wantSpeed {
+				t.Errorf("Expected tom walking at %v, got %q at %v", tt.wantSpeed, cat.Key, cat.WalkSpeed)
+			}
+		})
+	}
+}
+
+func TestDrones_PatrolFromLevelData(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	drones := game.world.WithTag(entities.TagDrone, nil)
+	if len(drones) != 2 {
+		t.Fatalf("Expected two drones in the test level, got %d", len(drones))
+	}
+
+	// The middle drone flies from one edge of the middle platform (x=480 to 672) to the other
+	middle := drones[0].(*entities.Drone)
+	want := []entities.Waypoint{{X: 480, Y: 320}, {X: 672 - entities.DroneWidth, Y: 320}}
+	if !slices.Equal(middle.Waypoints, want) {
+		t.Errorf("Expected an edge-to-edge patrol %v, got %v", want, middle.Waypoints)
+	}
+
+	// The exit drone follows its waypoints
+	exit := drones[1].(*entities.Drone)
+	if len(exit.Waypoints) != 4 || exit.Waypoints[2] != (entities.Waypoint{X: 900, Y: 140}) {
+		t.Errorf("Expected the exit drone's four waypoints, got %v", exit.Waypoints)
+	}
+}
+
+func TestDrones_SightAndDamage(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	drone := game.world.FirstWithTag(entities.TagDrone).(*entities.Drone)
+	drone.SightRange = 300
+
+	// Standing on the ground under the middle platform, the player is hidden from the drone
+	game.player.SetPosition(576, 544)
+	for i := 0; i < 30; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	if drone.CanSee(game.player) || drone.GetState() != entities.DronePatrol {
+		t.Fatalf("Expected the platform to hide the player, drone is %v", drone.GetState())
+	}
+
+	// On the platform, the drone sees the player, chases and hits them
+	game.player.SetPosition(560, 352)
+	damaged := false
+	for i := 0; i < 5*60 && !damaged; i++ {
+		game.world.Update(1.0 / 60.0)
+		damaged = game.player.IsDamaged
+	}
+	if !damaged {
+		t.Fatalf("Expected the drone to catch the player, drone is %v at (%v, %v)", drone.GetState(), drone.X, drone.Y)
+	}
+}
+
+func TestEnemy_Properties(t *testing.T) {
+	tests := []struct {
+		name    string
+		object  level.Object
+		wantErr string
+	}{
+		{"bad waypoints", level.Object{Name: "d", Type: level.ObjectEnemy, Kind: "drone",
+			Properties: map[string]string{"waypoints": "10,20 30"}}, `property "waypoints"`},
+		{"bad speed", level.Object{Name: "d", Type: level.ObjectEnemy, Kind: "drone",
+			Properties: map[string]string{"chase_speed": "quick"}}, `property "chase_speed"`},
+		{"unknown kind", level.Object{Name: "bot", Type: level.ObjectEnemy, Kind: "walker"}, `unknown kind "walker"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newLevelTestGame()
+			testLevel := level.CreateTestLevel()
+			testLevel.AddObject(tt.object)
+
+			err := game.loadLevel(testLevel)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// Drones over nothing hover where they are placed
+	game := newLevelTestGame()
+	testLevel := level.CreateTestLevel()
+	testLevel.AddObject(level.Object{Name: "hover", Type: level.ObjectEnemy, Kind: "drone", X: 100, Y: 0,
+		Properties: map[string]string{"patrol_speed": "20", "sight_range": "64"}})
+	testLevel.SetTile(3, 18, level.TileEmpty)
+	testLevel.SetTile(3, 19, level.TileEmpty)
+	if err := game.loadLevel(testLevel); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	drones := game.world.WithTag(entities.TagDrone, nil)
+	hover := drones[len(drones)-1].(*entities.Drone)
+	if len(hover.Waypoints) != 0 || hover.PatrolSpeed != 20 || hover.SightRange != 64 {
+		t.Errorf("Expected a hovering drone with its properties, got %v at %v px/s seeing %v px",
+			hover.Waypoints, hover.PatrolSpeed, hover.SightRange)
+	}
+}
+
+func TestPlatforms_FromLevelData(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	platforms := game.world.WithTag(entities.TagPlatform, nil)
+	if len(platforms) != 2 {
+		t.Fatalf("Expected two platforms in the test level, got %d", len(platforms))
+	}
+
+	// The lift runs between the ground and the high platform
+	lift := platforms[0].(*entities.Platform)
+	if x, y, width, height := lift.GetBounds(); x != 736 || y != 560 || width != 64 || height != 16 {
+		t.Errorf("Expected the lift at (736, 560) 64x16, got (%v, %v) %vx%v", x, y, width, height)
+	}
+	want := []entities.Waypoint{{X: 736, Y: 560}, {X: 736, Y: 256}}
+	if !slices.Equal(lift.Path, want) || lift.Mode != entities.PlatformPingPong || lift.Speed != 80 || lift.OneWay {
+		t.Errorf("Expected a solid ping-pong lift at 80 px/s along %v, got %v %v at %v px/s one-way=%v",
+			want, lift.Mode, lift.Path, lift.Speed, lift.OneWay)
+	}
+
+	// The ferry waits for the player and can be jumped up through
+	ferry := platforms[1].(*entities.Platform)
+	if ferry.Mode != entities.PlatformTriggered || !ferry.OneWay || len(ferry.Path) != 3 || ferry.IsMoving() {
+		t.Errorf("Expected a waiting one-way ferry with three points, got %v one-way=%v along %v", ferry.Mode, ferry.OneWay, ferry.Path)
+	}
+
+	// The player collides with platforms
+	game.player.SetPosition(752, 400)
+	for i := 0; i < 60; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	if _, y, _, height := game.player.GetBounds(); math.Abs(y+height-lift.Y) > 1e-6 || !game.player.IsOnGround() {
+		t.Errorf("Expected the player to land on the lift at Y=%v, feet at %v", lift.Y, y+height)
+	}
+}
+
+func TestPlatforms_RideAtHighSpeed(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+
+	// Drones would knock the player off
+	for _, drone := range game.world.WithTag(entities.TagDrone, nil) {
+		game.world.Despawn(drone)
+	}
+	lift := game.world.FirstWithTag(entities.TagPlatform).(*entities.Platform)
+	lift.Speed = 900
+	game.player.SetPosition(752, 528)
+
+	// Several trips between the ground and the high platform, past the level's tiles
+	for i := 0; i < 3*60; i++ {
+		game.world.Update(1.0 / 60.0)
+		x, y, _, height := game.player.GetBounds()
+		if game.player.IsDead || math.Abs(x-752) > 1e-6 || math.Abs(y+height-lift.Y) > 1e-6 {
+			t.Fatalf("Expected the player to ride the lift at X=752, got (%v, %v) with the lift at Y=%v after %d frames, dead=%v",
+				x, y, lift.Y, i, game.player.IsDead)
+		}
+	}
+}
+
+func TestPlatforms_CrushRespawns(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	game.GetStateManager().SetState(engine.StatePlaying)
+
+	// Raised above the player, the lift comes down onto them on its way back to its start
+	lift := game.world.FirstWithTag(entities.TagPlatform).(*entities.Platform)
+	lift.Y = 400
+	game.player.SetPosition(752, 544)
+	crushed := game.player
+	for i := 0; i < 3*60 && !crushed.IsDead; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	if !crushed.IsDead {
+		t.Fatalf("Expected the lift to crush the player, got the player at Y=%v and the lift at Y=%v", crushed.Y, lift.Y)
+	}
+
+	if err := game.Update(); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	start, _ := game.currentLevel.PlayerStart()
+	if game.player == crushed || game.player.IsDead || game.player.X != start.X {
+		t.Errorf("Expected a crushed player to respawn at the start, got X=%v dead=%v", game.player.X, game.player.IsDead)
+	}
+}
+
+func TestPlatform_Properties(t *testing.T) {
+	tests := []struct {
+		name    string
+		object  level.Object
+		wantErr string
+	}{
+		{"bad path", level.Object{Name: "p", Type: level.ObjectPlatform, Kind: "moving",
+			Properties: map[string]string{"path": "10,20 30"}}, `property "path"`},
+		{"bad speed", level.Object{Name: "p", Type: level.ObjectPlatform, Kind: "moving",
+			Properties: map[string]string{"speed": "fast"}}, `property "speed"`},
+		{"bad one-way", level.Object{Name: "p", Type: level.ObjectPlatform, Kind: "moving",
+			Properties: map[string]string{"one_way": "sometimes"}}, `property "one_way"`},
+		{"unknown mode", level.Object{Name: "p", Type: level.ObjectPlatform, Kind: "moving",
+			Properties: map[string]string{"mode": "teleport"}}, `unknown mode "teleport"`},
+		{"unknown material", level.Object{Name: "p", Type: level.ObjectPlatform, Kind: "moving",
+			Properties: map[string]string{"material": "lava"}}, `unknown material "lava"`},
+		{"unknown kind", level.Object{Name: "p", Type: level.ObjectPlatform, Kind: "crumbling"}, `unknown kind "crumbling"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newLevelTestGame()
+			testLevel := level.CreateSimpleLevel()
+			testLevel.AddObject(tt.object)
+
+			err := game.loadLevel(testLevel)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// Platforms placed as points are three tiles wide and one high, and may be icy
+	game := newLevelTestGame()
+	testLevel := level.CreateSimpleLevel()
+	testLevel.AddObject(level.Object{Name: "ice", Type: level.ObjectPlatform, Kind: "moving", X: 64, Y: 256,
+		Properties: map[string]string{"mode": "loop", "material": "ice", "path": "64,128 160,128"}})
+	if err := game.loadLevel(testLevel); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	platform := game.world.FirstWithTag(entities.TagPlatform).(*entities.Platform)
+	if platform.Width != 96 || platform.Height != 32 || platform.Mode != entities.PlatformLoop || platform.Material.Name != "ice" {
+		t.Errorf("Expected a 96x32 icy loop, got %vx%v %v on %q", platform.Width, platform.Height, platform.Mode, platform.Material.Name)
+	}
+}
+
+func TestCrumblingBlocks_CollapseAndRestoreTiles(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	lvl := game.currentLevel
+
+	blocks := game.world.WithTag(entities.TagCrumbling, nil)
+	if len(blocks) != 2 {
+		t.Fatalf("Expected a block for each of the test level's two crumbling tiles, got %d", len(blocks))
+	}
+	block := blocks[0].(*entities.CrumblingBlock)
+	if block.X != 13*32 || block.Y != 14*32 || block.Delay != level.DefaultCrumbleDelay || block.RespawnDelay != level.DefaultRespawnDelay {
+		t.Errorf("Expected a block on tile (13, 14) with the default delays, got %+v", block.Rect)
+	}
+
+	// Standing on the ledge collapses its tiles, and the player falls to the ground
+	game.player.SetPosition(13*32+16, 14*32-32)
+	game.player.OnGround = true
+	for i := 0; i < 60; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	for x := 13; x <= 14; x++ {
+		if tile := lvl.GetTile(x, 14); tile.Type != level.TileEmpty || !lvl.IsReplaced(x, 14) {
+			t.Errorf("Expected tile (%d, 14) to have collapsed, got %v", x, tile.Type)
+		}
+	}
+	for i := 0; i < 60; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	if _, y, _, height := game.player.GetBounds(); y+height != 18*32 {
+		t.Errorf("Expected the player to drop to the ground, feet at %v", y+height)
+	}
+
+	// They come back in time, and at once when the player respawns
+	for i := 0; i < int(level.DefaultRespawnDelay*60); i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	if lvl.GetTile(13, 14).Type != level.TileCrumbling || lvl.IsReplaced(13, 14) {
+		t.Errorf("Expected the ledge back after %vs, got %v", level.DefaultRespawnDelay, lvl.GetTile(13, 14).Type)
+	}
+
+	lvl.ReplaceTile(14, 14, level.TileEmpty)
+	if err := game.respawn(); err != nil {
+		t.Fatalf("respawn failed: %v", err)
+	}
+	if lvl.GetTile(14, 14).Type != level.TileCrumbling || len(game.world.WithTag(entities.TagCrumbling, nil)) != 2 {
+		t.Errorf("Expected respawning to restore the ledge, got %v", lvl.GetTile(14, 14).Type)
+	}
+}
+
+func TestDebris_FallsOnThePlayer(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	for _, drone := range game.world.WithTag(entities.TagDrone, nil) {
+		game.world.Despawn(drone)
+	}
+
+	debris := game.world.FirstWithTag(entities.TagDebris).(*entities.Debris)
+	if debris.Width != entities.DebrisSize || debris.Height != entities.DebrisSize {
+		t.Errorf("Expected debris placed as a point to be %vpx square, got %vx%v", entities.DebrisSize, debris.Width, debris.Height)
+	}
+
+	// Walking beneath it brings it down on the player, and it lands on the ground
+	game.player.SetPosition(debris.X-16, 18*32-32)
+	for i := 0; i < 2*60 && !game.player.IsDamaged; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	if !game.player.IsDamaged {
+		t.Fatalf("Expected the debris to hit the player, it is %v at Y=%v", debris.GetState(), debris.Y)
+	}
+	for i := 0; i < 60 && debris.GetState() == entities.DebrisFalling; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	if debris.GetState() != entities.DebrisLanded || debris.Y+debris.Height != 18*32 {
+		t.Errorf("Expected the debris to land on the ground, got %v with its bottom at %v", debris.GetState(), debris.Y+debris.Height)
+	}
+}
+
+func TestHazard_Properties(t *testing.T) {
+	tests := []struct {
+		name    string
+		object  level.Object
+		wantErr string
+	}{
+		{"bad trigger range", level.Object{Name: "h", Type: level.ObjectHazard, Kind: "debris",
+			Properties: map[string]string{"trigger_range": "far"}}, `property "trigger_range"`},
+		{"bad warn time", level.Object{Name: "h", Type: level.ObjectHazard, Kind: "debris",
+			Properties: map[string]string{"warn_time": "soon"}}, `property "warn_time"`},
+		{"unknown kind", level.Object{Name: "h", Type: level.ObjectHazard, Kind: "lava"}, `unknown kind "lava"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newLevelTestGame()
+			testLevel := level.CreateSimpleLevel()
+			testLevel.AddObject(tt.object)
+
+			err := game.loadLevel(testLevel)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	game := newLevelTestGame()
+	testLevel := level.CreateSimpleLevel()
+	testLevel.AddObject(level.Object{Name: "boulder", Type: level.ObjectHazard, Kind: "debris", X: 64, Y: 64, Width: 32, Height: 24,
+		Properties: map[string]string{"trigger_range": "64", "warn_time": "0", "rest_time": "0"}})
+	if err := game.loadLevel(testLevel); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	debris := game.world.FirstWithTag(entities.TagDebris).(*entities.Debris)
+	if debris.Width != 32 || debris.Height != 24 || debris.TriggerRange != 64 || debris.WarnTime != 0 || debris.RestTime != 0 {
+		t.Errorf("Expected 32x24 debris set off within 64px with no warning that never clears, got %+v", debris)
+	}
+}
+
+// walkInto walks the game's player right for up to frames frames, stopping early if they
+// are hurt, and returns whether they were
+func walkInto(game *RoboGame, frames int) bool {
+	for i := 0; i < frames && !game.player.IsDamaged; i++ {
+		game.player.MoveRight()
+		game.world.Update(1.0 / 60.0)
+	}
+	return game.player.IsDamaged
+}
+
+// doorTiles returns the types of the signal test level's door tiles in a column
+func doorTiles(lvl *level.Level, x int) []level.TileType {
+	var types []level.TileType
+	for y := 9; y <= 12; y++ {
+		types = append(types, lvl.GetTile(x, y).Type)
+	}
+	return types
+}
+
+func TestSignals_SolveThePuzzle(t *testing.T) {
+	game := newLevelTestGame()
+	if err := game.loadLevel(level.CreateSignalTestLevel()); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	lvl := game.currentLevel
+	closedDoor := []level.TileType{level.TileDoor, level.TileDoor, level.TileDoor, level.TileDoor}
+	openDoor := []level.TileType{level.TileEmpty, level.TileEmpty, level.TileEmpty, level.TileEmpty}
+	upBarrier := []level.TileType{level.TileBarrier, level.TileBarrier, level.TileBarrier, level.TileBarrier}
+
+	for tag, want := range map[string]int{entities.TagSwitch: 3, entities.TagLogic: 3, entities.TagDoor: 3} {
+		if got := len(game.world.WithTag(tag, nil)); got != want {
+			t.Errorf("Expected %d entities tagged %q, got %d", want, tag, got)
+		}
+	}
+	plate := game.world.WithTag(entities.TagSwitch, nil)[0].(*entities.PressurePlate)
+	if plate.Y != 416-entities.PlateHeight || plate.Width != 32 || plate.Height != entities.PlateHeight {
+		t.Errorf("Expected a plate placed as a point to lie along the bottom of its tile, got %+v", plate.Rect)
+	}
+
+	// Stepping on the plate opens the gate at once, and it shuts two seconds later
+	game.player.SetPosition(160, 384)
+	game.world.Update(1.0 / 60.0)
+	if got := doorTiles(lvl, 8); !slices.Equal(got, openDoor) {
+		t.Fatalf("Expected the plate to open the gate, got %v", got)
+	}
+	game.player.SetPosition(200, 384)
+	for range 2*60 + 2 {
+		game.world.Update(1.0 / 60.0)
+	}
+	if got := doorTiles(lvl, 8); !slices.Equal(got, closedDoor) {
+		t.Fatalf("Expected the gate shut once its timer ran out, got %v", got)
+	}
+
+	// Walking into the energy barrier hurts until the lever switches it off
+	game.player.SetPosition(16*32-40, 384)
+	if !walkInto(game, 60) {
+		t.Fatal("Expected touching the energy barrier to hurt")
+	}
+	for i := 0; i < 5*60 && game.player.IsDamaged; i++ {
+		game.world.Update(1.0 / 60.0)
+	}
+	lever := game.world.WithTag(entities.TagSwitch, nil)[1].(*entities.Lever)
+	game.player.SetPosition(lever.X+40, 384)
+	if !game.player.Interact() {
+		t.Fatal("Expected the player to pull the lever")
+	}
+	game.world.Update(1.0 / 60.0)
+	if got := doorTiles(lvl, 16); !slices.Equal(got, openDoor) {
+		t.Fatalf("Expected the lever to switch off the barrier, got %v", got)
+	}
+
+	// The vault needs the latch as well as the lever; the far plate flips the latch on,
+	// and it stays on after stepping off
+	if got := doorTiles(lvl, 24); !slices.Equal(got, closedDoor) {
+		t.Fatalf("Expected the vault locked with only the lever on, got %v", got)
+	}
+	game.player.SetPosition(608, 384)
+	game.world.Update(1.0 / 60.0)
+	game.player.SetPosition(680, 384)
+	for range 10 {
+		game.world.Update(1.0 / 60.0)
+	}
+	if got := doorTiles(lvl, 24); !slices.Equal(got, openDoor) {
+		t.Fatalf("Expected the latch and lever to unlock the vault, got %v", got)
+	}
+
+	// Respawning shuts everything and puts the lever back
+	if err := game.respawn(); err != nil {
+		t.Fatalf("respawn failed: %v", err)
+	}
+	game.world.Update(1.0 / 60.0)
+	for x, want := range map[int][]level.TileType{8: closedDoor, 16: upBarrier, 24: closedDoor} {
+		if got := doorTiles(lvl, x); !slices.Equal(got, want) {
+			t.Errorf("Expected respawning to restore column %d to %v, got %v", x, want, got)
+		}
+	}
+}
+
+func TestSignals_Wiring(t *testing.T) {
+	tests := []struct {
+		name    string
+		object  level.Object
+		wantErr string
+	}{
+		{"unknown switch kind", level.Object{Name: "s", Type: level.ObjectSwitch, Kind: "button"}, `switch "s" has unknown kind "button"`},
+		{"bad lever position", level.Object{Name: "s", Type: level.ObjectSwitch, Kind: "lever",
+			Properties: map[string]string{"on": "up"}}, `property "on"`},
+		{"unknown logic kind", level.Object{Name: "l", Type: level.ObjectLogic, Kind: "xor",
+			Properties: map[string]string{"inputs": "lever"}}, `logic "l" has unknown kind "xor"`},
+		{"no inputs", level.Object{Name: "l", Type: level.ObjectLogic, Kind: "or"}, `logic "l" has no inputs`},
+		{"unknown input", level.Object{Name: "l", Type: level.ObjectLogic, Kind: "or",
+			Properties: map[string]string{"inputs": "lever missing"}}, `wired to "missing"`},
+		{"input that isn't a signal", level.Object{Name: "l", Type: level.ObjectLogic, Kind: "or",
+			Properties: map[string]string{"inputs": "exit"}}, `wired to "exit"`},
+		{"negative duration", level.Object{Name: "l", Type: level.ObjectLogic, Kind: "timer",
+			Properties: map[string]string{"inputs": "lever", "duration": "-1"}}, "negative duration"},
+		{"unknown door kind", level.Object{Name: "d", Type: level.ObjectDoor, Kind: "portcullis",
+			Properties: map[string]string{"inputs": "lever"}}, `door "d" has unknown kind "portcullis"`},
+		{"door without tiles", level.Object{Name: "d", Type: level.ObjectDoor, Kind: "door", X: 64, Y: 320,
+			Properties: map[string]string{"inputs": "lever"}}, `door "d" covers no tiles`},
+		{"door without inputs", level.Object{Name: "d", Type: level.ObjectDoor, Kind: "door", X: 0, Y: 256}, `door "d" has no inputs`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newLevelTestGame()
+			testLevel := level.CreateSignalTestLevel()
+			testLevel.AddObject(tt.object)
+
+			err := game.loadLevel(testLevel)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// An inverted door wired to a lever that starts on: a trapdoor in the ceiling that
+	// stays shut until the lever is pulled off
+	game := newLevelTestGame()
+	testLevel := level.CreateSignalTestLevel()
+	testLevel.AddObject(level.Object{Name: "up", Type: level.ObjectSwitch, Kind: "lever", X: 64, Y: 384,
+		Properties: map[string]string{"on": "true"}})
+	testLevel.AddObject(level.Object{Name: "hatch", Type: level.ObjectDoor, Kind: "door", X: 64, Y: 256,
+		Properties: map[string]string{"inputs": "up", "invert": "true"}})
+	if err := game.loadLevel(testLevel); err != nil {
+		t.Fatalf("loadLevel failed: %v", err)
+	}
+	game.world.Update(1.0 / 60.0)
+	if testLevel.GetTile(2, 8).Type != level.TileSolid {
+		t.Fatal("Expected the hatch shut while its lever is on")
+	}
+	for _, e := range game.world.WithTag(entities.TagSwitch, nil) {
+		if lever, ok := e.(*entities.Lever); ok && lever.Signal == "up" {
+			lever.SetOn(false)
+		}
+	}
+	game.world.Update(1.0 / 60.0)
+	game.world.Update(1.0 / 60.0)
+	if testLevel.GetTile(2, 8).Type != level.TileEmpty {
+		t.Error("Expected the hatch open once its lever is off")
+	}
+}
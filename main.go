@@ -1,14 +1,17 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
+	"ebiten-platformer/collision"
 	"ebiten-platformer/engine"
 	"ebiten-platformer/entities"
 	"ebiten-platformer/level"
@@ -20,9 +23,13 @@ type RoboGame struct {
 	playerImage    *ebiten.Image
 	overlayImage   *ebiten.Image
 	player         *entities.Player
+	world          *entities.World
 	inputHandler   *entities.InputHandler
 	currentLevel   *level.Level
-	levelAdapter   *level.CollisionAdapter
+	solids         *collision.Solids     // The current level's tiles plus moving platforms, which the player collides with
+	signals        *entities.Signals     // The current level's switch, logic and door signals
+	lastCheckpoint *level.Object         // Checkpoint the player touched last in the current level, nil for none
+	levelProgress  *engine.LevelProgress // Saved progress in the current level
 	deltaTime      float64
 	lastUpdateTime float64
 }
@@ -150,20 +157,25 @@ func (g *RoboGame) LoadAssets() error {
 		// Create a test sprite sheet for development
 		playerImg = entities.CreateTestSpriteSheet()
 	}
-	g.playerImage = playerImg
 
-	// Create level
-	g.currentLevel = level.CreateSimpleLevel()
-	g.levelAdapter = level.NewCollisionAdapter(g.currentLevel)
+	// A sheet that doesn't match the spec would animate as garbage, so fall back instead
+	bounds := playerImg.Bounds()
+	if err := entities.ValidateSpriteSheetSize(bounds.Dx(), bounds.Dy(), entities.Robo9SpriteLayout()); err != nil {
+		log.Printf("player.png does not match the ROBO-9 sprite specification, using test sprite sheet: %v", err)
+		playerImg = entities.CreateTestSpriteSheet()
+	}
+	g.playerImage = playerImg
 
-	// Create player entity
-	g.player = entities.NewPlayer(100, 200, playerImg) // Start higher up
-	
-	// Connect player with level for collision detection
-	g.player.SetLevel(g.levelAdapter)
-	
-	// Create input handler
-	g.inputHandler = entities.NewInputHandler(g.player)
+	// Create level and everything placed in it
+	lvl := level.CreateSimpleLevel()
+	if *levelFlag != "" {
+		if lvl, err = loadLevelFile(*levelFlag, assetManager.LoadImage); err != nil {
+			return err
+		}
+	}
+	if err := g.loadLevel(lvl); err != nil {
+		return fmt.Errorf("failed to load level: %w", err)
+	}
 
 	// Set state to menu after assets are loaded
 	g.SetState(engine.StateMenu)
@@ -187,8 +199,13 @@ func (g *RoboGame) Update() error {
 		if g.inputHandler != nil {
 			g.inputHandler.Update()
 		}
-		if g.player != nil {
-			g.player.Update(g.deltaTime)
+		if g.world != nil {
+			g.world.Update(g.deltaTime)
+		}
+		if g.player != nil && (g.hasFallenOut() || g.player.IsDead) {
+			if err := g.respawn(); err != nil {
+				return err
+			}
 		}
 	}
 	
@@ -264,15 +281,17 @@ func (g *RoboGame) drawGameScreen(screen *ebiten.Image) {
 	// Clear screen with sky blue
 	screen.Fill(color.RGBA{135, 206, 235, 255})
 	
-	// Draw level first (background)
+	// Draw level layers behind the player first
 	if g.currentLevel != nil {
-		g.currentLevel.Draw(screen)
+		g.currentLevel.DrawBehind(screen, 0, 0)
+	}
+	
+	// Draw the player and other entities on top of the level
+	if g.world != nil {
+		g.world.Draw(screen)
 	}
 	
-	// Draw player on top of level
 	if g.player != nil {
-		g.player.Draw(screen)
-		
 		// Debug info
 		x, y := g.player.GetPosition()
 		vx, vy := g.player.GetVelocity()
@@ -283,6 +302,12 @@ func (g *RoboGame) drawGameScreen(screen *ebiten.Image) {
 		ebitenutil.DebugPrintAt(screen, debugInfo, 10, 90)
 	}
 	
+	// Foreground layers cover the player
+	if g.currentLevel != nil {
+		g.currentLevel.DrawFront(screen, 0, 0)
+	}
+	g.drawHUD(screen)
+	
 	// Game title and info
 	ebitenutil.DebugPrint(screen, "ROBO-9 Platformer - PLAYING (Tile-Based Collision)")
 	ebitenutil.DebugPrintAt(screen, "ESC: Pause | M: Menu | G: Game Over", 10, 20)
@@ -366,11 +391,39 @@ func (g *RoboGame) drawTransitionScreen(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Transitioning... %.1f%%", progress*100), 10, 340)
 }
 
+// Command-line flags for asset tooling
+var (
+	validateSpriteFlag = flag.String("validate-sprite", "", "validate a ROBO-9 sprite sheet PNG and exit")
+	contactSheetFlag   = flag.String("contact-sheet", "", "with -validate-sprite, write a labelled contact sheet PNG to this path")
+	levelFlag          = flag.String("level", "", "play a JSON level file instead of the built-in level")
+)
+
 func main() {
+	flag.Parse()
+
+	// Asset tooling mode: validate art without opening a window
+	if *validateSpriteFlag != "" {
+		if err := runSpriteCheck(*validateSpriteFlag, *contactSheetFlag, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ebiten.SetWindowSize(960, 720)
 	ebiten.SetWindowTitle("ROBO-9 Platformer")
 
 	game := NewRoboGame()
+
+	// Keep progress between sessions
+	if savePath, err := engine.DefaultSavePath("robo9-platformer"); err != nil {
+		log.Printf("Progress won't be saved: %v", err)
+	} else {
+		saves := game.GetSaveManager()
+		saves.SetPath(savePath)
+		if err := saves.Load(); err != nil {
+			log.Printf("Could not load saved progress, starting afresh: %v", err)
+		}
+	}
 	
 	// Load assets before starting the game
 	if err := game.LoadAssets(); err != nil {
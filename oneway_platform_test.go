@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"ebiten-platformer/entities"
+	"ebiten-platformer/level"
+)
+
+const (
+	oneWayUpperTop = 20 * 32 // Top of the upper one-way platform
+	oneWayLowerTop = 24 * 32 // Top of the lower one-way platform
+	oneWayFloorTop = 29 * 32 // Top of the solid floor
+)
+
+// newOneWayTestLevel creates a tall level with two stacked one-way platforms over a solid floor
+func newOneWayTestLevel() *level.Level {
+	testLevel := level.NewLevel(12, 30, 32, "One-Way Test")
+	for x := 0; x < testLevel.Width; x++ {
+		testLevel.SetTile(x, 29, level.TileSolid)
+	}
+	for x := 2; x <= 6; x++ {
+		testLevel.SetTile(x, 20, level.TileOneWay)
+		testLevel.SetTile(x, 24, level.TileOneWay)
+	}
+	return testLevel
+}
+
+// settleOnGround updates the player until it lands, returning the number of frames taken
+func settleOnGround(player *entities.Player, maxFrames int) int {
+	for i := 0; i < maxFrames; i++ {
+		player.Update(1.0 / 60.0)
+		if player.OnGround && player.VelocityY == 0 {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// TestOneWayFastUpward verifies jumping up through one-way platforms is never stopped from below
+func TestOneWayFastUpward(t *testing.T) {
+	testLevel := newOneWayTestLevel()
+
+	testCases := []struct {
+		name      string
+		velocityY float64
+	}{
+		{"Just clears both platforms", 600},
+		{"Fast jump", 900},
+		{"Very fast jump", 1200},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			player := entities.NewPlayer(3*32, oneWayFloorTop-32, entities.CreateTestSpriteSheet())
+			player.SetLevel(testLevel)
+			player.VelocityY = -tc.velocityY
+
+			// While rising, gravity must be the only thing slowing the player down
+			for i := 0; player.VelocityY < 0 && i < 600; i++ {
+				before := player.VelocityY
+				player.Update(1.0 / 60.0)
+				if player.VelocityY < 0 && math.Abs(player.VelocityY-(before+player.Gravity/60.0)) > 0.001 {
+					_, y := player.GetPosition()
+					t.Fatalf("%s: Rising velocity changed from %.1f to %.1f at Y=%.1f (frame %d)", tc.name, before, player.VelocityY, y, i)
+				}
+				if player.OnGround && player.VelocityY < 0 {
+					t.Fatalf("%s: Player should not be grounded while rising (frame %d)", tc.name, i)
+				}
+			}
+
+			// Falling back down lands on the upper platform's top
+			frames := settleOnGround(player, 600)
+			_, y := player.GetPosition()
+			fmt.Printf("%s: Landed with feet at %.2f after %d frames\n", tc.name, y+player.Height, frames)
+			if frames < 0 {
+				t.Fatalf("%s: Player never landed", tc.name)
+			}
+			if math.Abs(y+player.Height-oneWayUpperTop) > level.GroundTolerance {
+				t.Errorf("%s: Expected to land on the upper platform at %d, feet at %.2f", tc.name, oneWayUpperTop, y+player.Height)
+			}
+		})
+	}
+}
+
+// TestOneWayFastDownward verifies falling onto a one-way platform lands on it at any speed
+func TestOneWayFastDownward(t *testing.T) {
+	testLevel := newOneWayTestLevel()
+
+	testCases := []struct {
+		name      string
+		velocityY float64
+	}{
+		{"Slow fall", 50},
+		{"Fast fall", 800},
+		{"Faster than a tile per frame", 2500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			player := entities.NewPlayer(3*32, 100, entities.CreateTestSpriteSheet())
+			player.SetLevel(testLevel)
+			player.VelocityY = tc.velocityY
+
+			if settleOnGround(player, 600) < 0 {
+				t.Fatalf("%s: Player never landed", tc.name)
+			}
+
+			_, y := player.GetPosition()
+			if math.Abs(y+player.Height-oneWayUpperTop) > level.GroundTolerance {
+				t.Errorf("%s: Expected to land on the upper platform at %d, feet at %.2f", tc.name, oneWayUpperTop, y+player.Height)
+			}
+		})
+	}
+}
+
+// TestOneWayDropThrough verifies down+jump drops through one platform and lands on the next
+func TestOneWayDropThrough(t *testing.T) {
+	testLevel := newOneWayTestLevel()
+	player := entities.NewPlayer(3*32, oneWayUpperTop-32, entities.CreateTestSpriteSheet())
+	player.SetLevel(testLevel)
+	if settleOnGround(player, 60) < 0 {
+		t.Fatal("Player should start on the upper platform")
+	}
+
+	if !player.DropThrough() {
+		t.Fatal("Expected to drop through the one-way platform")
+	}
+	if !player.IsDroppingThrough() || player.OnGround {
+		t.Error("Expected the player to be airborne and dropping through")
+	}
+	if player.Jump(); player.VelocityY < 0 {
+		t.Error("Dropping through should not leave coyote time to jump back up")
+	}
+
+	// The grace window ends before the lower platform, which catches the player
+	if settleOnGround(player, 600) < 0 {
+		t.Fatal("Player never landed after dropping")
+	}
+	_, y := player.GetPosition()
+	fmt.Printf("Drop through: landed with feet at %.2f\n", y+player.Height)
+	if math.Abs(y+player.Height-oneWayLowerTop) > level.GroundTolerance {
+		t.Errorf("Expected to land on the lower platform at %d, feet at %.2f", oneWayLowerTop, y+player.Height)
+	}
+
+	// Dropping again reaches the solid floor, which can't be dropped through
+	if !player.DropThrough() {
+		t.Fatal("Expected to drop through the lower platform")
+	}
+	settleOnGround(player, 600)
+	_, y = player.GetPosition()
+	if math.Abs(y+player.Height-oneWayFloorTop) > level.GroundTolerance {
+		t.Errorf("Expected to land on the floor at %d, feet at %.2f", oneWayFloorTop, y+player.Height)
+	}
+	if player.DropThrough() {
+		t.Error("Solid ground should not allow dropping through")
+	}
+}
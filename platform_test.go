@@ -16,8 +16,6 @@ func TestPlayerFallsOffPlatform(t *testing.T) {
 		testLevel.SetTile(x, 5, level.TileSolid)
 	}
 	
-	// Create collision adapter
-	adapter := level.NewCollisionAdapter(testLevel)
 	
 	// Create player on the platform
 	spriteSheet := ebiten.NewImage(32, 32)
@@ -26,7 +24,7 @@ func TestPlayerFallsOffPlatform(t *testing.T) {
 	// Tile Y=5 means pixel Y = 5 * 32 = 160
 	// We want player to be on top of the platform, so Y = 160 - 32 = 128
 	player := entities.NewPlayer(128, 128, spriteSheet) // X=128 (tile 4), Y=128 (on platform)
-	player.SetLevel(adapter)
+	player.SetLevel(testLevel)
 	
 	deltaTime := 1.0 / 60.0
 	
@@ -13,11 +13,10 @@ func TestPositionRange(t *testing.T) {
 		testLevel.SetTile(x, 5, level.TileSolid)
 	}
 	
-	adapter := level.NewCollisionAdapter(testLevel)
 	
 	// Test positions from X=180 to X=210 in steps of 2
 	for x := 180.0; x <= 210.0; x += 2.0 {
-		result := adapter.CheckCollision(x, 128, 32, 32)
+		result := testLevel.CheckCollision(x, 128, 32, 32)
 		
 		// Calculate expected overlap
 		playerLeft := x
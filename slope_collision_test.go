@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"ebiten-platformer/entities"
+	"ebiten-platformer/level"
+)
+
+// newSlopeTestPlayer creates a player on the slope test level and lets it settle
+func newSlopeTestPlayer(x, y float64) *entities.Player {
+	testLevel := level.CreateSlopeTestLevel()
+	player := entities.NewPlayer(x, y, entities.CreateTestSpriteSheet())
+	player.SetLevel(testLevel)
+
+	for i := 0; i < 10; i++ {
+		player.Update(1.0 / 60.0)
+	}
+	return player
+}
+
+// expectedSurfaceY returns the surface under the player's centre on the slope test level
+func expectedSurfaceY(testLevel *level.Level, player *entities.Player) float64 {
+	x, _ := player.GetPosition()
+	centreX := x + player.Width/2
+	tileSize := float64(testLevel.TileSize)
+	tileX := int(math.Floor(centreX / tileSize))
+	localX := centreX - float64(tileX)*tileSize
+
+	// Highest surface in the column: row 9 if it has ground, otherwise the top of row 10
+	tile := testLevel.GetTile(tileX, 9)
+	if height := tile.SurfaceHeight(localX, tileSize); height > 0 {
+		return 10*tileSize - height
+	}
+	return 10 * tileSize
+}
+
+// TestSlopeWalkUphill verifies the player follows the ramp surface while walking up
+func TestSlopeWalkUphill(t *testing.T) {
+	testLevel := level.CreateSlopeTestLevel()
+
+	testCases := []struct {
+		name          string
+		startX        float64
+		targetX       float64 // Walk until the player's X passes this
+		left          bool
+		maxSlopeAngle float64
+	}{
+		{"22.5 degree ramp", 40, 5 * 32, false, entities.DefaultMaxSlopeAngle},
+		{"22.5 degree ramp walking left", 12 * 32, 8 * 32, true, entities.DefaultMaxSlopeAngle},
+		{"45 degree ramp when walkable", 11 * 32, 15 * 32, false, 50},
+		{"45 degree ramp walking left when walkable", 20 * 32, 17 * 32, true, 50},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			player := newSlopeTestPlayer(tc.startX, 288)
+			player.SetLevel(testLevel)
+			player.MaxSlopeAngle = tc.maxSlopeAngle
+
+			for i := 0; i < 300; i++ {
+				if tc.left {
+					player.MoveLeft()
+				} else {
+					player.MoveRight()
+				}
+				player.Update(1.0 / 60.0)
+
+				x, y := player.GetPosition()
+				if !player.OnGround {
+					t.Fatalf("%s: Player left the ground at frame %d, position (%.1f, %.1f)", tc.name, i, x, y)
+				}
+
+				// Feet stay on the surface under the player's centre
+				surface := expectedSurfaceY(testLevel, player)
+				if feet := y + player.Height; math.Abs(feet-surface) > level.GroundTolerance {
+					t.Fatalf("%s: Feet at %.2f but surface at %.2f (frame %d, x=%.1f)", tc.name, feet, surface, i, x)
+				}
+
+				if (!tc.left && x > tc.targetX) || (tc.left && x < tc.targetX) {
+					fmt.Printf("%s: Reached plateau at (%.1f, %.1f) after %d frames\n", tc.name, x, y, i+1)
+					if math.Abs(y-256) > level.GroundTolerance {
+						t.Errorf("%s: Expected to stand on the plateau at Y=256, got %.1f", tc.name, y)
+					}
+					return
+				}
+			}
+
+			x, y := player.GetPosition()
+			t.Errorf("%s: Player didn't reach the plateau, stopped at (%.1f, %.1f)", tc.name, x, y)
+		})
+	}
+}
+
+// TestSlopeWalkDownhill verifies the player stays grounded while walking down ramps
+func TestSlopeWalkDownhill(t *testing.T) {
+	testCases := []struct {
+		name    string
+		startX  float64
+		targetX float64
+		left    bool
+	}{
+		{"22.5 degree ramp", 7 * 32, 11 * 32, false},
+		{"22.5 degree ramp walking left", 6 * 32, 2 * 32, true},
+		{"45 degree ramp", 16 * 32, 19 * 32, false},
+		{"45 degree ramp walking left", 16 * 32, 13 * 32, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			player := newSlopeTestPlayer(tc.startX, 256)
+			if !player.OnGround {
+				t.Fatalf("%s: Player should start on the plateau", tc.name)
+			}
+
+			for i := 0; i < 300; i++ {
+				if tc.left {
+					player.MoveLeft()
+				} else {
+					player.MoveRight()
+				}
+				player.Update(1.0 / 60.0)
+
+				x, y := player.GetPosition()
+				if !player.OnGround {
+					t.Fatalf("%s: Player went airborne going downhill at frame %d, position (%.1f, %.1f)", tc.name, i, x, y)
+				}
+
+				if (!tc.left && x > tc.targetX) || (tc.left && x < tc.targetX) {
+					fmt.Printf("%s: Reached the bottom at (%.1f, %.1f) after %d frames\n", tc.name, x, y, i+1)
+					if math.Abs(y-288) > level.GroundTolerance {
+						t.Errorf("%s: Expected to stand on the ground at Y=288, got %.1f", tc.name, y)
+					}
+					return
+				}
+			}
+
+			x, y := player.GetPosition()
+			t.Errorf("%s: Player didn't reach the bottom, stopped at (%.1f, %.1f)", tc.name, x, y)
+		})
+	}
+}
+
+// TestSteepSlopeSlide verifies the player slides down steep slopes but not walkable ones
+func TestSteepSlopeSlide(t *testing.T) {
+	t.Run("45 degree slope slides", func(t *testing.T) {
+		// Centre over the middle of the 45° ramp
+		player := newSlopeTestPlayer(14*32, 272)
+		startX, _ := player.GetPosition()
+
+		slid := false
+		for i := 0; i < 120; i++ {
+			player.Update(1.0 / 60.0)
+			if player.Sliding {
+				slid = true
+			}
+		}
+
+		x, y := player.GetPosition()
+		fmt.Printf("45 degree slide: moved from X=%.1f to (%.1f, %.1f)\n", startX, x, y)
+		if !slid {
+			t.Error("Expected the player to slide on a 45 degree slope")
+		}
+		if x >= startX-8 {
+			t.Errorf("Expected the player to slide downhill (left), X went from %.1f to %.1f", startX, x)
+		}
+		if !player.OnGround || math.Abs(y-288) > level.GroundTolerance {
+			t.Errorf("Expected the player to end on the ground at Y=288, got Y=%.1f on ground=%v", y, player.OnGround)
+		}
+	})
+
+	t.Run("uphill input can't climb a steep slope", func(t *testing.T) {
+		player := newSlopeTestPlayer(12*32, 288)
+		for i := 0; i < 180; i++ {
+			player.MoveRight()
+			player.Update(1.0 / 60.0)
+		}
+
+		x, y := player.GetPosition()
+		if x > 14*32 {
+			t.Errorf("Expected the steep ramp to stop the player, got (%.1f, %.1f)", x, y)
+		}
+	})
+
+	t.Run("22.5 degree slope holds", func(t *testing.T) {
+		player := newSlopeTestPlayer(4*32, 272)
+		startX, startY := player.GetPosition()
+
+		for i := 0; i < 120; i++ {
+			player.Update(1.0 / 60.0)
+			if player.Sliding {
+				t.Fatalf("Player should not slide on a walkable slope (frame %d)", i)
+			}
+		}
+
+		x, y := player.GetPosition()
+		if math.Abs(x-startX) > 1 || math.Abs(y-startY) > 1 {
+			t.Errorf("Expected the player to stand still, moved from (%.1f, %.1f) to (%.1f, %.1f)", startX, startY, x, y)
+		}
+		if !player.OnSlope || player.SlopeGradient != -0.5 {
+			t.Errorf("Expected to be on a -0.5 gradient slope, got on slope=%v gradient=%.2f", player.OnSlope, player.SlopeGradient)
+		}
+	})
+}
+
+// TestSlopeLanding verifies falling onto a slope lands on its surface at any speed
+func TestSlopeLanding(t *testing.T) {
+	testLevel := level.CreateSlopeTestLevel()
+
+	testCases := []struct {
+		name      string
+		startY    float64
+		velocityY float64
+	}{
+		{"Slow landing", 230, 10},
+		{"Fast landing", 150, 200},
+		{"Very fast landing", 50, 400},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			player := entities.NewPlayer(3*32+16, tc.startY, entities.CreateTestSpriteSheet())
+			player.SetLevel(testLevel)
+			player.VelocityY = tc.velocityY
+
+			for i := 0; i < 200; i++ {
+				player.Update(1.0 / 60.0)
+				if player.OnGround {
+					break
+				}
+			}
+
+			_, y := player.GetPosition()
+			surface := expectedSurfaceY(testLevel, player)
+			fmt.Printf("%s: Landed with feet at %.2f, surface at %.2f\n", tc.name, y+player.Height, surface)
+			if !player.OnGround {
+				t.Fatalf("%s: Player never landed", tc.name)
+			}
+			if math.Abs(y+player.Height-surface) > level.GroundTolerance {
+				t.Errorf("%s: Feet at %.2f, expected the slope surface at %.2f", tc.name, y+player.Height, surface)
+			}
+		})
+	}
+}
+
+// TestHalfHeightTiles verifies half tiles can be stood on and block from the side
+func TestHalfHeightTiles(t *testing.T) {
+	t.Run("land on top", func(t *testing.T) {
+		player := newSlopeTestPlayer(22*32+16, 200)
+		for i := 0; i < 60; i++ {
+			player.Update(1.0 / 60.0)
+		}
+
+		_, y := player.GetPosition()
+		if !player.OnGround || math.Abs(y+player.Height-304) > level.GroundTolerance {
+			t.Errorf("Expected to stand on the half tile at feet Y=304, got feet %.2f on ground=%v", y+player.Height, player.OnGround)
+		}
+	})
+
+	t.Run("blocks walking into its side", func(t *testing.T) {
+		player := newSlopeTestPlayer(19*32, 288)
+		for i := 0; i < 120; i++ {
+			player.MoveRight()
+			player.Update(1.0 / 60.0)
+		}
+
+		x, _ := player.GetPosition()
+		if right := x + player.Width; right > 22*32+level.GroundTolerance {
+			t.Errorf("Expected the half tile's side to stop the player at X=704, right edge reached %.2f", right)
+		}
+		if !player.OnGround {
+			t.Error("Player should still be on the ground beside the half tile")
+		}
+	})
+}
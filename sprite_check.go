@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+
+	"ebiten-platformer/entities"
+)
+
+// runSpriteCheck validates a sprite sheet PNG against the ROBO-9 layout, printing
+// each issue to out. If contactSheetPath is set, a labelled contact sheet is written there
+// whether or not validation passes, so broken art can be inspected.
+func runSpriteCheck(path, contactSheetPath string, out io.Writer) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open sprite sheet %s: %w", path, err)
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		return fmt.Errorf("failed to decode sprite sheet %s: %w", path, err)
+	}
+
+	layout := entities.Robo9SpriteLayout()
+	validationErr := entities.ValidateSpriteSheet(img, layout)
+
+	if contactSheetPath != "" {
+		if err := writeContactSheet(entities.RenderContactSheet(img, layout, filepath.Base(path)), contactSheetPath); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Contact sheet written to %s\n", contactSheetPath)
+	}
+
+	var sheetErr *entities.SpriteSheetError
+	if errors.As(validationErr, &sheetErr) {
+		for _, issue := range sheetErr.Issues {
+			fmt.Fprintf(out, "  %s\n", issue)
+		}
+		return fmt.Errorf("%s does not match the ROBO-9 sprite specification (%d issue(s))", path, len(sheetErr.Issues))
+	}
+
+	fmt.Fprintf(out, "%s matches the ROBO-9 sprite specification\n", path)
+	return nil
+}
+
+// writeContactSheet encodes a contact sheet as PNG
+func writeContactSheet(sheet image.Image, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create contact sheet %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, sheet); err != nil {
+		return fmt.Errorf("failed to encode contact sheet %s: %w", path, err)
+	}
+	return nil
+}
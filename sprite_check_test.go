@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestSheet writes an opaque PNG of the given size and returns its path
+func writeTestSheet(t *testing.T, width, height int) string {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{72, 209, 204, 255})
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "player.png")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create test sheet: %v", err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, img); err != nil {
+		t.Fatalf("Failed to encode test sheet: %v", err)
+	}
+	return path
+}
+
+func TestRunSpriteCheck_Valid(t *testing.T) {
+	sheetPath := writeTestSheet(t, 192, 96)
+	contactPath := filepath.Join(t.TempDir(), "contact.png")
+
+	var out bytes.Buffer
+	if err := runSpriteCheck(sheetPath, contactPath, &out); err != nil {
+		t.Fatalf("Expected valid sheet to pass, got %v", err)
+	}
+
+	file, err := os.Open(contactPath)
+	if err != nil {
+		t.Fatalf("Contact sheet was not written: %v", err)
+	}
+	defer file.Close()
+	if _, err := png.Decode(file); err != nil {
+		t.Errorf("Contact sheet is not a valid PNG: %v", err)
+	}
+}
+
+func TestRunSpriteCheck_Invalid(t *testing.T) {
+	sheetPath := writeTestSheet(t, 128, 64)
+	contactPath := filepath.Join(t.TempDir(), "contact.png")
+
+	var out bytes.Buffer
+	err := runSpriteCheck(sheetPath, contactPath, &out)
+	if err == nil {
+		t.Fatal("Expected undersized sheet to fail validation")
+	}
+
+	if !strings.Contains(out.String(), "SheetTooSmall") {
+		t.Errorf("Expected issues to be listed, got:\n%s", out.String())
+	}
+
+	// The contact sheet is still written so the art can be inspected
+	if _, err := os.Stat(contactPath); err != nil {
+		t.Errorf("Contact sheet should be written even when validation fails: %v", err)
+	}
+}
+
+func TestRunSpriteCheck_MissingFile(t *testing.T) {
+	var out bytes.Buffer
+	if err := runSpriteCheck(filepath.Join(t.TempDir(), "missing.png"), "", &out); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
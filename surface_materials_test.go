@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"ebiten-platformer/entities"
+	"ebiten-platformer/level"
+)
+
+// newMaterialTestPlayer creates a player standing on a long floor made of one tile type
+func newMaterialTestPlayer(floor level.TileType, settle bool) *entities.Player {
+	testLevel := level.NewLevel(60, 12, 32, "Material Test")
+	for x := 0; x < testLevel.Width; x++ {
+		testLevel.SetTile(x, 10, floor)
+		testLevel.SetTile(x, 11, level.TileSolid)
+	}
+
+	player := entities.NewPlayer(100, 288, entities.CreateTestSpriteSheet())
+	player.SetLevel(testLevel)
+	if settle {
+		for i := 0; i < 10; i++ {
+			player.Update(1.0 / 60.0)
+		}
+	}
+	return player
+}
+
+// walkRight holds right for a number of frames and returns the distance covered
+func walkRight(player *entities.Player, frames int) float64 {
+	startX, _ := player.GetPosition()
+	for i := 0; i < frames; i++ {
+		player.MoveRight()
+		player.Update(1.0 / 60.0)
+	}
+	x, _ := player.GetPosition()
+	return x - startX
+}
+
+// coastDistance lets go of the controls and returns how far the player slides before stopping
+func coastDistance(player *entities.Player) float64 {
+	startX, _ := player.GetPosition()
+	for i := 0; i < 600 && math.Abs(player.VelocityX) > 1; i++ {
+		player.Update(1.0 / 60.0)
+	}
+	x, _ := player.GetPosition()
+	return x - startX
+}
+
+// jumpHeight jumps and returns the highest the player's feet got above the start
+func jumpHeight(player *entities.Player) float64 {
+	_, startY := player.GetPosition()
+	minY := startY
+	player.Jump()
+	for i := 0; i < 300; i++ {
+		player.Update(1.0 / 60.0)
+		_, y := player.GetPosition()
+		minY = math.Min(minY, y)
+		if player.OnGround && player.VelocityY == 0 {
+			break
+		}
+	}
+	return startY - minY
+}
+
+// TestIceSurface verifies ice is slow to speed up and slow to stop
+func TestIceSurface(t *testing.T) {
+	normal := newMaterialTestPlayer(level.TileSolid, true)
+	ice := newMaterialTestPlayer(level.TileIce, true)
+	if ice.GroundMaterial.Name != "ice" {
+		t.Fatalf("Expected the player to report standing on ice, got %q", ice.GroundMaterial.Name)
+	}
+
+	normalStart := walkRight(normal, 10)
+	iceStart := walkRight(ice, 10)
+	if iceStart >= normalStart*0.8 {
+		t.Errorf("Expected slower acceleration on ice: %.1f px on ice vs %.1f px normally over 10 frames", iceStart, normalStart)
+	}
+
+	// Reach full speed, then let go
+	walkRight(normal, 120)
+	walkRight(ice, 120)
+	normalCoast := coastDistance(normal)
+	iceCoast := coastDistance(ice)
+	fmt.Printf("Ice: coasted %.1f px vs %.1f px on normal ground\n", iceCoast, normalCoast)
+	if iceCoast < normalCoast*5 {
+		t.Errorf("Expected the player to slide much further on ice: %.1f px vs %.1f px", iceCoast, normalCoast)
+	}
+}
+
+// TestConveyorSurface verifies conveyors carry a standing player and slow walking against them
+func TestConveyorSurface(t *testing.T) {
+	testCases := []struct {
+		name  string
+		floor level.TileType
+		speed float64
+	}{
+		{"Right conveyor", level.TileConveyorRight, level.DefaultConveyorSpeed},
+		{"Left conveyor", level.TileConveyorLeft, -level.DefaultConveyorSpeed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			player := newMaterialTestPlayer(tc.floor, true)
+			startX, _ := player.GetPosition()
+			for i := 0; i < 60; i++ {
+				player.Update(1.0 / 60.0)
+			}
+
+			x, _ := player.GetPosition()
+			if math.Abs(x-startX-tc.speed) > 2 {
+				t.Errorf("%s: Expected to be carried %.0f px in a second, moved %.1f px", tc.name, tc.speed, x-startX)
+			}
+			if !player.OnGround {
+				t.Errorf("%s: Player should stay on the conveyor", tc.name)
+			}
+		})
+	}
+
+	normal := walkRight(newMaterialTestPlayer(level.TileSolid, true), 60)
+	against := walkRight(newMaterialTestPlayer(level.TileConveyorLeft, true), 60)
+	if math.Abs(normal-against-level.DefaultConveyorSpeed) > 2 {
+		t.Errorf("Walking against the belt should lose its speed: %.1f px vs %.1f px normally", against, normal)
+	}
+}
+
+// TestBouncePadSurface verifies bounce pads launch the player when landed or walked on
+func TestBouncePadSurface(t *testing.T) {
+	t.Run("landing launches", func(t *testing.T) {
+		player := newMaterialTestPlayer(level.TileBouncePad, false)
+		player.SetPosition(100, 200)
+
+		launched := false
+		for i := 0; i < 120 && !launched; i++ {
+			player.Update(1.0 / 60.0)
+			launched = player.VelocityY < -300
+		}
+		if !launched {
+			t.Fatal("Expected landing on the pad to launch the player")
+		}
+
+		_, startY := player.GetPosition()
+		minY := startY
+		for i := 0; i < 60; i++ {
+			player.Update(1.0 / 60.0)
+			_, y := player.GetPosition()
+			minY = math.Min(minY, y)
+		}
+		fmt.Printf("Bounce pad: launched %.1f px high\n", 288-minY)
+		if 288-minY < 120 {
+			t.Errorf("Expected a launch far higher than a jump, reached %.1f px", 288-minY)
+		}
+	})
+
+	t.Run("walking onto a pad launches", func(t *testing.T) {
+		testLevel := level.NewLevel(20, 12, 32, "Pad")
+		for x := 0; x < testLevel.Width; x++ {
+			testLevel.SetTile(x, 10, level.TileSolid)
+		}
+		testLevel.SetTile(6, 10, level.TileBouncePad)
+		testLevel.SetTile(7, 10, level.TileBouncePad)
+
+		player := entities.NewPlayer(64, 288, entities.CreateTestSpriteSheet())
+		player.SetLevel(testLevel)
+
+		for i := 0; i < 180; i++ {
+			player.MoveRight()
+			player.Update(1.0 / 60.0)
+			if player.VelocityY < -300 {
+				return
+			}
+		}
+		t.Error("Expected walking onto the pad to launch the player")
+	})
+}
+
+// TestMudSurface verifies mud slows walking and weakens jumps
+func TestMudSurface(t *testing.T) {
+	normalWalk := walkRight(newMaterialTestPlayer(level.TileSolid, true), 60)
+	mudWalk := walkRight(newMaterialTestPlayer(level.TileMud, true), 60)
+	fmt.Printf("Mud: walked %.1f px vs %.1f px normally\n", mudWalk, normalWalk)
+	if mudWalk > normalWalk*0.6 {
+		t.Errorf("Expected mud to slow walking: %.1f px vs %.1f px", mudWalk, normalWalk)
+	}
+
+	normalJump := jumpHeight(newMaterialTestPlayer(level.TileSolid, true))
+	mudJump := jumpHeight(newMaterialTestPlayer(level.TileMud, true))
+	fmt.Printf("Mud: jumped %.1f px vs %.1f px normally\n", mudJump, normalJump)
+	if mudJump > normalJump*0.5 {
+		t.Errorf("Expected mud to weaken jumps: %.1f px vs %.1f px", mudJump, normalJump)
+	}
+}